@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,11 +24,19 @@ const (
 )
 
 type PVZ struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	City             string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
+	Address          string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude         *float64               `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude        *float64               `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Timezone         string                 `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	WorkingHours     []*WorkingHours        `protobuf:"bytes,8,rep,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	Capacity         *int32                 `protobuf:"varint,9,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	Status           string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PVZ) Reset() {
@@ -74,27 +83,86 @@ func (x *PVZ) GetCity() string {
 	return ""
 }
 
-type GetPVZListResponse struct {
+func (x *PVZ) GetRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationDate
+	}
+	return nil
+}
+
+func (x *PVZ) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PVZ) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *PVZ) GetWorkingHours() []*WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *PVZ) GetCapacity() int32 {
+	if x != nil && x.Capacity != nil {
+		return *x.Capacity
+	}
+	return 0
+}
+
+func (x *PVZ) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Local "HH:MM" hours for an ISO weekday, 1 being Monday.
+type WorkingHours struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pvz           []*PVZ                 `protobuf:"bytes,1,rep,name=pvz,proto3" json:"pvz,omitempty"`
+	Weekday       int32                  `protobuf:"varint,1,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	Opens         string                 `protobuf:"bytes,2,opt,name=opens,proto3" json:"opens,omitempty"`
+	Closes        string                 `protobuf:"bytes,3,opt,name=closes,proto3" json:"closes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListResponse) Reset() {
-	*x = GetPVZListResponse{}
+func (x *WorkingHours) Reset() {
+	*x = WorkingHours{}
 	mi := &file_api_pvz_v1_pvz_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListResponse) String() string {
+func (x *WorkingHours) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListResponse) ProtoMessage() {}
+func (*WorkingHours) ProtoMessage() {}
 
-func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
+func (x *WorkingHours) ProtoReflect() protoreflect.Message {
 	mi := &file_api_pvz_v1_pvz_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -106,32 +174,4482 @@ func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
-func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WorkingHours.ProtoReflect.Descriptor instead.
+func (*WorkingHours) Descriptor() ([]byte, []int) {
 	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetPVZListResponse) GetPvz() []*PVZ {
+func (x *WorkingHours) GetWeekday() int32 {
 	if x != nil {
-		return x.Pvz
+		return x.Weekday
+	}
+	return 0
+}
+
+func (x *WorkingHours) GetOpens() string {
+	if x != nil {
+		return x.Opens
+	}
+	return ""
+}
+
+func (x *WorkingHours) GetCloses() string {
+	if x != nil {
+		return x.Closes
+	}
+	return ""
+}
+
+type WorkingHoursList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*WorkingHours        `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkingHoursList) Reset() {
+	*x = WorkingHoursList{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkingHoursList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkingHoursList) ProtoMessage() {}
+
+func (x *WorkingHoursList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkingHoursList.ProtoReflect.Descriptor instead.
+func (*WorkingHoursList) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkingHoursList) GetDays() []*WorkingHours {
+	if x != nil {
+		return x.Days
 	}
 	return nil
 }
 
-var File_api_pvz_v1_pvz_proto protoreflect.FileDescriptor
+type Reception struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	PvzId         string                 `protobuf:"bytes,3,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Manifest      *Manifest              `protobuf:"bytes,5,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_pvz_v1_pvz_proto_rawDesc = "" +
-	"\n" +
-	"\x14api/pvz/v1/pvz.proto\x12\x06pvz.v1\x1a\x1bgoogle/protobuf/empty.proto\")\n" +
-	"\x03PVZ\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04city\x18\x02 \x01(\tR\x04city\"3\n" +
-	"\x12GetPVZListResponse\x12\x1d\n" +
-	"\x03pvz\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x03pvz2N\n" +
-	"\n" +
-	"PVZService\x12@\n" +
+func (x *Reception) Reset() {
+	*x = Reception{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reception) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reception) ProtoMessage() {}
+
+func (x *Reception) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reception.ProtoReflect.Descriptor instead.
+func (*Reception) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Reception) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reception) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Reception) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *Reception) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reception) GetManifest() *Manifest {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+type ManifestItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestItem) Reset() {
+	*x = ManifestItem{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestItem) ProtoMessage() {}
+
+func (x *ManifestItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestItem.ProtoReflect.Descriptor instead.
+func (*ManifestItem) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ManifestItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ManifestItem) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Manifest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      string                 `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	Items         []*ManifestItem        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Barcodes      []string               `protobuf:"bytes,3,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Manifest) Reset() {
+	*x = Manifest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Manifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Manifest) ProtoMessage() {}
+
+func (x *Manifest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Manifest.ProtoReflect.Descriptor instead.
+func (*Manifest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Manifest) GetSupplier() string {
+	if x != nil {
+		return x.Supplier
+	}
+	return ""
+}
+
+func (x *Manifest) GetItems() []*ManifestItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Manifest) GetBarcodes() []string {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+type DiscrepancyLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Expected      int32                  `protobuf:"varint,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	Received      int32                  `protobuf:"varint,3,opt,name=received,proto3" json:"received,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscrepancyLine) Reset() {
+	*x = DiscrepancyLine{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscrepancyLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscrepancyLine) ProtoMessage() {}
+
+func (x *DiscrepancyLine) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscrepancyLine.ProtoReflect.Descriptor instead.
+func (*DiscrepancyLine) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DiscrepancyLine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DiscrepancyLine) GetExpected() int32 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *DiscrepancyLine) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+// status is matched, discrepancies or accepted.
+type ReceptionReport struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId        string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	PvzId              string                 `protobuf:"bytes,2,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Supplier           string                 `protobuf:"bytes,3,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Missing            []*DiscrepancyLine     `protobuf:"bytes,5,rep,name=missing,proto3" json:"missing,omitempty"`
+	Surplus            []*DiscrepancyLine     `protobuf:"bytes,6,rep,name=surplus,proto3" json:"surplus,omitempty"`
+	Unexpected         []*DiscrepancyLine     `protobuf:"bytes,7,rep,name=unexpected,proto3" json:"unexpected,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AcceptedBy         string                 `protobuf:"bytes,9,opt,name=accepted_by,json=acceptedBy,proto3" json:"accepted_by,omitempty"`
+	AcceptedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
+	Comment            string                 `protobuf:"bytes,11,opt,name=comment,proto3" json:"comment,omitempty"`
+	MissingBarcodes    []string               `protobuf:"bytes,12,rep,name=missing_barcodes,json=missingBarcodes,proto3" json:"missing_barcodes,omitempty"`
+	UnexpectedBarcodes []string               `protobuf:"bytes,13,rep,name=unexpected_barcodes,json=unexpectedBarcodes,proto3" json:"unexpected_barcodes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReceptionReport) Reset() {
+	*x = ReceptionReport{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionReport) ProtoMessage() {}
+
+func (x *ReceptionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionReport.ProtoReflect.Descriptor instead.
+func (*ReceptionReport) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReceptionReport) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *ReceptionReport) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *ReceptionReport) GetSupplier() string {
+	if x != nil {
+		return x.Supplier
+	}
+	return ""
+}
+
+func (x *ReceptionReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReceptionReport) GetMissing() []*DiscrepancyLine {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *ReceptionReport) GetSurplus() []*DiscrepancyLine {
+	if x != nil {
+		return x.Surplus
+	}
+	return nil
+}
+
+func (x *ReceptionReport) GetUnexpected() []*DiscrepancyLine {
+	if x != nil {
+		return x.Unexpected
+	}
+	return nil
+}
+
+func (x *ReceptionReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReceptionReport) GetAcceptedBy() string {
+	if x != nil {
+		return x.AcceptedBy
+	}
+	return ""
+}
+
+func (x *ReceptionReport) GetAcceptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return nil
+}
+
+func (x *ReceptionReport) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ReceptionReport) GetMissingBarcodes() []string {
+	if x != nil {
+		return x.MissingBarcodes
+	}
+	return nil
+}
+
+func (x *ReceptionReport) GetUnexpectedBarcodes() []string {
+	if x != nil {
+		return x.UnexpectedBarcodes
+	}
+	return nil
+}
+
+type Product struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	ReceptionId   string                 `protobuf:"bytes,4,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	Barcode       string                 `protobuf:"bytes,5,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Sku           string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"`
+	WeightGrams   *int32                 `protobuf:"varint,7,opt,name=weight_grams,json=weightGrams,proto3,oneof" json:"weight_grams,omitempty"`
+	Dimensions    *Dimensions            `protobuf:"bytes,8,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Product) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Product) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *Product) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetWeightGrams() int32 {
+	if x != nil && x.WeightGrams != nil {
+		return *x.WeightGrams
+	}
+	return 0
+}
+
+func (x *Product) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+// Sizes are in millimetres.
+type Dimensions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LengthMm      int32                  `protobuf:"varint,1,opt,name=length_mm,json=lengthMm,proto3" json:"length_mm,omitempty"`
+	WidthMm       int32                  `protobuf:"varint,2,opt,name=width_mm,json=widthMm,proto3" json:"width_mm,omitempty"`
+	HeightMm      int32                  `protobuf:"varint,3,opt,name=height_mm,json=heightMm,proto3" json:"height_mm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimensions) Reset() {
+	*x = Dimensions{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimensions) ProtoMessage() {}
+
+func (x *Dimensions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Dimensions) GetLengthMm() int32 {
+	if x != nil {
+		return x.LengthMm
+	}
+	return 0
+}
+
+func (x *Dimensions) GetWidthMm() int32 {
+	if x != nil {
+		return x.WidthMm
+	}
+	return 0
+}
+
+func (x *Dimensions) GetHeightMm() int32 {
+	if x != nil {
+		return x.HeightMm
+	}
+	return 0
+}
+
+type BarcodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Barcode       string                 `protobuf:"bytes,1,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BarcodeRequest) Reset() {
+	*x = BarcodeRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BarcodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BarcodeRequest) ProtoMessage() {}
+
+func (x *BarcodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BarcodeRequest.ProtoReflect.Descriptor instead.
+func (*BarcodeRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BarcodeRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type ProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductsResponse) Reset() {
+	*x = ProductsResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductsResponse) ProtoMessage() {}
+
+func (x *ProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductsResponse.ProtoReflect.Descriptor instead.
+func (*ProductsResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type GetPVZListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvz           []*PVZ                 `protobuf:"bytes,1,rep,name=pvz,proto3" json:"pvz,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListResponse) Reset() {
+	*x = GetPVZListResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListResponse) ProtoMessage() {}
+
+func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
+func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPVZListResponse) GetPvz() []*PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+type ListPVZRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Page      int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit     int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor from next_cursor of the previous response; cannot be combined
+	// with page > 1.
+	After         string `protobuf:"bytes,5,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZRequest) Reset() {
+	*x = ListPVZRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZRequest) ProtoMessage() {}
+
+func (x *ListPVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZRequest.ProtoReflect.Descriptor instead.
+func (*ListPVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPVZRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *ListPVZRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *ListPVZRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPVZRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPVZRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type ReceptionWithProducts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reception     *Reception             `protobuf:"bytes,1,opt,name=reception,proto3" json:"reception,omitempty"`
+	Products      []*Product             `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionWithProducts) Reset() {
+	*x = ReceptionWithProducts{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionWithProducts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionWithProducts) ProtoMessage() {}
+
+func (x *ReceptionWithProducts) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionWithProducts.ProtoReflect.Descriptor instead.
+func (*ReceptionWithProducts) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReceptionWithProducts) GetReception() *Reception {
+	if x != nil {
+		return x.Reception
+	}
+	return nil
+}
+
+func (x *ReceptionWithProducts) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type PVZWithReceptions struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Pvz           *PVZ                     `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	Receptions    []*ReceptionWithProducts `protobuf:"bytes,2,rep,name=receptions,proto3" json:"receptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PVZWithReceptions) Reset() {
+	*x = PVZWithReceptions{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZWithReceptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZWithReceptions) ProtoMessage() {}
+
+func (x *PVZWithReceptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZWithReceptions.ProtoReflect.Descriptor instead.
+func (*PVZWithReceptions) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PVZWithReceptions) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *PVZWithReceptions) GetReceptions() []*ReceptionWithProducts {
+	if x != nil {
+		return x.Receptions
+	}
+	return nil
+}
+
+type ListPVZResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*PVZWithReceptions   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZResponse) Reset() {
+	*x = ListPVZResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZResponse) ProtoMessage() {}
+
+func (x *ListPVZResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZResponse.ProtoReflect.Descriptor instead.
+func (*ListPVZResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPVZResponse) GetItems() []*PVZWithReceptions {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListPVZResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreatePVZRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,3,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,4,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	WorkingHours  []*WorkingHours        `protobuf:"bytes,6,rep,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	Capacity      *int32                 `protobuf:"varint,7,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePVZRequest) Reset() {
+	*x = CreatePVZRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePVZRequest) ProtoMessage() {}
+
+func (x *CreatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePVZRequest.ProtoReflect.Descriptor instead.
+func (*CreatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreatePVZRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *CreatePVZRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *CreatePVZRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetWorkingHours() []*WorkingHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *CreatePVZRequest) GetCapacity() int32 {
+	if x != nil && x.Capacity != nil {
+		return *x.Capacity
+	}
+	return 0
+}
+
+// Only the fields that are set are changed.
+type UpdatePVZRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	City          *string                `protobuf:"bytes,2,opt,name=city,proto3,oneof" json:"city,omitempty"`
+	Address       *string                `protobuf:"bytes,3,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,4,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,5,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Timezone      *string                `protobuf:"bytes,6,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	WorkingHours  *WorkingHoursList      `protobuf:"bytes,7,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	Capacity      *int32                 `protobuf:"varint,8,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	Status        *string                `protobuf:"bytes,9,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePVZRequest) Reset() {
+	*x = UpdatePVZRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePVZRequest) ProtoMessage() {}
+
+func (x *UpdatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePVZRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdatePVZRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetCity() string {
+	if x != nil && x.City != nil {
+		return *x.City
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdatePVZRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdatePVZRequest) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdatePVZRequest) GetWorkingHours() *WorkingHoursList {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *UpdatePVZRequest) GetCapacity() int32 {
+	if x != nil && x.Capacity != nil {
+		return *x.Capacity
+	}
+	return 0
+}
+
+func (x *UpdatePVZRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+type DeactivatePVZRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivatePVZRequest) Reset() {
+	*x = DeactivatePVZRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivatePVZRequest) ProtoMessage() {}
+
+func (x *DeactivatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivatePVZRequest.ProtoReflect.Descriptor instead.
+func (*DeactivatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeactivatePVZRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+// radius_km defaults to 5 and limit to 10 when unset.
+type NearbyPVZRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RadiusKm      float64                `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	OpenOnly      bool                   `protobuf:"varint,5,opt,name=open_only,json=openOnly,proto3" json:"open_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyPVZRequest) Reset() {
+	*x = NearbyPVZRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPVZRequest) ProtoMessage() {}
+
+func (x *NearbyPVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPVZRequest.ProtoReflect.Descriptor instead.
+func (*NearbyPVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NearbyPVZRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *NearbyPVZRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *NearbyPVZRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *NearbyPVZRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *NearbyPVZRequest) GetOpenOnly() bool {
+	if x != nil {
+		return x.OpenOnly
+	}
+	return false
+}
+
+type NearbyPVZ struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvz           *PVZ                   `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	DistanceKm    float64                `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	OpenNow       bool                   `protobuf:"varint,3,opt,name=open_now,json=openNow,proto3" json:"open_now,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyPVZ) Reset() {
+	*x = NearbyPVZ{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPVZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPVZ) ProtoMessage() {}
+
+func (x *NearbyPVZ) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPVZ.ProtoReflect.Descriptor instead.
+func (*NearbyPVZ) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NearbyPVZ) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *NearbyPVZ) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *NearbyPVZ) GetOpenNow() bool {
+	if x != nil {
+		return x.OpenNow
+	}
+	return false
+}
+
+type NearbyPVZResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*NearbyPVZ           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyPVZResponse) Reset() {
+	*x = NearbyPVZResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPVZResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPVZResponse) ProtoMessage() {}
+
+func (x *NearbyPVZResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPVZResponse.ProtoReflect.Descriptor instead.
+func (*NearbyPVZResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NearbyPVZResponse) GetItems() []*NearbyPVZ {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type OpenReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Manifest      *Manifest              `protobuf:"bytes,2,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenReceptionRequest) Reset() {
+	*x = OpenReceptionRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenReceptionRequest) ProtoMessage() {}
+
+func (x *OpenReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenReceptionRequest.ProtoReflect.Descriptor instead.
+func (*OpenReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OpenReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *OpenReceptionRequest) GetManifest() *Manifest {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+type AddProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Barcode       string                 `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	WeightGrams   *int32                 `protobuf:"varint,5,opt,name=weight_grams,json=weightGrams,proto3,oneof" json:"weight_grams,omitempty"`
+	Dimensions    *Dimensions            `protobuf:"bytes,6,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductRequest) Reset() {
+	*x = AddProductRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductRequest) ProtoMessage() {}
+
+func (x *AddProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductRequest.ProtoReflect.Descriptor instead.
+func (*AddProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetWeightGrams() int32 {
+	if x != nil && x.WeightGrams != nil {
+		return *x.WeightGrams
+	}
+	return 0
+}
+
+func (x *AddProductRequest) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+// One product of a scanner batch per message; pvz_id and partial are taken
+// from the first message.
+type AddProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Partial       bool                   `protobuf:"varint,2,opt,name=partial,proto3" json:"partial,omitempty"`
+	Product       *NewProduct            `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductsRequest) Reset() {
+	*x = AddProductsRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductsRequest) ProtoMessage() {}
+
+func (x *AddProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductsRequest.ProtoReflect.Descriptor instead.
+func (*AddProductsRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddProductsRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *AddProductsRequest) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *AddProductsRequest) GetProduct() *NewProduct {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type NewProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Barcode       string                 `protobuf:"bytes,2,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	WeightGrams   *int32                 `protobuf:"varint,4,opt,name=weight_grams,json=weightGrams,proto3,oneof" json:"weight_grams,omitempty"`
+	Dimensions    *Dimensions            `protobuf:"bytes,5,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewProduct) Reset() {
+	*x = NewProduct{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewProduct) ProtoMessage() {}
+
+func (x *NewProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewProduct.ProtoReflect.Descriptor instead.
+func (*NewProduct) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *NewProduct) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NewProduct) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *NewProduct) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *NewProduct) GetWeightGrams() int32 {
+	if x != nil && x.WeightGrams != nil {
+		return *x.WeightGrams
+	}
+	return 0
+}
+
+func (x *NewProduct) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+// status is added, failed or skipped; skipped items were valid but their
+// batch was rejected as a whole.
+type BatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Product       *Product               `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BatchItem) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchItem) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *BatchItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AddProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int32                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Failed        int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Items         []*BatchItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductsResponse) Reset() {
+	*x = AddProductsResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductsResponse) ProtoMessage() {}
+
+func (x *AddProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductsResponse.ProtoReflect.Descriptor instead.
+func (*AddProductsResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddProductsResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *AddProductsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *AddProductsResponse) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type DeleteLastProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLastProductRequest) Reset() {
+	*x = DeleteLastProductRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLastProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLastProductRequest) ProtoMessage() {}
+
+func (x *DeleteLastProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLastProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLastProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteLastProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+// Exactly one of product_id and barcode is set.
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Barcode       string                 `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *DeleteProductRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeleteProductRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+type CorrectProductTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorrectProductTypeRequest) Reset() {
+	*x = CorrectProductTypeRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrectProductTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrectProductTypeRequest) ProtoMessage() {}
+
+func (x *CorrectProductTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrectProductTypeRequest.ProtoReflect.Descriptor instead.
+func (*CorrectProductTypeRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CorrectProductTypeRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *CorrectProductTypeRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CorrectProductTypeRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// A steps of 0 undoes or redoes one operation.
+type ProductOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Steps         int32                  `protobuf:"varint,2,opt,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductOperationsRequest) Reset() {
+	*x = ProductOperationsRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductOperationsRequest) ProtoMessage() {}
+
+func (x *ProductOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ProductOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ProductOperationsRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *ProductOperationsRequest) GetSteps() int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+type ListProductOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductOperationsRequest) Reset() {
+	*x = ListProductOperationsRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductOperationsRequest) ProtoMessage() {}
+
+func (x *ListProductOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListProductOperationsRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+// An entry of the change log of a reception's products; undo and redo
+// entries refer to the operation they act on in target_id.
+type ProductOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReceptionId   string                 `protobuf:"bytes,2,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Product       *Product               `protobuf:"bytes,4,opt,name=product,proto3" json:"product,omitempty"`
+	PreviousType  string                 `protobuf:"bytes,5,opt,name=previous_type,json=previousType,proto3" json:"previous_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,6,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	State         string                 `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	UserId        string                 `protobuf:"bytes,8,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ApiKeyId      string                 `protobuf:"bytes,9,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductOperation) Reset() {
+	*x = ProductOperation{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductOperation) ProtoMessage() {}
+
+func (x *ProductOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductOperation.ProtoReflect.Descriptor instead.
+func (*ProductOperation) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ProductOperation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ProductOperation) GetPreviousType() string {
+	if x != nil {
+		return x.PreviousType
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+func (x *ProductOperation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ProductOperationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*ProductOperation    `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductOperationsResponse) Reset() {
+	*x = ProductOperationsResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductOperationsResponse) ProtoMessage() {}
+
+func (x *ProductOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ProductOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ProductOperationsResponse) GetOperations() []*ProductOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type CloseReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseReceptionRequest) Reset() {
+	*x = CloseReceptionRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseReceptionRequest) ProtoMessage() {}
+
+func (x *CloseReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CloseReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CloseReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type ReceptionReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionReportRequest) Reset() {
+	*x = ReceptionReportRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionReportRequest) ProtoMessage() {}
+
+func (x *ReceptionReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionReportRequest.ProtoReflect.Descriptor instead.
+func (*ReceptionReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReceptionReportRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type AcceptReceptionReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptReceptionReportRequest) Reset() {
+	*x = AcceptReceptionReportRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptReceptionReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptReceptionReportRequest) ProtoMessage() {}
+
+func (x *AcceptReceptionReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptReceptionReportRequest.ProtoReflect.Descriptor instead.
+func (*AcceptReceptionReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AcceptReceptionReportRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *AcceptReceptionReportRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type TokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenResponse) Reset() {
+	*x = TokenResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenResponse) ProtoMessage() {}
+
+func (x *TokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenResponse.ProtoReflect.Descriptor instead.
+func (*TokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DisabledAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetDisabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type ChangeUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeUserRoleRequest) Reset() {
+	*x = ChangeUserRoleRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeUserRoleRequest) ProtoMessage() {}
+
+func (x *ChangeUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*ChangeUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ChangeUserRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangeUserRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UserIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserIdRequest) Reset() {
+	*x = UserIdRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserIdRequest) ProtoMessage() {}
+
+func (x *UserIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserIdRequest.ProtoReflect.Descriptor instead.
+func (*UserIdRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *UserIdRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ResetUserPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetUserPasswordRequest) Reset() {
+	*x = ResetUserPasswordRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetUserPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetUserPasswordRequest) ProtoMessage() {}
+
+func (x *ResetUserPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetUserPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetUserPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ResetUserPasswordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ResetUserPasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type InviteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteUserRequest) Reset() {
+	*x = InviteUserRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteUserRequest) ProtoMessage() {}
+
+func (x *InviteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteUserRequest.ProtoReflect.Descriptor instead.
+func (*InviteUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *InviteUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteUserRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type Invite struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email           string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role            string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ActivationToken string                 `protobuf:"bytes,5,opt,name=activation_token,json=activationToken,proto3" json:"activation_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Invite) Reset() {
+	*x = Invite{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invite) ProtoMessage() {}
+
+func (x *Invite) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invite.ProtoReflect.Descriptor instead.
+func (*Invite) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Invite) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invite) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Invite) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Invite) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Invite) GetActivationToken() string {
+	if x != nil {
+		return x.ActivationToken
+	}
+	return ""
+}
+
+type AcceptInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInviteRequest) Reset() {
+	*x = AcceptInviteRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInviteRequest) ProtoMessage() {}
+
+func (x *AcceptInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInviteRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInviteRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AcceptInviteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AcceptInviteRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ListPVZEmployeesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPVZEmployeesRequest) Reset() {
+	*x = ListPVZEmployeesRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPVZEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPVZEmployeesRequest) ProtoMessage() {}
+
+func (x *ListPVZEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPVZEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*ListPVZEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListPVZEmployeesRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type EmployeeAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeAssignmentRequest) Reset() {
+	*x = EmployeeAssignmentRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeAssignmentRequest) ProtoMessage() {}
+
+func (x *EmployeeAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*EmployeeAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EmployeeAssignmentRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *EmployeeAssignmentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type APIKey struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix     string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Scopes     []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedBy  string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	// Set only when the key is created or rotated.
+	Key           string `protobuf:"bytes,10,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*APIKey              `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListAPIKeysResponse) GetKeys() []*APIKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type APIKeyIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKeyIdRequest) Reset() {
+	*x = APIKeyIdRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKeyIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyIdRequest) ProtoMessage() {}
+
+func (x *APIKeyIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyIdRequest.ProtoReflect.Descriptor instead.
+func (*APIKeyIdRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *APIKeyIdRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type City struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *City) Reset() {
+	*x = City{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *City) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*City) ProtoMessage() {}
+
+func (x *City) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *City) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *City) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListCitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cities        []*City                `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCitiesResponse) Reset() {
+	*x = ListCitiesResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCitiesResponse) ProtoMessage() {}
+
+func (x *ListCitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListCitiesResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListCitiesResponse) GetCities() []*City {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+type CreateCityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCityRequest) Reset() {
+	*x = CreateCityRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCityRequest) ProtoMessage() {}
+
+func (x *CreateCityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCityRequest.ProtoReflect.Descriptor instead.
+func (*CreateCityRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateCityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameCityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameCityRequest) Reset() {
+	*x = RenameCityRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameCityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCityRequest) ProtoMessage() {}
+
+func (x *RenameCityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameCityRequest.ProtoReflect.Descriptor instead.
+func (*RenameCityRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RenameCityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenameCityRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type DeleteCityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCityRequest) Reset() {
+	*x = DeleteCityRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCityRequest) ProtoMessage() {}
+
+func (x *DeleteCityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCityRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteCityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// A deprecated type keeps its products but cannot be used for new ones.
+type ProductType struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fragile         bool                   `protobuf:"varint,2,opt,name=fragile,proto3" json:"fragile,omitempty"`
+	RequiresIdCheck bool                   `protobuf:"varint,3,opt,name=requires_id_check,json=requiresIdCheck,proto3" json:"requires_id_check,omitempty"`
+	MaxWeightGrams  *int32                 `protobuf:"varint,4,opt,name=max_weight_grams,json=maxWeightGrams,proto3,oneof" json:"max_weight_grams,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeprecatedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deprecated_at,json=deprecatedAt,proto3" json:"deprecated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProductType) Reset() {
+	*x = ProductType{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductType) ProtoMessage() {}
+
+func (x *ProductType) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductType.ProtoReflect.Descriptor instead.
+func (*ProductType) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ProductType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductType) GetFragile() bool {
+	if x != nil {
+		return x.Fragile
+	}
+	return false
+}
+
+func (x *ProductType) GetRequiresIdCheck() bool {
+	if x != nil {
+		return x.RequiresIdCheck
+	}
+	return false
+}
+
+func (x *ProductType) GetMaxWeightGrams() int32 {
+	if x != nil && x.MaxWeightGrams != nil {
+		return *x.MaxWeightGrams
+	}
+	return 0
+}
+
+func (x *ProductType) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProductType) GetDeprecatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeprecatedAt
+	}
+	return nil
+}
+
+type ListProductTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductTypes  []*ProductType         `protobuf:"bytes,1,rep,name=product_types,json=productTypes,proto3" json:"product_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductTypesResponse) Reset() {
+	*x = ListProductTypesResponse{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductTypesResponse) ProtoMessage() {}
+
+func (x *ListProductTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListProductTypesResponse) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListProductTypesResponse) GetProductTypes() []*ProductType {
+	if x != nil {
+		return x.ProductTypes
+	}
+	return nil
+}
+
+type CreateProductTypeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fragile         bool                   `protobuf:"varint,2,opt,name=fragile,proto3" json:"fragile,omitempty"`
+	RequiresIdCheck bool                   `protobuf:"varint,3,opt,name=requires_id_check,json=requiresIdCheck,proto3" json:"requires_id_check,omitempty"`
+	MaxWeightGrams  *int32                 `protobuf:"varint,4,opt,name=max_weight_grams,json=maxWeightGrams,proto3,oneof" json:"max_weight_grams,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateProductTypeRequest) Reset() {
+	*x = CreateProductTypeRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductTypeRequest) ProtoMessage() {}
+
+func (x *CreateProductTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductTypeRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductTypeRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CreateProductTypeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProductTypeRequest) GetFragile() bool {
+	if x != nil {
+		return x.Fragile
+	}
+	return false
+}
+
+func (x *CreateProductTypeRequest) GetRequiresIdCheck() bool {
+	if x != nil {
+		return x.RequiresIdCheck
+	}
+	return false
+}
+
+func (x *CreateProductTypeRequest) GetMaxWeightGrams() int32 {
+	if x != nil && x.MaxWeightGrams != nil {
+		return *x.MaxWeightGrams
+	}
+	return 0
+}
+
+// max_weight_grams = 0 removes the limit.
+type UpdateProductTypeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fragile         *bool                  `protobuf:"varint,2,opt,name=fragile,proto3,oneof" json:"fragile,omitempty"`
+	RequiresIdCheck *bool                  `protobuf:"varint,3,opt,name=requires_id_check,json=requiresIdCheck,proto3,oneof" json:"requires_id_check,omitempty"`
+	MaxWeightGrams  *int32                 `protobuf:"varint,4,opt,name=max_weight_grams,json=maxWeightGrams,proto3,oneof" json:"max_weight_grams,omitempty"`
+	Deprecated      *bool                  `protobuf:"varint,5,opt,name=deprecated,proto3,oneof" json:"deprecated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProductTypeRequest) Reset() {
+	*x = UpdateProductTypeRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductTypeRequest) ProtoMessage() {}
+
+func (x *UpdateProductTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductTypeRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *UpdateProductTypeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductTypeRequest) GetFragile() bool {
+	if x != nil && x.Fragile != nil {
+		return *x.Fragile
+	}
+	return false
+}
+
+func (x *UpdateProductTypeRequest) GetRequiresIdCheck() bool {
+	if x != nil && x.RequiresIdCheck != nil {
+		return *x.RequiresIdCheck
+	}
+	return false
+}
+
+func (x *UpdateProductTypeRequest) GetMaxWeightGrams() int32 {
+	if x != nil && x.MaxWeightGrams != nil {
+		return *x.MaxWeightGrams
+	}
+	return 0
+}
+
+func (x *UpdateProductTypeRequest) GetDeprecated() bool {
+	if x != nil && x.Deprecated != nil {
+		return *x.Deprecated
+	}
+	return false
+}
+
+type DeleteProductTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductTypeRequest) Reset() {
+	*x = DeleteProductTypeRequest{}
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductTypeRequest) ProtoMessage() {}
+
+func (x *DeleteProductTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_pvz_v1_pvz_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductTypeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductTypeRequest) Descriptor() ([]byte, []int) {
+	return file_api_pvz_v1_pvz_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeleteProductTypeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_api_pvz_v1_pvz_proto protoreflect.FileDescriptor
+
+const file_api_pvz_v1_pvz_proto_rawDesc = "" +
+	"\n" +
+	"\x14api/pvz/v1/pvz.proto\x12\x06pvz.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x03\n" +
+	"\x03PVZ\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12G\n" +
+	"\x11registration_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x1a\n" +
+	"\btimezone\x18\a \x01(\tR\btimezone\x129\n" +
+	"\rworking_hours\x18\b \x03(\v2\x14.pvz.v1.WorkingHoursR\fworkingHours\x12\x1f\n" +
+	"\bcapacity\x18\t \x01(\x05H\x02R\bcapacity\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06statusB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_capacity\"V\n" +
+	"\fWorkingHours\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\x05R\aweekday\x12\x14\n" +
+	"\x05opens\x18\x02 \x01(\tR\x05opens\x12\x16\n" +
+	"\x06closes\x18\x03 \x01(\tR\x06closes\"<\n" +
+	"\x10WorkingHoursList\x12(\n" +
+	"\x04days\x18\x01 \x03(\v2\x14.pvz.v1.WorkingHoursR\x04days\"\xb1\x01\n" +
+	"\tReception\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x15\n" +
+	"\x06pvz_id\x18\x03 \x01(\tR\x05pvzId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12,\n" +
+	"\bmanifest\x18\x05 \x01(\v2\x10.pvz.v1.ManifestR\bmanifest\"8\n" +
+	"\fManifestItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"n\n" +
+	"\bManifest\x12\x1a\n" +
+	"\bsupplier\x18\x01 \x01(\tR\bsupplier\x12*\n" +
+	"\x05items\x18\x02 \x03(\v2\x14.pvz.v1.ManifestItemR\x05items\x12\x1a\n" +
+	"\bbarcodes\x18\x03 \x03(\tR\bbarcodes\"]\n" +
+	"\x0fDiscrepancyLine\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bexpected\x18\x02 \x01(\x05R\bexpected\x12\x1a\n" +
+	"\breceived\x18\x03 \x01(\x05R\breceived\"\xad\x04\n" +
+	"\x0fReceptionReport\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\x12\x15\n" +
+	"\x06pvz_id\x18\x02 \x01(\tR\x05pvzId\x12\x1a\n" +
+	"\bsupplier\x18\x03 \x01(\tR\bsupplier\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x121\n" +
+	"\amissing\x18\x05 \x03(\v2\x17.pvz.v1.DiscrepancyLineR\amissing\x121\n" +
+	"\asurplus\x18\x06 \x03(\v2\x17.pvz.v1.DiscrepancyLineR\asurplus\x127\n" +
+	"\n" +
+	"unexpected\x18\a \x03(\v2\x17.pvz.v1.DiscrepancyLineR\n" +
+	"unexpected\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vaccepted_by\x18\t \x01(\tR\n" +
+	"acceptedBy\x12;\n" +
+	"\vaccepted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acceptedAt\x12\x18\n" +
+	"\acomment\x18\v \x01(\tR\acomment\x12)\n" +
+	"\x10missing_barcodes\x18\f \x03(\tR\x0fmissingBarcodes\x12/\n" +
+	"\x13unexpected_barcodes\x18\r \x03(\tR\x12unexpectedBarcodes\"\xa2\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12!\n" +
+	"\freception_id\x18\x04 \x01(\tR\vreceptionId\x12\x18\n" +
+	"\abarcode\x18\x05 \x01(\tR\abarcode\x12\x10\n" +
+	"\x03sku\x18\x06 \x01(\tR\x03sku\x12&\n" +
+	"\fweight_grams\x18\a \x01(\x05H\x00R\vweightGrams\x88\x01\x01\x122\n" +
+	"\n" +
+	"dimensions\x18\b \x01(\v2\x12.pvz.v1.DimensionsR\n" +
+	"dimensionsB\x0f\n" +
+	"\r_weight_grams\"a\n" +
+	"\n" +
+	"Dimensions\x12\x1b\n" +
+	"\tlength_mm\x18\x01 \x01(\x05R\blengthMm\x12\x19\n" +
+	"\bwidth_mm\x18\x02 \x01(\x05R\awidthMm\x12\x1b\n" +
+	"\theight_mm\x18\x03 \x01(\x05R\bheightMm\"*\n" +
+	"\x0eBarcodeRequest\x12\x18\n" +
+	"\abarcode\x18\x01 \x01(\tR\abarcode\"?\n" +
+	"\x10ProductsResponse\x12+\n" +
+	"\bproducts\x18\x01 \x03(\v2\x0f.pvz.v1.ProductR\bproducts\"3\n" +
+	"\x12GetPVZListResponse\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x03pvz\"\xc2\x01\n" +
+	"\x0eListPVZRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05after\x18\x05 \x01(\tR\x05after\"u\n" +
+	"\x15ReceptionWithProducts\x12/\n" +
+	"\treception\x18\x01 \x01(\v2\x11.pvz.v1.ReceptionR\treception\x12+\n" +
+	"\bproducts\x18\x02 \x03(\v2\x0f.pvz.v1.ProductR\bproducts\"q\n" +
+	"\x11PVZWithReceptions\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12=\n" +
+	"\n" +
+	"receptions\x18\x02 \x03(\v2\x1d.pvz.v1.ReceptionWithProductsR\n" +
+	"receptions\"c\n" +
+	"\x0fListPVZResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.pvz.v1.PVZWithReceptionsR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xa4\x02\n" +
+	"\x10CreatePVZRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1f\n" +
+	"\blatitude\x18\x03 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x04 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x129\n" +
+	"\rworking_hours\x18\x06 \x03(\v2\x14.pvz.v1.WorkingHoursR\fworkingHours\x12\x1f\n" +
+	"\bcapacity\x18\a \x01(\x05H\x02R\bcapacity\x88\x01\x01B\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_capacity\"\x98\x03\n" +
+	"\x10UpdatePVZRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x17\n" +
+	"\x04city\x18\x02 \x01(\tH\x00R\x04city\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x03 \x01(\tH\x01R\aaddress\x88\x01\x01\x12\x1f\n" +
+	"\blatitude\x18\x04 \x01(\x01H\x02R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x05 \x01(\x01H\x03R\tlongitude\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\x06 \x01(\tH\x04R\btimezone\x88\x01\x01\x12=\n" +
+	"\rworking_hours\x18\a \x01(\v2\x18.pvz.v1.WorkingHoursListR\fworkingHours\x12\x1f\n" +
+	"\bcapacity\x18\b \x01(\x05H\x05R\bcapacity\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\t \x01(\tH\x06R\x06status\x88\x01\x01B\a\n" +
+	"\x05_cityB\n" +
+	"\n" +
+	"\b_addressB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\v\n" +
+	"\t_timezoneB\v\n" +
+	"\t_capacityB\t\n" +
+	"\a_status\"-\n" +
+	"\x14DeactivatePVZRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"\x9c\x01\n" +
+	"\x10NearbyPVZRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1b\n" +
+	"\tradius_km\x18\x03 \x01(\x01R\bradiusKm\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\topen_only\x18\x05 \x01(\bR\bopenOnly\"f\n" +
+	"\tNearbyPVZ\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12\x1f\n" +
+	"\vdistance_km\x18\x02 \x01(\x01R\n" +
+	"distanceKm\x12\x19\n" +
+	"\bopen_now\x18\x03 \x01(\bR\aopenNow\"<\n" +
+	"\x11NearbyPVZResponse\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.pvz.v1.NearbyPVZR\x05items\"[\n" +
+	"\x14OpenReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12,\n" +
+	"\bmanifest\x18\x02 \x01(\v2\x10.pvz.v1.ManifestR\bmanifest\"\xd7\x01\n" +
+	"\x11AddProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\abarcode\x18\x03 \x01(\tR\abarcode\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\x12&\n" +
+	"\fweight_grams\x18\x05 \x01(\x05H\x00R\vweightGrams\x88\x01\x01\x122\n" +
+	"\n" +
+	"dimensions\x18\x06 \x01(\v2\x12.pvz.v1.DimensionsR\n" +
+	"dimensionsB\x0f\n" +
+	"\r_weight_grams\"s\n" +
+	"\x12AddProductsRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x18\n" +
+	"\apartial\x18\x02 \x01(\bR\apartial\x12,\n" +
+	"\aproduct\x18\x03 \x01(\v2\x12.pvz.v1.NewProductR\aproduct\"\xb9\x01\n" +
+	"\n" +
+	"NewProduct\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\abarcode\x18\x02 \x01(\tR\abarcode\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12&\n" +
+	"\fweight_grams\x18\x04 \x01(\x05H\x00R\vweightGrams\x88\x01\x01\x122\n" +
+	"\n" +
+	"dimensions\x18\x05 \x01(\v2\x12.pvz.v1.DimensionsR\n" +
+	"dimensionsB\x0f\n" +
+	"\r_weight_grams\"z\n" +
+	"\tBatchItem\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12)\n" +
+	"\aproduct\x18\x03 \x01(\v2\x0f.pvz.v1.ProductR\aproduct\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"l\n" +
+	"\x13AddProductsResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12'\n" +
+	"\x05items\x18\x03 \x03(\v2\x11.pvz.v1.BatchItemR\x05items\"1\n" +
+	"\x18DeleteLastProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"f\n" +
+	"\x14DeleteProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x18\n" +
+	"\abarcode\x18\x03 \x01(\tR\abarcode\"e\n" +
+	"\x19CorrectProductTypeRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"G\n" +
+	"\x18ProductOperationsRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x14\n" +
+	"\x05steps\x18\x02 \x01(\x05R\x05steps\"5\n" +
+	"\x1cListProductOperationsRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"\xce\x02\n" +
+	"\x10ProductOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\freception_id\x18\x02 \x01(\tR\vreceptionId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12)\n" +
+	"\aproduct\x18\x04 \x01(\v2\x0f.pvz.v1.ProductR\aproduct\x12#\n" +
+	"\rprevious_type\x18\x05 \x01(\tR\fpreviousType\x12\x1b\n" +
+	"\ttarget_id\x18\x06 \x01(\tR\btargetId\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12\x17\n" +
+	"\auser_id\x18\b \x01(\tR\x06userId\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\t \x01(\tR\bapiKeyId\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"U\n" +
+	"\x19ProductOperationsResponse\x128\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x18.pvz.v1.ProductOperationR\n" +
+	"operations\".\n" +
+	"\x15CloseReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\";\n" +
+	"\x16ReceptionReportRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\"[\n" +
+	"\x1cAcceptReceptionReportRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\"@\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"W\n" +
+	"\x0fRegisterRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"J\n" +
+	"\rTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"4\n" +
+	"\rLogoutRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xb8\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vdisabled_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disabledAt\"f\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"7\n" +
+	"\x11ListUsersResponse\x12\"\n" +
+	"\x05users\x18\x01 \x03(\v2\f.pvz.v1.UserR\x05users\"D\n" +
+	"\x15ChangeUserRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"(\n" +
+	"\rUserIdRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"O\n" +
+	"\x18ResetUserPasswordRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"=\n" +
+	"\x11InviteUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"\xa8\x01\n" +
+	"\x06Invite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12)\n" +
+	"\x10activation_token\x18\x05 \x01(\tR\x0factivationToken\"G\n" +
+	"\x13AcceptInviteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"0\n" +
+	"\x17ListPVZEmployeesRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"K\n" +
+	"\x19EmployeeAssignmentRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xfc\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12\x10\n" +
+	"\x03key\x18\n" +
+	" \x01(\tR\x03key\"|\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"9\n" +
+	"\x13ListAPIKeysResponse\x12\"\n" +
+	"\x04keys\x18\x01 \x03(\v2\x0e.pvz.v1.APIKeyR\x04keys\"(\n" +
+	"\x0fAPIKeyIdRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"U\n" +
+	"\x04City\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\":\n" +
+	"\x12ListCitiesResponse\x12$\n" +
+	"\x06cities\x18\x01 \x03(\v2\f.pvz.v1.CityR\x06cities\"'\n" +
+	"\x11CreateCityRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"B\n" +
+	"\x11RenameCityRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\"'\n" +
+	"\x11DeleteCityRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xa7\x02\n" +
+	"\vProductType\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\afragile\x18\x02 \x01(\bR\afragile\x12*\n" +
+	"\x11requires_id_check\x18\x03 \x01(\bR\x0frequiresIdCheck\x12-\n" +
+	"\x10max_weight_grams\x18\x04 \x01(\x05H\x00R\x0emaxWeightGrams\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12?\n" +
+	"\rdeprecated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fdeprecatedAtB\x13\n" +
+	"\x11_max_weight_grams\"T\n" +
+	"\x18ListProductTypesResponse\x128\n" +
+	"\rproduct_types\x18\x01 \x03(\v2\x13.pvz.v1.ProductTypeR\fproductTypes\"\xb8\x01\n" +
+	"\x18CreateProductTypeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\afragile\x18\x02 \x01(\bR\afragile\x12*\n" +
+	"\x11requires_id_check\x18\x03 \x01(\bR\x0frequiresIdCheck\x12-\n" +
+	"\x10max_weight_grams\x18\x04 \x01(\x05H\x00R\x0emaxWeightGrams\x88\x01\x01B\x13\n" +
+	"\x11_max_weight_grams\"\x98\x02\n" +
+	"\x18UpdateProductTypeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\afragile\x18\x02 \x01(\bH\x00R\afragile\x88\x01\x01\x12/\n" +
+	"\x11requires_id_check\x18\x03 \x01(\bH\x01R\x0frequiresIdCheck\x88\x01\x01\x12-\n" +
+	"\x10max_weight_grams\x18\x04 \x01(\x05H\x02R\x0emaxWeightGrams\x88\x01\x01\x12#\n" +
+	"\n" +
+	"deprecated\x18\x05 \x01(\bH\x03R\n" +
+	"deprecated\x88\x01\x01B\n" +
+	"\n" +
+	"\b_fragileB\x14\n" +
+	"\x12_requires_id_checkB\x13\n" +
+	"\x11_max_weight_gramsB\r\n" +
+	"\v_deprecated\".\n" +
+	"\x18DeleteProductTypeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name2\xcf\x18\n" +
+	"\n" +
+	"PVZService\x12@\n" +
+	"\n" +
+	"GetPVZList\x12\x16.google.protobuf.Empty\x1a\x1a.pvz.v1.GetPVZListResponse\x12:\n" +
+	"\aListPVZ\x12\x16.pvz.v1.ListPVZRequest\x1a\x17.pvz.v1.ListPVZResponse\x122\n" +
+	"\tCreatePVZ\x12\x18.pvz.v1.CreatePVZRequest\x1a\v.pvz.v1.PVZ\x122\n" +
+	"\tUpdatePVZ\x12\x18.pvz.v1.UpdatePVZRequest\x1a\v.pvz.v1.PVZ\x12:\n" +
+	"\rDeactivatePVZ\x12\x1c.pvz.v1.DeactivatePVZRequest\x1a\v.pvz.v1.PVZ\x12@\n" +
+	"\tNearbyPVZ\x12\x18.pvz.v1.NearbyPVZRequest\x1a\x19.pvz.v1.NearbyPVZResponse\x12@\n" +
+	"\rOpenReception\x12\x1c.pvz.v1.OpenReceptionRequest\x1a\x11.pvz.v1.Reception\x128\n" +
+	"\n" +
+	"AddProduct\x12\x19.pvz.v1.AddProductRequest\x1a\x0f.pvz.v1.Product\x12H\n" +
+	"\vAddProducts\x12\x1a.pvz.v1.AddProductsRequest\x1a\x1b.pvz.v1.AddProductsResponse(\x01\x12H\n" +
+	"\x14GetProductsByBarcode\x12\x16.pvz.v1.BarcodeRequest\x1a\x18.pvz.v1.ProductsResponse\x12M\n" +
+	"\x11DeleteLastProduct\x12 .pvz.v1.DeleteLastProductRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\rDeleteProduct\x12\x1c.pvz.v1.DeleteProductRequest\x1a\x0f.pvz.v1.Product\x12H\n" +
+	"\x12CorrectProductType\x12!.pvz.v1.CorrectProductTypeRequest\x1a\x0f.pvz.v1.Product\x12\\\n" +
+	"\x15UndoProductOperations\x12 .pvz.v1.ProductOperationsRequest\x1a!.pvz.v1.ProductOperationsResponse\x12\\\n" +
+	"\x15RedoProductOperations\x12 .pvz.v1.ProductOperationsRequest\x1a!.pvz.v1.ProductOperationsResponse\x12`\n" +
+	"\x15ListProductOperations\x12$.pvz.v1.ListProductOperationsRequest\x1a!.pvz.v1.ProductOperationsResponse\x12B\n" +
+	"\x0eCloseReception\x12\x1d.pvz.v1.CloseReceptionRequest\x1a\x11.pvz.v1.Reception\x12M\n" +
+	"\x12GetReceptionReport\x12\x1e.pvz.v1.ReceptionReportRequest\x1a\x17.pvz.v1.ReceptionReport\x12V\n" +
+	"\x15AcceptReceptionReport\x12$.pvz.v1.AcceptReceptionReportRequest\x1a\x17.pvz.v1.ReceptionReport\x124\n" +
+	"\x05Login\x12\x14.pvz.v1.LoginRequest\x1a\x15.pvz.v1.TokenResponse\x12:\n" +
+	"\bRegister\x12\x17.pvz.v1.RegisterRequest\x1a\x15.pvz.v1.TokenResponse\x12B\n" +
+	"\fRefreshToken\x12\x1b.pvz.v1.RefreshTokenRequest\x1a\x15.pvz.v1.TokenResponse\x127\n" +
+	"\x06Logout\x12\x15.pvz.v1.LogoutRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\tLogoutAll\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\tListUsers\x12\x18.pvz.v1.ListUsersRequest\x1a\x19.pvz.v1.ListUsersResponse\x12=\n" +
+	"\x0eChangeUserRole\x12\x1d.pvz.v1.ChangeUserRoleRequest\x1a\f.pvz.v1.User\x122\n" +
+	"\vDisableUser\x12\x15.pvz.v1.UserIdRequest\x1a\f.pvz.v1.User\x121\n" +
+	"\n" +
+	"EnableUser\x12\x15.pvz.v1.UserIdRequest\x1a\f.pvz.v1.User\x12M\n" +
+	"\x11ResetUserPassword\x12 .pvz.v1.ResetUserPasswordRequest\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\n" +
+	"InviteUser\x12\x19.pvz.v1.InviteUserRequest\x1a\x0e.pvz.v1.Invite\x12B\n" +
+	"\fAcceptInvite\x12\x1b.pvz.v1.AcceptInviteRequest\x1a\x15.pvz.v1.TokenResponse\x12N\n" +
+	"\x10ListPVZEmployees\x12\x1f.pvz.v1.ListPVZEmployeesRequest\x1a\x19.pvz.v1.ListUsersResponse\x12K\n" +
+	"\x0eAssignEmployee\x12!.pvz.v1.EmployeeAssignmentRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x10UnassignEmployee\x12!.pvz.v1.EmployeeAssignmentRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\fCreateAPIKey\x12\x1b.pvz.v1.CreateAPIKeyRequest\x1a\x0e.pvz.v1.APIKey\x12B\n" +
+	"\vListAPIKeys\x12\x16.google.protobuf.Empty\x1a\x1b.pvz.v1.ListAPIKeysResponse\x127\n" +
+	"\fRotateAPIKey\x12\x17.pvz.v1.APIKeyIdRequest\x1a\x0e.pvz.v1.APIKey\x12?\n" +
+	"\fRevokeAPIKey\x12\x17.pvz.v1.APIKeyIdRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\n" +
+	"ListCities\x12\x16.google.protobuf.Empty\x1a\x1a.pvz.v1.ListCitiesResponse\x125\n" +
+	"\n" +
+	"CreateCity\x12\x19.pvz.v1.CreateCityRequest\x1a\f.pvz.v1.City\x125\n" +
+	"\n" +
+	"RenameCity\x12\x19.pvz.v1.RenameCityRequest\x1a\f.pvz.v1.City\x12?\n" +
 	"\n" +
-	"GetPVZList\x12\x16.google.protobuf.Empty\x1a\x1a.pvz.v1.GetPVZListResponseB Z\x1epvz-backend-service/api/pvz/v1b\x06proto3"
+	"DeleteCity\x12\x19.pvz.v1.DeleteCityRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x10ListProductTypes\x12\x16.google.protobuf.Empty\x1a .pvz.v1.ListProductTypesResponse\x12J\n" +
+	"\x11CreateProductType\x12 .pvz.v1.CreateProductTypeRequest\x1a\x13.pvz.v1.ProductType\x12J\n" +
+	"\x11UpdateProductType\x12 .pvz.v1.UpdateProductTypeRequest\x1a\x13.pvz.v1.ProductType\x12M\n" +
+	"\x11DeleteProductType\x12 .pvz.v1.DeleteProductTypeRequest\x1a\x16.google.protobuf.EmptyB Z\x1epvz-backend-service/api/pvz/v1b\x06proto3"
 
 var (
 	file_api_pvz_v1_pvz_proto_rawDescOnce sync.Once
@@ -145,21 +4663,228 @@ func file_api_pvz_v1_pvz_proto_rawDescGZIP() []byte {
 	return file_api_pvz_v1_pvz_proto_rawDescData
 }
 
-var file_api_pvz_v1_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_pvz_v1_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
 var file_api_pvz_v1_pvz_proto_goTypes = []any{
-	(*PVZ)(nil),                // 0: pvz.v1.PVZ
-	(*GetPVZListResponse)(nil), // 1: pvz.v1.GetPVZListResponse
-	(*emptypb.Empty)(nil),      // 2: google.protobuf.Empty
+	(*PVZ)(nil),                          // 0: pvz.v1.PVZ
+	(*WorkingHours)(nil),                 // 1: pvz.v1.WorkingHours
+	(*WorkingHoursList)(nil),             // 2: pvz.v1.WorkingHoursList
+	(*Reception)(nil),                    // 3: pvz.v1.Reception
+	(*ManifestItem)(nil),                 // 4: pvz.v1.ManifestItem
+	(*Manifest)(nil),                     // 5: pvz.v1.Manifest
+	(*DiscrepancyLine)(nil),              // 6: pvz.v1.DiscrepancyLine
+	(*ReceptionReport)(nil),              // 7: pvz.v1.ReceptionReport
+	(*Product)(nil),                      // 8: pvz.v1.Product
+	(*Dimensions)(nil),                   // 9: pvz.v1.Dimensions
+	(*BarcodeRequest)(nil),               // 10: pvz.v1.BarcodeRequest
+	(*ProductsResponse)(nil),             // 11: pvz.v1.ProductsResponse
+	(*GetPVZListResponse)(nil),           // 12: pvz.v1.GetPVZListResponse
+	(*ListPVZRequest)(nil),               // 13: pvz.v1.ListPVZRequest
+	(*ReceptionWithProducts)(nil),        // 14: pvz.v1.ReceptionWithProducts
+	(*PVZWithReceptions)(nil),            // 15: pvz.v1.PVZWithReceptions
+	(*ListPVZResponse)(nil),              // 16: pvz.v1.ListPVZResponse
+	(*CreatePVZRequest)(nil),             // 17: pvz.v1.CreatePVZRequest
+	(*UpdatePVZRequest)(nil),             // 18: pvz.v1.UpdatePVZRequest
+	(*DeactivatePVZRequest)(nil),         // 19: pvz.v1.DeactivatePVZRequest
+	(*NearbyPVZRequest)(nil),             // 20: pvz.v1.NearbyPVZRequest
+	(*NearbyPVZ)(nil),                    // 21: pvz.v1.NearbyPVZ
+	(*NearbyPVZResponse)(nil),            // 22: pvz.v1.NearbyPVZResponse
+	(*OpenReceptionRequest)(nil),         // 23: pvz.v1.OpenReceptionRequest
+	(*AddProductRequest)(nil),            // 24: pvz.v1.AddProductRequest
+	(*AddProductsRequest)(nil),           // 25: pvz.v1.AddProductsRequest
+	(*NewProduct)(nil),                   // 26: pvz.v1.NewProduct
+	(*BatchItem)(nil),                    // 27: pvz.v1.BatchItem
+	(*AddProductsResponse)(nil),          // 28: pvz.v1.AddProductsResponse
+	(*DeleteLastProductRequest)(nil),     // 29: pvz.v1.DeleteLastProductRequest
+	(*DeleteProductRequest)(nil),         // 30: pvz.v1.DeleteProductRequest
+	(*CorrectProductTypeRequest)(nil),    // 31: pvz.v1.CorrectProductTypeRequest
+	(*ProductOperationsRequest)(nil),     // 32: pvz.v1.ProductOperationsRequest
+	(*ListProductOperationsRequest)(nil), // 33: pvz.v1.ListProductOperationsRequest
+	(*ProductOperation)(nil),             // 34: pvz.v1.ProductOperation
+	(*ProductOperationsResponse)(nil),    // 35: pvz.v1.ProductOperationsResponse
+	(*CloseReceptionRequest)(nil),        // 36: pvz.v1.CloseReceptionRequest
+	(*ReceptionReportRequest)(nil),       // 37: pvz.v1.ReceptionReportRequest
+	(*AcceptReceptionReportRequest)(nil), // 38: pvz.v1.AcceptReceptionReportRequest
+	(*LoginRequest)(nil),                 // 39: pvz.v1.LoginRequest
+	(*RegisterRequest)(nil),              // 40: pvz.v1.RegisterRequest
+	(*TokenResponse)(nil),                // 41: pvz.v1.TokenResponse
+	(*RefreshTokenRequest)(nil),          // 42: pvz.v1.RefreshTokenRequest
+	(*LogoutRequest)(nil),                // 43: pvz.v1.LogoutRequest
+	(*User)(nil),                         // 44: pvz.v1.User
+	(*ListUsersRequest)(nil),             // 45: pvz.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),            // 46: pvz.v1.ListUsersResponse
+	(*ChangeUserRoleRequest)(nil),        // 47: pvz.v1.ChangeUserRoleRequest
+	(*UserIdRequest)(nil),                // 48: pvz.v1.UserIdRequest
+	(*ResetUserPasswordRequest)(nil),     // 49: pvz.v1.ResetUserPasswordRequest
+	(*InviteUserRequest)(nil),            // 50: pvz.v1.InviteUserRequest
+	(*Invite)(nil),                       // 51: pvz.v1.Invite
+	(*AcceptInviteRequest)(nil),          // 52: pvz.v1.AcceptInviteRequest
+	(*ListPVZEmployeesRequest)(nil),      // 53: pvz.v1.ListPVZEmployeesRequest
+	(*EmployeeAssignmentRequest)(nil),    // 54: pvz.v1.EmployeeAssignmentRequest
+	(*APIKey)(nil),                       // 55: pvz.v1.APIKey
+	(*CreateAPIKeyRequest)(nil),          // 56: pvz.v1.CreateAPIKeyRequest
+	(*ListAPIKeysResponse)(nil),          // 57: pvz.v1.ListAPIKeysResponse
+	(*APIKeyIdRequest)(nil),              // 58: pvz.v1.APIKeyIdRequest
+	(*City)(nil),                         // 59: pvz.v1.City
+	(*ListCitiesResponse)(nil),           // 60: pvz.v1.ListCitiesResponse
+	(*CreateCityRequest)(nil),            // 61: pvz.v1.CreateCityRequest
+	(*RenameCityRequest)(nil),            // 62: pvz.v1.RenameCityRequest
+	(*DeleteCityRequest)(nil),            // 63: pvz.v1.DeleteCityRequest
+	(*ProductType)(nil),                  // 64: pvz.v1.ProductType
+	(*ListProductTypesResponse)(nil),     // 65: pvz.v1.ListProductTypesResponse
+	(*CreateProductTypeRequest)(nil),     // 66: pvz.v1.CreateProductTypeRequest
+	(*UpdateProductTypeRequest)(nil),     // 67: pvz.v1.UpdateProductTypeRequest
+	(*DeleteProductTypeRequest)(nil),     // 68: pvz.v1.DeleteProductTypeRequest
+	(*timestamppb.Timestamp)(nil),        // 69: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 70: google.protobuf.Empty
 }
 var file_api_pvz_v1_pvz_proto_depIdxs = []int32{
-	0, // 0: pvz.v1.GetPVZListResponse.pvz:type_name -> pvz.v1.PVZ
-	2, // 1: pvz.v1.PVZService.GetPVZList:input_type -> google.protobuf.Empty
-	1, // 2: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	69, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
+	1,  // 1: pvz.v1.PVZ.working_hours:type_name -> pvz.v1.WorkingHours
+	1,  // 2: pvz.v1.WorkingHoursList.days:type_name -> pvz.v1.WorkingHours
+	69, // 3: pvz.v1.Reception.date_time:type_name -> google.protobuf.Timestamp
+	5,  // 4: pvz.v1.Reception.manifest:type_name -> pvz.v1.Manifest
+	4,  // 5: pvz.v1.Manifest.items:type_name -> pvz.v1.ManifestItem
+	6,  // 6: pvz.v1.ReceptionReport.missing:type_name -> pvz.v1.DiscrepancyLine
+	6,  // 7: pvz.v1.ReceptionReport.surplus:type_name -> pvz.v1.DiscrepancyLine
+	6,  // 8: pvz.v1.ReceptionReport.unexpected:type_name -> pvz.v1.DiscrepancyLine
+	69, // 9: pvz.v1.ReceptionReport.created_at:type_name -> google.protobuf.Timestamp
+	69, // 10: pvz.v1.ReceptionReport.accepted_at:type_name -> google.protobuf.Timestamp
+	69, // 11: pvz.v1.Product.date_time:type_name -> google.protobuf.Timestamp
+	9,  // 12: pvz.v1.Product.dimensions:type_name -> pvz.v1.Dimensions
+	8,  // 13: pvz.v1.ProductsResponse.products:type_name -> pvz.v1.Product
+	0,  // 14: pvz.v1.GetPVZListResponse.pvz:type_name -> pvz.v1.PVZ
+	69, // 15: pvz.v1.ListPVZRequest.start_date:type_name -> google.protobuf.Timestamp
+	69, // 16: pvz.v1.ListPVZRequest.end_date:type_name -> google.protobuf.Timestamp
+	3,  // 17: pvz.v1.ReceptionWithProducts.reception:type_name -> pvz.v1.Reception
+	8,  // 18: pvz.v1.ReceptionWithProducts.products:type_name -> pvz.v1.Product
+	0,  // 19: pvz.v1.PVZWithReceptions.pvz:type_name -> pvz.v1.PVZ
+	14, // 20: pvz.v1.PVZWithReceptions.receptions:type_name -> pvz.v1.ReceptionWithProducts
+	15, // 21: pvz.v1.ListPVZResponse.items:type_name -> pvz.v1.PVZWithReceptions
+	1,  // 22: pvz.v1.CreatePVZRequest.working_hours:type_name -> pvz.v1.WorkingHours
+	2,  // 23: pvz.v1.UpdatePVZRequest.working_hours:type_name -> pvz.v1.WorkingHoursList
+	0,  // 24: pvz.v1.NearbyPVZ.pvz:type_name -> pvz.v1.PVZ
+	21, // 25: pvz.v1.NearbyPVZResponse.items:type_name -> pvz.v1.NearbyPVZ
+	5,  // 26: pvz.v1.OpenReceptionRequest.manifest:type_name -> pvz.v1.Manifest
+	9,  // 27: pvz.v1.AddProductRequest.dimensions:type_name -> pvz.v1.Dimensions
+	26, // 28: pvz.v1.AddProductsRequest.product:type_name -> pvz.v1.NewProduct
+	9,  // 29: pvz.v1.NewProduct.dimensions:type_name -> pvz.v1.Dimensions
+	8,  // 30: pvz.v1.BatchItem.product:type_name -> pvz.v1.Product
+	27, // 31: pvz.v1.AddProductsResponse.items:type_name -> pvz.v1.BatchItem
+	8,  // 32: pvz.v1.ProductOperation.product:type_name -> pvz.v1.Product
+	69, // 33: pvz.v1.ProductOperation.created_at:type_name -> google.protobuf.Timestamp
+	34, // 34: pvz.v1.ProductOperationsResponse.operations:type_name -> pvz.v1.ProductOperation
+	69, // 35: pvz.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	69, // 36: pvz.v1.User.disabled_at:type_name -> google.protobuf.Timestamp
+	44, // 37: pvz.v1.ListUsersResponse.users:type_name -> pvz.v1.User
+	69, // 38: pvz.v1.Invite.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 39: pvz.v1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	69, // 40: pvz.v1.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 41: pvz.v1.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	69, // 42: pvz.v1.APIKey.revoked_at:type_name -> google.protobuf.Timestamp
+	69, // 43: pvz.v1.CreateAPIKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	55, // 44: pvz.v1.ListAPIKeysResponse.keys:type_name -> pvz.v1.APIKey
+	69, // 45: pvz.v1.City.created_at:type_name -> google.protobuf.Timestamp
+	59, // 46: pvz.v1.ListCitiesResponse.cities:type_name -> pvz.v1.City
+	69, // 47: pvz.v1.ProductType.created_at:type_name -> google.protobuf.Timestamp
+	69, // 48: pvz.v1.ProductType.deprecated_at:type_name -> google.protobuf.Timestamp
+	64, // 49: pvz.v1.ListProductTypesResponse.product_types:type_name -> pvz.v1.ProductType
+	70, // 50: pvz.v1.PVZService.GetPVZList:input_type -> google.protobuf.Empty
+	13, // 51: pvz.v1.PVZService.ListPVZ:input_type -> pvz.v1.ListPVZRequest
+	17, // 52: pvz.v1.PVZService.CreatePVZ:input_type -> pvz.v1.CreatePVZRequest
+	18, // 53: pvz.v1.PVZService.UpdatePVZ:input_type -> pvz.v1.UpdatePVZRequest
+	19, // 54: pvz.v1.PVZService.DeactivatePVZ:input_type -> pvz.v1.DeactivatePVZRequest
+	20, // 55: pvz.v1.PVZService.NearbyPVZ:input_type -> pvz.v1.NearbyPVZRequest
+	23, // 56: pvz.v1.PVZService.OpenReception:input_type -> pvz.v1.OpenReceptionRequest
+	24, // 57: pvz.v1.PVZService.AddProduct:input_type -> pvz.v1.AddProductRequest
+	25, // 58: pvz.v1.PVZService.AddProducts:input_type -> pvz.v1.AddProductsRequest
+	10, // 59: pvz.v1.PVZService.GetProductsByBarcode:input_type -> pvz.v1.BarcodeRequest
+	29, // 60: pvz.v1.PVZService.DeleteLastProduct:input_type -> pvz.v1.DeleteLastProductRequest
+	30, // 61: pvz.v1.PVZService.DeleteProduct:input_type -> pvz.v1.DeleteProductRequest
+	31, // 62: pvz.v1.PVZService.CorrectProductType:input_type -> pvz.v1.CorrectProductTypeRequest
+	32, // 63: pvz.v1.PVZService.UndoProductOperations:input_type -> pvz.v1.ProductOperationsRequest
+	32, // 64: pvz.v1.PVZService.RedoProductOperations:input_type -> pvz.v1.ProductOperationsRequest
+	33, // 65: pvz.v1.PVZService.ListProductOperations:input_type -> pvz.v1.ListProductOperationsRequest
+	36, // 66: pvz.v1.PVZService.CloseReception:input_type -> pvz.v1.CloseReceptionRequest
+	37, // 67: pvz.v1.PVZService.GetReceptionReport:input_type -> pvz.v1.ReceptionReportRequest
+	38, // 68: pvz.v1.PVZService.AcceptReceptionReport:input_type -> pvz.v1.AcceptReceptionReportRequest
+	39, // 69: pvz.v1.PVZService.Login:input_type -> pvz.v1.LoginRequest
+	40, // 70: pvz.v1.PVZService.Register:input_type -> pvz.v1.RegisterRequest
+	42, // 71: pvz.v1.PVZService.RefreshToken:input_type -> pvz.v1.RefreshTokenRequest
+	43, // 72: pvz.v1.PVZService.Logout:input_type -> pvz.v1.LogoutRequest
+	70, // 73: pvz.v1.PVZService.LogoutAll:input_type -> google.protobuf.Empty
+	45, // 74: pvz.v1.PVZService.ListUsers:input_type -> pvz.v1.ListUsersRequest
+	47, // 75: pvz.v1.PVZService.ChangeUserRole:input_type -> pvz.v1.ChangeUserRoleRequest
+	48, // 76: pvz.v1.PVZService.DisableUser:input_type -> pvz.v1.UserIdRequest
+	48, // 77: pvz.v1.PVZService.EnableUser:input_type -> pvz.v1.UserIdRequest
+	49, // 78: pvz.v1.PVZService.ResetUserPassword:input_type -> pvz.v1.ResetUserPasswordRequest
+	50, // 79: pvz.v1.PVZService.InviteUser:input_type -> pvz.v1.InviteUserRequest
+	52, // 80: pvz.v1.PVZService.AcceptInvite:input_type -> pvz.v1.AcceptInviteRequest
+	53, // 81: pvz.v1.PVZService.ListPVZEmployees:input_type -> pvz.v1.ListPVZEmployeesRequest
+	54, // 82: pvz.v1.PVZService.AssignEmployee:input_type -> pvz.v1.EmployeeAssignmentRequest
+	54, // 83: pvz.v1.PVZService.UnassignEmployee:input_type -> pvz.v1.EmployeeAssignmentRequest
+	56, // 84: pvz.v1.PVZService.CreateAPIKey:input_type -> pvz.v1.CreateAPIKeyRequest
+	70, // 85: pvz.v1.PVZService.ListAPIKeys:input_type -> google.protobuf.Empty
+	58, // 86: pvz.v1.PVZService.RotateAPIKey:input_type -> pvz.v1.APIKeyIdRequest
+	58, // 87: pvz.v1.PVZService.RevokeAPIKey:input_type -> pvz.v1.APIKeyIdRequest
+	70, // 88: pvz.v1.PVZService.ListCities:input_type -> google.protobuf.Empty
+	61, // 89: pvz.v1.PVZService.CreateCity:input_type -> pvz.v1.CreateCityRequest
+	62, // 90: pvz.v1.PVZService.RenameCity:input_type -> pvz.v1.RenameCityRequest
+	63, // 91: pvz.v1.PVZService.DeleteCity:input_type -> pvz.v1.DeleteCityRequest
+	70, // 92: pvz.v1.PVZService.ListProductTypes:input_type -> google.protobuf.Empty
+	66, // 93: pvz.v1.PVZService.CreateProductType:input_type -> pvz.v1.CreateProductTypeRequest
+	67, // 94: pvz.v1.PVZService.UpdateProductType:input_type -> pvz.v1.UpdateProductTypeRequest
+	68, // 95: pvz.v1.PVZService.DeleteProductType:input_type -> pvz.v1.DeleteProductTypeRequest
+	12, // 96: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
+	16, // 97: pvz.v1.PVZService.ListPVZ:output_type -> pvz.v1.ListPVZResponse
+	0,  // 98: pvz.v1.PVZService.CreatePVZ:output_type -> pvz.v1.PVZ
+	0,  // 99: pvz.v1.PVZService.UpdatePVZ:output_type -> pvz.v1.PVZ
+	0,  // 100: pvz.v1.PVZService.DeactivatePVZ:output_type -> pvz.v1.PVZ
+	22, // 101: pvz.v1.PVZService.NearbyPVZ:output_type -> pvz.v1.NearbyPVZResponse
+	3,  // 102: pvz.v1.PVZService.OpenReception:output_type -> pvz.v1.Reception
+	8,  // 103: pvz.v1.PVZService.AddProduct:output_type -> pvz.v1.Product
+	28, // 104: pvz.v1.PVZService.AddProducts:output_type -> pvz.v1.AddProductsResponse
+	11, // 105: pvz.v1.PVZService.GetProductsByBarcode:output_type -> pvz.v1.ProductsResponse
+	70, // 106: pvz.v1.PVZService.DeleteLastProduct:output_type -> google.protobuf.Empty
+	8,  // 107: pvz.v1.PVZService.DeleteProduct:output_type -> pvz.v1.Product
+	8,  // 108: pvz.v1.PVZService.CorrectProductType:output_type -> pvz.v1.Product
+	35, // 109: pvz.v1.PVZService.UndoProductOperations:output_type -> pvz.v1.ProductOperationsResponse
+	35, // 110: pvz.v1.PVZService.RedoProductOperations:output_type -> pvz.v1.ProductOperationsResponse
+	35, // 111: pvz.v1.PVZService.ListProductOperations:output_type -> pvz.v1.ProductOperationsResponse
+	3,  // 112: pvz.v1.PVZService.CloseReception:output_type -> pvz.v1.Reception
+	7,  // 113: pvz.v1.PVZService.GetReceptionReport:output_type -> pvz.v1.ReceptionReport
+	7,  // 114: pvz.v1.PVZService.AcceptReceptionReport:output_type -> pvz.v1.ReceptionReport
+	41, // 115: pvz.v1.PVZService.Login:output_type -> pvz.v1.TokenResponse
+	41, // 116: pvz.v1.PVZService.Register:output_type -> pvz.v1.TokenResponse
+	41, // 117: pvz.v1.PVZService.RefreshToken:output_type -> pvz.v1.TokenResponse
+	70, // 118: pvz.v1.PVZService.Logout:output_type -> google.protobuf.Empty
+	70, // 119: pvz.v1.PVZService.LogoutAll:output_type -> google.protobuf.Empty
+	46, // 120: pvz.v1.PVZService.ListUsers:output_type -> pvz.v1.ListUsersResponse
+	44, // 121: pvz.v1.PVZService.ChangeUserRole:output_type -> pvz.v1.User
+	44, // 122: pvz.v1.PVZService.DisableUser:output_type -> pvz.v1.User
+	44, // 123: pvz.v1.PVZService.EnableUser:output_type -> pvz.v1.User
+	70, // 124: pvz.v1.PVZService.ResetUserPassword:output_type -> google.protobuf.Empty
+	51, // 125: pvz.v1.PVZService.InviteUser:output_type -> pvz.v1.Invite
+	41, // 126: pvz.v1.PVZService.AcceptInvite:output_type -> pvz.v1.TokenResponse
+	46, // 127: pvz.v1.PVZService.ListPVZEmployees:output_type -> pvz.v1.ListUsersResponse
+	70, // 128: pvz.v1.PVZService.AssignEmployee:output_type -> google.protobuf.Empty
+	70, // 129: pvz.v1.PVZService.UnassignEmployee:output_type -> google.protobuf.Empty
+	55, // 130: pvz.v1.PVZService.CreateAPIKey:output_type -> pvz.v1.APIKey
+	57, // 131: pvz.v1.PVZService.ListAPIKeys:output_type -> pvz.v1.ListAPIKeysResponse
+	55, // 132: pvz.v1.PVZService.RotateAPIKey:output_type -> pvz.v1.APIKey
+	70, // 133: pvz.v1.PVZService.RevokeAPIKey:output_type -> google.protobuf.Empty
+	60, // 134: pvz.v1.PVZService.ListCities:output_type -> pvz.v1.ListCitiesResponse
+	59, // 135: pvz.v1.PVZService.CreateCity:output_type -> pvz.v1.City
+	59, // 136: pvz.v1.PVZService.RenameCity:output_type -> pvz.v1.City
+	70, // 137: pvz.v1.PVZService.DeleteCity:output_type -> google.protobuf.Empty
+	65, // 138: pvz.v1.PVZService.ListProductTypes:output_type -> pvz.v1.ListProductTypesResponse
+	64, // 139: pvz.v1.PVZService.CreateProductType:output_type -> pvz.v1.ProductType
+	64, // 140: pvz.v1.PVZService.UpdateProductType:output_type -> pvz.v1.ProductType
+	70, // 141: pvz.v1.PVZService.DeleteProductType:output_type -> google.protobuf.Empty
+	96, // [96:142] is the sub-list for method output_type
+	50, // [50:96] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_api_pvz_v1_pvz_proto_init() }
@@ -167,13 +4892,22 @@ func file_api_pvz_v1_pvz_proto_init() {
 	if File_api_pvz_v1_pvz_proto != nil {
 		return
 	}
+	file_api_pvz_v1_pvz_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[8].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[17].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[18].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[24].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[26].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[64].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[66].OneofWrappers = []any{}
+	file_api_pvz_v1_pvz_proto_msgTypes[67].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_pvz_v1_pvz_proto_rawDesc), len(file_api_pvz_v1_pvz_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,7 +20,52 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PVZService_GetPVZList_FullMethodName = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_GetPVZList_FullMethodName            = "/pvz.v1.PVZService/GetPVZList"
+	PVZService_ListPVZ_FullMethodName               = "/pvz.v1.PVZService/ListPVZ"
+	PVZService_CreatePVZ_FullMethodName             = "/pvz.v1.PVZService/CreatePVZ"
+	PVZService_UpdatePVZ_FullMethodName             = "/pvz.v1.PVZService/UpdatePVZ"
+	PVZService_DeactivatePVZ_FullMethodName         = "/pvz.v1.PVZService/DeactivatePVZ"
+	PVZService_NearbyPVZ_FullMethodName             = "/pvz.v1.PVZService/NearbyPVZ"
+	PVZService_OpenReception_FullMethodName         = "/pvz.v1.PVZService/OpenReception"
+	PVZService_AddProduct_FullMethodName            = "/pvz.v1.PVZService/AddProduct"
+	PVZService_AddProducts_FullMethodName           = "/pvz.v1.PVZService/AddProducts"
+	PVZService_GetProductsByBarcode_FullMethodName  = "/pvz.v1.PVZService/GetProductsByBarcode"
+	PVZService_DeleteLastProduct_FullMethodName     = "/pvz.v1.PVZService/DeleteLastProduct"
+	PVZService_DeleteProduct_FullMethodName         = "/pvz.v1.PVZService/DeleteProduct"
+	PVZService_CorrectProductType_FullMethodName    = "/pvz.v1.PVZService/CorrectProductType"
+	PVZService_UndoProductOperations_FullMethodName = "/pvz.v1.PVZService/UndoProductOperations"
+	PVZService_RedoProductOperations_FullMethodName = "/pvz.v1.PVZService/RedoProductOperations"
+	PVZService_ListProductOperations_FullMethodName = "/pvz.v1.PVZService/ListProductOperations"
+	PVZService_CloseReception_FullMethodName        = "/pvz.v1.PVZService/CloseReception"
+	PVZService_GetReceptionReport_FullMethodName    = "/pvz.v1.PVZService/GetReceptionReport"
+	PVZService_AcceptReceptionReport_FullMethodName = "/pvz.v1.PVZService/AcceptReceptionReport"
+	PVZService_Login_FullMethodName                 = "/pvz.v1.PVZService/Login"
+	PVZService_Register_FullMethodName              = "/pvz.v1.PVZService/Register"
+	PVZService_RefreshToken_FullMethodName          = "/pvz.v1.PVZService/RefreshToken"
+	PVZService_Logout_FullMethodName                = "/pvz.v1.PVZService/Logout"
+	PVZService_LogoutAll_FullMethodName             = "/pvz.v1.PVZService/LogoutAll"
+	PVZService_ListUsers_FullMethodName             = "/pvz.v1.PVZService/ListUsers"
+	PVZService_ChangeUserRole_FullMethodName        = "/pvz.v1.PVZService/ChangeUserRole"
+	PVZService_DisableUser_FullMethodName           = "/pvz.v1.PVZService/DisableUser"
+	PVZService_EnableUser_FullMethodName            = "/pvz.v1.PVZService/EnableUser"
+	PVZService_ResetUserPassword_FullMethodName     = "/pvz.v1.PVZService/ResetUserPassword"
+	PVZService_InviteUser_FullMethodName            = "/pvz.v1.PVZService/InviteUser"
+	PVZService_AcceptInvite_FullMethodName          = "/pvz.v1.PVZService/AcceptInvite"
+	PVZService_ListPVZEmployees_FullMethodName      = "/pvz.v1.PVZService/ListPVZEmployees"
+	PVZService_AssignEmployee_FullMethodName        = "/pvz.v1.PVZService/AssignEmployee"
+	PVZService_UnassignEmployee_FullMethodName      = "/pvz.v1.PVZService/UnassignEmployee"
+	PVZService_CreateAPIKey_FullMethodName          = "/pvz.v1.PVZService/CreateAPIKey"
+	PVZService_ListAPIKeys_FullMethodName           = "/pvz.v1.PVZService/ListAPIKeys"
+	PVZService_RotateAPIKey_FullMethodName          = "/pvz.v1.PVZService/RotateAPIKey"
+	PVZService_RevokeAPIKey_FullMethodName          = "/pvz.v1.PVZService/RevokeAPIKey"
+	PVZService_ListCities_FullMethodName            = "/pvz.v1.PVZService/ListCities"
+	PVZService_CreateCity_FullMethodName            = "/pvz.v1.PVZService/CreateCity"
+	PVZService_RenameCity_FullMethodName            = "/pvz.v1.PVZService/RenameCity"
+	PVZService_DeleteCity_FullMethodName            = "/pvz.v1.PVZService/DeleteCity"
+	PVZService_ListProductTypes_FullMethodName      = "/pvz.v1.PVZService/ListProductTypes"
+	PVZService_CreateProductType_FullMethodName     = "/pvz.v1.PVZService/CreateProductType"
+	PVZService_UpdateProductType_FullMethodName     = "/pvz.v1.PVZService/UpdateProductType"
+	PVZService_DeleteProductType_FullMethodName     = "/pvz.v1.PVZService/DeleteProductType"
 )
 
 // PVZServiceClient is the client API for PVZService service.
@@ -28,6 +73,51 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PVZServiceClient interface {
 	GetPVZList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetPVZListResponse, error)
+	ListPVZ(ctx context.Context, in *ListPVZRequest, opts ...grpc.CallOption) (*ListPVZResponse, error)
+	CreatePVZ(ctx context.Context, in *CreatePVZRequest, opts ...grpc.CallOption) (*PVZ, error)
+	UpdatePVZ(ctx context.Context, in *UpdatePVZRequest, opts ...grpc.CallOption) (*PVZ, error)
+	DeactivatePVZ(ctx context.Context, in *DeactivatePVZRequest, opts ...grpc.CallOption) (*PVZ, error)
+	NearbyPVZ(ctx context.Context, in *NearbyPVZRequest, opts ...grpc.CallOption) (*NearbyPVZResponse, error)
+	OpenReception(ctx context.Context, in *OpenReceptionRequest, opts ...grpc.CallOption) (*Reception, error)
+	AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*Product, error)
+	AddProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AddProductsRequest, AddProductsResponse], error)
+	GetProductsByBarcode(ctx context.Context, in *BarcodeRequest, opts ...grpc.CallOption) (*ProductsResponse, error)
+	DeleteLastProduct(ctx context.Context, in *DeleteLastProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*Product, error)
+	CorrectProductType(ctx context.Context, in *CorrectProductTypeRequest, opts ...grpc.CallOption) (*Product, error)
+	UndoProductOperations(ctx context.Context, in *ProductOperationsRequest, opts ...grpc.CallOption) (*ProductOperationsResponse, error)
+	RedoProductOperations(ctx context.Context, in *ProductOperationsRequest, opts ...grpc.CallOption) (*ProductOperationsResponse, error)
+	ListProductOperations(ctx context.Context, in *ListProductOperationsRequest, opts ...grpc.CallOption) (*ProductOperationsResponse, error)
+	CloseReception(ctx context.Context, in *CloseReceptionRequest, opts ...grpc.CallOption) (*Reception, error)
+	GetReceptionReport(ctx context.Context, in *ReceptionReportRequest, opts ...grpc.CallOption) (*ReceptionReport, error)
+	AcceptReceptionReport(ctx context.Context, in *AcceptReceptionReportRequest, opts ...grpc.CallOption) (*ReceptionReport, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LogoutAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	ChangeUserRole(ctx context.Context, in *ChangeUserRoleRequest, opts ...grpc.CallOption) (*User, error)
+	DisableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error)
+	EnableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error)
+	ResetUserPassword(ctx context.Context, in *ResetUserPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	InviteUser(ctx context.Context, in *InviteUserRequest, opts ...grpc.CallOption) (*Invite, error)
+	AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	ListPVZEmployees(ctx context.Context, in *ListPVZEmployeesRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	AssignEmployee(ctx context.Context, in *EmployeeAssignmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnassignEmployee(ctx context.Context, in *EmployeeAssignmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	RotateAPIKey(ctx context.Context, in *APIKeyIdRequest, opts ...grpc.CallOption) (*APIKey, error)
+	RevokeAPIKey(ctx context.Context, in *APIKeyIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCitiesResponse, error)
+	CreateCity(ctx context.Context, in *CreateCityRequest, opts ...grpc.CallOption) (*City, error)
+	RenameCity(ctx context.Context, in *RenameCityRequest, opts ...grpc.CallOption) (*City, error)
+	DeleteCity(ctx context.Context, in *DeleteCityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListProductTypes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListProductTypesResponse, error)
+	CreateProductType(ctx context.Context, in *CreateProductTypeRequest, opts ...grpc.CallOption) (*ProductType, error)
+	UpdateProductType(ctx context.Context, in *UpdateProductTypeRequest, opts ...grpc.CallOption) (*ProductType, error)
+	DeleteProductType(ctx context.Context, in *DeleteProductTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type pVZServiceClient struct {
@@ -48,59 +138,1491 @@ func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *emptypb.Empty, op
 	return out, nil
 }
 
+func (c *pVZServiceClient) ListPVZ(ctx context.Context, in *ListPVZRequest, opts ...grpc.CallOption) (*ListPVZResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPVZResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListPVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CreatePVZ(ctx context.Context, in *CreatePVZRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_CreatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) UpdatePVZ(ctx context.Context, in *UpdatePVZRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_UpdatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeactivatePVZ(ctx context.Context, in *DeactivatePVZRequest, opts ...grpc.CallOption) (*PVZ, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PVZ)
+	err := c.cc.Invoke(ctx, PVZService_DeactivatePVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) NearbyPVZ(ctx context.Context, in *NearbyPVZRequest, opts ...grpc.CallOption) (*NearbyPVZResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NearbyPVZResponse)
+	err := c.cc.Invoke(ctx, PVZService_NearbyPVZ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) OpenReception(ctx context.Context, in *OpenReceptionRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, PVZService_OpenReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, PVZService_AddProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AddProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AddProductsRequest, AddProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PVZService_ServiceDesc.Streams[0], PVZService_AddProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AddProductsRequest, AddProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_AddProductsClient = grpc.ClientStreamingClient[AddProductsRequest, AddProductsResponse]
+
+func (c *pVZServiceClient) GetProductsByBarcode(ctx context.Context, in *BarcodeRequest, opts ...grpc.CallOption) (*ProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductsResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetProductsByBarcode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteLastProduct(ctx context.Context, in *DeleteLastProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_DeleteLastProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, PVZService_DeleteProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CorrectProductType(ctx context.Context, in *CorrectProductTypeRequest, opts ...grpc.CallOption) (*Product, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Product)
+	err := c.cc.Invoke(ctx, PVZService_CorrectProductType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) UndoProductOperations(ctx context.Context, in *ProductOperationsRequest, opts ...grpc.CallOption) (*ProductOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductOperationsResponse)
+	err := c.cc.Invoke(ctx, PVZService_UndoProductOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) RedoProductOperations(ctx context.Context, in *ProductOperationsRequest, opts ...grpc.CallOption) (*ProductOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductOperationsResponse)
+	err := c.cc.Invoke(ctx, PVZService_RedoProductOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListProductOperations(ctx context.Context, in *ListProductOperationsRequest, opts ...grpc.CallOption) (*ProductOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductOperationsResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListProductOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CloseReception(ctx context.Context, in *CloseReceptionRequest, opts ...grpc.CallOption) (*Reception, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reception)
+	err := c.cc.Invoke(ctx, PVZService_CloseReception_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) GetReceptionReport(ctx context.Context, in *ReceptionReportRequest, opts ...grpc.CallOption) (*ReceptionReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceptionReport)
+	err := c.cc.Invoke(ctx, PVZService_GetReceptionReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AcceptReceptionReport(ctx context.Context, in *AcceptReceptionReportRequest, opts ...grpc.CallOption) (*ReceptionReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceptionReport)
+	err := c.cc.Invoke(ctx, PVZService_AcceptReceptionReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, PVZService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, PVZService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, PVZService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) LogoutAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_LogoutAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ChangeUserRole(ctx context.Context, in *ChangeUserRoleRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PVZService_ChangeUserRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DisableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PVZService_DisableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) EnableUser(ctx context.Context, in *UserIdRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PVZService_EnableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ResetUserPassword(ctx context.Context, in *ResetUserPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_ResetUserPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) InviteUser(ctx context.Context, in *InviteUserRequest, opts ...grpc.CallOption) (*Invite, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Invite)
+	err := c.cc.Invoke(ctx, PVZService_InviteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, PVZService_AcceptInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListPVZEmployees(ctx context.Context, in *ListPVZEmployeesRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListPVZEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) AssignEmployee(ctx context.Context, in *EmployeeAssignmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_AssignEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) UnassignEmployee(ctx context.Context, in *EmployeeAssignmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_UnassignEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, PVZService_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListAPIKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) RotateAPIKey(ctx context.Context, in *APIKeyIdRequest, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, PVZService_RotateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) RevokeAPIKey(ctx context.Context, in *APIKeyIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListCities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCitiesResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListCities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CreateCity(ctx context.Context, in *CreateCityRequest, opts ...grpc.CallOption) (*City, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(City)
+	err := c.cc.Invoke(ctx, PVZService_CreateCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) RenameCity(ctx context.Context, in *RenameCityRequest, opts ...grpc.CallOption) (*City, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(City)
+	err := c.cc.Invoke(ctx, PVZService_RenameCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteCity(ctx context.Context, in *DeleteCityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_DeleteCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) ListProductTypes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListProductTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductTypesResponse)
+	err := c.cc.Invoke(ctx, PVZService_ListProductTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) CreateProductType(ctx context.Context, in *CreateProductTypeRequest, opts ...grpc.CallOption) (*ProductType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductType)
+	err := c.cc.Invoke(ctx, PVZService_CreateProductType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) UpdateProductType(ctx context.Context, in *UpdateProductTypeRequest, opts ...grpc.CallOption) (*ProductType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductType)
+	err := c.cc.Invoke(ctx, PVZService_UpdateProductType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) DeleteProductType(ctx context.Context, in *DeleteProductTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PVZService_DeleteProductType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PVZServiceServer is the server API for PVZService service.
 // All implementations must embed UnimplementedPVZServiceServer
 // for forward compatibility.
 type PVZServiceServer interface {
 	GetPVZList(context.Context, *emptypb.Empty) (*GetPVZListResponse, error)
+	ListPVZ(context.Context, *ListPVZRequest) (*ListPVZResponse, error)
+	CreatePVZ(context.Context, *CreatePVZRequest) (*PVZ, error)
+	UpdatePVZ(context.Context, *UpdatePVZRequest) (*PVZ, error)
+	DeactivatePVZ(context.Context, *DeactivatePVZRequest) (*PVZ, error)
+	NearbyPVZ(context.Context, *NearbyPVZRequest) (*NearbyPVZResponse, error)
+	OpenReception(context.Context, *OpenReceptionRequest) (*Reception, error)
+	AddProduct(context.Context, *AddProductRequest) (*Product, error)
+	AddProducts(grpc.ClientStreamingServer[AddProductsRequest, AddProductsResponse]) error
+	GetProductsByBarcode(context.Context, *BarcodeRequest) (*ProductsResponse, error)
+	DeleteLastProduct(context.Context, *DeleteLastProductRequest) (*emptypb.Empty, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*Product, error)
+	CorrectProductType(context.Context, *CorrectProductTypeRequest) (*Product, error)
+	UndoProductOperations(context.Context, *ProductOperationsRequest) (*ProductOperationsResponse, error)
+	RedoProductOperations(context.Context, *ProductOperationsRequest) (*ProductOperationsResponse, error)
+	ListProductOperations(context.Context, *ListProductOperationsRequest) (*ProductOperationsResponse, error)
+	CloseReception(context.Context, *CloseReceptionRequest) (*Reception, error)
+	GetReceptionReport(context.Context, *ReceptionReportRequest) (*ReceptionReport, error)
+	AcceptReceptionReport(context.Context, *AcceptReceptionReportRequest) (*ReceptionReport, error)
+	Login(context.Context, *LoginRequest) (*TokenResponse, error)
+	Register(context.Context, *RegisterRequest) (*TokenResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*TokenResponse, error)
+	Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error)
+	LogoutAll(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	ChangeUserRole(context.Context, *ChangeUserRoleRequest) (*User, error)
+	DisableUser(context.Context, *UserIdRequest) (*User, error)
+	EnableUser(context.Context, *UserIdRequest) (*User, error)
+	ResetUserPassword(context.Context, *ResetUserPasswordRequest) (*emptypb.Empty, error)
+	InviteUser(context.Context, *InviteUserRequest) (*Invite, error)
+	AcceptInvite(context.Context, *AcceptInviteRequest) (*TokenResponse, error)
+	ListPVZEmployees(context.Context, *ListPVZEmployeesRequest) (*ListUsersResponse, error)
+	AssignEmployee(context.Context, *EmployeeAssignmentRequest) (*emptypb.Empty, error)
+	UnassignEmployee(context.Context, *EmployeeAssignmentRequest) (*emptypb.Empty, error)
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*APIKey, error)
+	ListAPIKeys(context.Context, *emptypb.Empty) (*ListAPIKeysResponse, error)
+	RotateAPIKey(context.Context, *APIKeyIdRequest) (*APIKey, error)
+	RevokeAPIKey(context.Context, *APIKeyIdRequest) (*emptypb.Empty, error)
+	ListCities(context.Context, *emptypb.Empty) (*ListCitiesResponse, error)
+	CreateCity(context.Context, *CreateCityRequest) (*City, error)
+	RenameCity(context.Context, *RenameCityRequest) (*City, error)
+	DeleteCity(context.Context, *DeleteCityRequest) (*emptypb.Empty, error)
+	ListProductTypes(context.Context, *emptypb.Empty) (*ListProductTypesResponse, error)
+	CreateProductType(context.Context, *CreateProductTypeRequest) (*ProductType, error)
+	UpdateProductType(context.Context, *UpdateProductTypeRequest) (*ProductType, error)
+	DeleteProductType(context.Context, *DeleteProductTypeRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPVZServiceServer()
+}
+
+// UnimplementedPVZServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPVZServiceServer struct{}
+
+func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *emptypb.Empty) (*GetPVZListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
+}
+func (UnimplementedPVZServiceServer) ListPVZ(context.Context, *ListPVZRequest) (*ListPVZResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) CreatePVZ(context.Context, *CreatePVZRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) UpdatePVZ(context.Context, *UpdatePVZRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) DeactivatePVZ(context.Context, *DeactivatePVZRequest) (*PVZ, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivatePVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) NearbyPVZ(context.Context, *NearbyPVZRequest) (*NearbyPVZResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NearbyPVZ not implemented")
+}
+func (UnimplementedPVZServiceServer) OpenReception(context.Context, *OpenReceptionRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenReception not implemented")
+}
+func (UnimplementedPVZServiceServer) AddProduct(context.Context, *AddProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) AddProducts(grpc.ClientStreamingServer[AddProductsRequest, AddProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method AddProducts not implemented")
+}
+func (UnimplementedPVZServiceServer) GetProductsByBarcode(context.Context, *BarcodeRequest) (*ProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByBarcode not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteLastProduct(context.Context, *DeleteLastProductRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLastProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedPVZServiceServer) CorrectProductType(context.Context, *CorrectProductTypeRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CorrectProductType not implemented")
+}
+func (UnimplementedPVZServiceServer) UndoProductOperations(context.Context, *ProductOperationsRequest) (*ProductOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoProductOperations not implemented")
+}
+func (UnimplementedPVZServiceServer) RedoProductOperations(context.Context, *ProductOperationsRequest) (*ProductOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedoProductOperations not implemented")
+}
+func (UnimplementedPVZServiceServer) ListProductOperations(context.Context, *ListProductOperationsRequest) (*ProductOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductOperations not implemented")
+}
+func (UnimplementedPVZServiceServer) CloseReception(context.Context, *CloseReceptionRequest) (*Reception, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseReception not implemented")
+}
+func (UnimplementedPVZServiceServer) GetReceptionReport(context.Context, *ReceptionReportRequest) (*ReceptionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceptionReport not implemented")
+}
+func (UnimplementedPVZServiceServer) AcceptReceptionReport(context.Context, *AcceptReceptionReportRequest) (*ReceptionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptReceptionReport not implemented")
+}
+func (UnimplementedPVZServiceServer) Login(context.Context, *LoginRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedPVZServiceServer) Register(context.Context, *RegisterRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedPVZServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedPVZServiceServer) Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedPVZServiceServer) LogoutAll(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutAll not implemented")
+}
+func (UnimplementedPVZServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedPVZServiceServer) ChangeUserRole(context.Context, *ChangeUserRoleRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeUserRole not implemented")
+}
+func (UnimplementedPVZServiceServer) DisableUser(context.Context, *UserIdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedPVZServiceServer) EnableUser(context.Context, *UserIdRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedPVZServiceServer) ResetUserPassword(context.Context, *ResetUserPasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetUserPassword not implemented")
+}
+func (UnimplementedPVZServiceServer) InviteUser(context.Context, *InviteUserRequest) (*Invite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteUser not implemented")
+}
+func (UnimplementedPVZServiceServer) AcceptInvite(context.Context, *AcceptInviteRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvite not implemented")
+}
+func (UnimplementedPVZServiceServer) ListPVZEmployees(context.Context, *ListPVZEmployeesRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPVZEmployees not implemented")
+}
+func (UnimplementedPVZServiceServer) AssignEmployee(context.Context, *EmployeeAssignmentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignEmployee not implemented")
+}
+func (UnimplementedPVZServiceServer) UnassignEmployee(context.Context, *EmployeeAssignmentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignEmployee not implemented")
+}
+func (UnimplementedPVZServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedPVZServiceServer) ListAPIKeys(context.Context, *emptypb.Empty) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedPVZServiceServer) RotateAPIKey(context.Context, *APIKeyIdRequest) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateAPIKey not implemented")
+}
+func (UnimplementedPVZServiceServer) RevokeAPIKey(context.Context, *APIKeyIdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedPVZServiceServer) ListCities(context.Context, *emptypb.Empty) (*ListCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCities not implemented")
+}
+func (UnimplementedPVZServiceServer) CreateCity(context.Context, *CreateCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCity not implemented")
+}
+func (UnimplementedPVZServiceServer) RenameCity(context.Context, *RenameCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCity not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteCity(context.Context, *DeleteCityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCity not implemented")
+}
+func (UnimplementedPVZServiceServer) ListProductTypes(context.Context, *emptypb.Empty) (*ListProductTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductTypes not implemented")
+}
+func (UnimplementedPVZServiceServer) CreateProductType(context.Context, *CreateProductTypeRequest) (*ProductType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProductType not implemented")
+}
+func (UnimplementedPVZServiceServer) UpdateProductType(context.Context, *UpdateProductTypeRequest) (*ProductType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProductType not implemented")
+}
+func (UnimplementedPVZServiceServer) DeleteProductType(context.Context, *DeleteProductTypeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProductType not implemented")
+}
+func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
+func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafePVZServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PVZServiceServer will
+// result in compilation errors.
+type UnsafePVZServiceServer interface {
 	mustEmbedUnimplementedPVZServiceServer()
 }
 
-// UnimplementedPVZServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedPVZServiceServer struct{}
+func RegisterPVZServiceServer(s grpc.ServiceRegistrar, srv PVZServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPVZServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PVZService_ServiceDesc, srv)
+}
+
+func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetPVZList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetPVZList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetPVZList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListPVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListPVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListPVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListPVZ(ctx, req.(*ListPVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CreatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreatePVZ(ctx, req.(*CreatePVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_UpdatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).UpdatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_UpdatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).UpdatePVZ(ctx, req.(*UpdatePVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeactivatePVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivatePVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeactivatePVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeactivatePVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeactivatePVZ(ctx, req.(*DeactivatePVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_NearbyPVZ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearbyPVZRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).NearbyPVZ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_NearbyPVZ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).NearbyPVZ(ctx, req.(*NearbyPVZRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_OpenReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).OpenReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_OpenReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).OpenReception(ctx, req.(*OpenReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).AddProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_AddProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).AddProduct(ctx, req.(*AddProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AddProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PVZServiceServer).AddProducts(&grpc.GenericServerStream[AddProductsRequest, AddProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PVZService_AddProductsServer = grpc.ClientStreamingServer[AddProductsRequest, AddProductsResponse]
+
+func _PVZService_GetProductsByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BarcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetProductsByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetProductsByBarcode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetProductsByBarcode(ctx, req.(*BarcodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteLastProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLastProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteLastProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteLastProduct(ctx, req.(*DeleteLastProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CorrectProductType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorrectProductTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CorrectProductType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CorrectProductType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CorrectProductType(ctx, req.(*CorrectProductTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_UndoProductOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).UndoProductOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_UndoProductOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).UndoProductOperations(ctx, req.(*ProductOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_RedoProductOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).RedoProductOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_RedoProductOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).RedoProductOperations(ctx, req.(*ProductOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListProductOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListProductOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListProductOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListProductOperations(ctx, req.(*ListProductOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CloseReception_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseReceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CloseReception(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CloseReception_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CloseReception(ctx, req.(*CloseReceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_GetReceptionReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceptionReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetReceptionReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetReceptionReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetReceptionReport(ctx, req.(*ReceptionReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AcceptReceptionReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptReceptionReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).AcceptReceptionReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_AcceptReceptionReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).AcceptReceptionReport(ctx, req.(*AcceptReceptionReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_LogoutAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).LogoutAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_LogoutAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).LogoutAll(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ChangeUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ChangeUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ChangeUserRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ChangeUserRole(ctx, req.(*ChangeUserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DisableUser(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).EnableUser(ctx, req.(*UserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ResetUserPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetUserPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ResetUserPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ResetUserPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ResetUserPassword(ctx, req.(*ResetUserPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_InviteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).InviteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_InviteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).InviteUser(ctx, req.(*InviteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AcceptInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).AcceptInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_AcceptInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).AcceptInvite(ctx, req.(*AcceptInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListPVZEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPVZEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListPVZEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListPVZEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListPVZEmployees(ctx, req.(*ListPVZEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_AssignEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).AssignEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_AssignEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).AssignEmployee(ctx, req.(*EmployeeAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_UnassignEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).UnassignEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_UnassignEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).UnassignEmployee(ctx, req.(*EmployeeAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *emptypb.Empty) (*GetPVZListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
+func _PVZService_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListAPIKeys(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
-func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
 
-// UnsafePVZServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PVZServiceServer will
-// result in compilation errors.
-type UnsafePVZServiceServer interface {
-	mustEmbedUnimplementedPVZServiceServer()
+func _PVZService_RotateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeyIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).RotateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_RotateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).RotateAPIKey(ctx, req.(*APIKeyIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterPVZServiceServer(s grpc.ServiceRegistrar, srv PVZServiceServer) {
-	// If the following call pancis, it indicates UnimplementedPVZServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _PVZService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeyIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&PVZService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(PVZServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).RevokeAPIKey(ctx, req.(*APIKeyIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _PVZService_ListCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PVZServiceServer).GetPVZList(ctx, in)
+		return srv.(PVZServiceServer).ListCities(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PVZService_GetPVZList_FullMethodName,
+		FullMethod: PVZService_ListCities_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PVZServiceServer).GetPVZList(ctx, req.(*emptypb.Empty))
+		return srv.(PVZServiceServer).ListCities(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CreateCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreateCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreateCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreateCity(ctx, req.(*CreateCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_RenameCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).RenameCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_RenameCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).RenameCity(ctx, req.(*RenameCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteCity(ctx, req.(*DeleteCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_ListProductTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).ListProductTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_ListProductTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).ListProductTypes(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_CreateProductType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).CreateProductType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_CreateProductType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).CreateProductType(ctx, req.(*CreateProductTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_UpdateProductType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).UpdateProductType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_UpdateProductType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).UpdateProductType(ctx, req.(*UpdateProductTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_DeleteProductType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).DeleteProductType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_DeleteProductType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).DeleteProductType(ctx, req.(*DeleteProductTypeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -116,7 +1638,189 @@ var PVZService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPVZList",
 			Handler:    _PVZService_GetPVZList_Handler,
 		},
+		{
+			MethodName: "ListPVZ",
+			Handler:    _PVZService_ListPVZ_Handler,
+		},
+		{
+			MethodName: "CreatePVZ",
+			Handler:    _PVZService_CreatePVZ_Handler,
+		},
+		{
+			MethodName: "UpdatePVZ",
+			Handler:    _PVZService_UpdatePVZ_Handler,
+		},
+		{
+			MethodName: "DeactivatePVZ",
+			Handler:    _PVZService_DeactivatePVZ_Handler,
+		},
+		{
+			MethodName: "NearbyPVZ",
+			Handler:    _PVZService_NearbyPVZ_Handler,
+		},
+		{
+			MethodName: "OpenReception",
+			Handler:    _PVZService_OpenReception_Handler,
+		},
+		{
+			MethodName: "AddProduct",
+			Handler:    _PVZService_AddProduct_Handler,
+		},
+		{
+			MethodName: "GetProductsByBarcode",
+			Handler:    _PVZService_GetProductsByBarcode_Handler,
+		},
+		{
+			MethodName: "DeleteLastProduct",
+			Handler:    _PVZService_DeleteLastProduct_Handler,
+		},
+		{
+			MethodName: "DeleteProduct",
+			Handler:    _PVZService_DeleteProduct_Handler,
+		},
+		{
+			MethodName: "CorrectProductType",
+			Handler:    _PVZService_CorrectProductType_Handler,
+		},
+		{
+			MethodName: "UndoProductOperations",
+			Handler:    _PVZService_UndoProductOperations_Handler,
+		},
+		{
+			MethodName: "RedoProductOperations",
+			Handler:    _PVZService_RedoProductOperations_Handler,
+		},
+		{
+			MethodName: "ListProductOperations",
+			Handler:    _PVZService_ListProductOperations_Handler,
+		},
+		{
+			MethodName: "CloseReception",
+			Handler:    _PVZService_CloseReception_Handler,
+		},
+		{
+			MethodName: "GetReceptionReport",
+			Handler:    _PVZService_GetReceptionReport_Handler,
+		},
+		{
+			MethodName: "AcceptReceptionReport",
+			Handler:    _PVZService_AcceptReceptionReport_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _PVZService_Login_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _PVZService_Register_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _PVZService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _PVZService_Logout_Handler,
+		},
+		{
+			MethodName: "LogoutAll",
+			Handler:    _PVZService_LogoutAll_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _PVZService_ListUsers_Handler,
+		},
+		{
+			MethodName: "ChangeUserRole",
+			Handler:    _PVZService_ChangeUserRole_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _PVZService_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _PVZService_EnableUser_Handler,
+		},
+		{
+			MethodName: "ResetUserPassword",
+			Handler:    _PVZService_ResetUserPassword_Handler,
+		},
+		{
+			MethodName: "InviteUser",
+			Handler:    _PVZService_InviteUser_Handler,
+		},
+		{
+			MethodName: "AcceptInvite",
+			Handler:    _PVZService_AcceptInvite_Handler,
+		},
+		{
+			MethodName: "ListPVZEmployees",
+			Handler:    _PVZService_ListPVZEmployees_Handler,
+		},
+		{
+			MethodName: "AssignEmployee",
+			Handler:    _PVZService_AssignEmployee_Handler,
+		},
+		{
+			MethodName: "UnassignEmployee",
+			Handler:    _PVZService_UnassignEmployee_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _PVZService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _PVZService_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RotateAPIKey",
+			Handler:    _PVZService_RotateAPIKey_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _PVZService_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "ListCities",
+			Handler:    _PVZService_ListCities_Handler,
+		},
+		{
+			MethodName: "CreateCity",
+			Handler:    _PVZService_CreateCity_Handler,
+		},
+		{
+			MethodName: "RenameCity",
+			Handler:    _PVZService_RenameCity_Handler,
+		},
+		{
+			MethodName: "DeleteCity",
+			Handler:    _PVZService_DeleteCity_Handler,
+		},
+		{
+			MethodName: "ListProductTypes",
+			Handler:    _PVZService_ListProductTypes_Handler,
+		},
+		{
+			MethodName: "CreateProductType",
+			Handler:    _PVZService_CreateProductType_Handler,
+		},
+		{
+			MethodName: "UpdateProductType",
+			Handler:    _PVZService_UpdateProductType_Handler,
+		},
+		{
+			MethodName: "DeleteProductType",
+			Handler:    _PVZService_DeleteProductType_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AddProducts",
+			Handler:       _PVZService_AddProducts_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/pvz/v1/pvz.proto",
 }
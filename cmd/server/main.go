@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -28,6 +29,18 @@ import (
 
 func main() {
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+	var disabled []string
+	if cfg.DevFeatures() {
+		log.Printf("WARNING: running in %q mode with dev-only features enabled: %v is open to anyone; set APP_ENV=prod for production", cfg.Env, api.DevOnlyPaths)
+	} else {
+		disabled = append(disabled, api.DevOnlyPaths...)
+	}
+	if cfg.OIDCIssuer == "" {
+		disabled = append(disabled, api.OIDCPaths...)
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -35,12 +48,36 @@ func main() {
 	db := connectDB(ctx, cfg)
 	defer db.Close()
 
-	rep := repo.New(db)
-	svc := service.New(rep, cfg.JWTSecret)
+	rep := repo.New(db, repo.WithIsolation(pgx.TxIsoLevel(cfg.TxIsolation)), repo.WithTxRetries(cfg.TxMaxRetries))
+	tokens := auth.NewTokenManager(loadKeys(cfg), cfg.AccessTokenTTL, cfg.RefreshTokenTTL, rep)
+	perms := loadPermissions(cfg)
+	apiKeys := auth.NewAPIKeys(rep)
+	opts := []service.Option{
+		service.WithRegistrationMode(cfg.RegistrationMode),
+		service.WithInviteTTL(cfg.InviteTTL),
+		service.WithLoginThrottle(service.LoginThrottle{
+			MaxFailures:   cfg.LoginMaxFailures,
+			MaxIPFailures: cfg.LoginIPFailures,
+			Window:        cfg.LoginWindow,
+			BaseLockout:   cfg.LoginLockout,
+			MaxLockout:    cfg.LoginMaxLockout,
+		}),
+		service.WithPasswordPolicy(loadPasswordPolicy(cfg)),
+		service.WithPermissions(perms),
+	}
+	if cfg.OIDCIssuer != "" {
+		opts = append(opts, service.WithOIDC(loadOIDC(cfg, perms)))
+	}
+	svc := service.New(rep, tokens, opts...)
 
 	router := gin.New()
-	router.Use(logger.Middleware(), metrics.Middleware(), auth.Middleware(cfg.JWTSecret))
-	api.RegisterHTTP(router, svc)
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+	public := api.PublicHTTPPaths(cfg.DevFeatures())
+	router.Use(logger.Middleware(), metrics.Middleware(), auth.Middleware(tokens, apiKeys, public),
+		auth.RequirePermissions(perms, api.HTTPPermissions, public))
+	api.RegisterHTTP(router, api.NewHTTPHandlers(svc), disabled...)
 
 	go func() {
 		addr := ":" + cfg.AppPort
@@ -68,8 +105,11 @@ func main() {
 		if err != nil {
 			log.Fatalf("gRPC listen error: %v", err)
 		}
-		grpcSrv := grpc.NewServer()
-		api.RegisterGRPC(grpcSrv, rep)
+		grpcSrv := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(tokens, apiKeys, perms, api.GRPCAccess)),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(tokens, apiKeys, perms, api.GRPCAccess)),
+		)
+		api.RegisterGRPC(grpcSrv, svc)
 		reflection.Register(grpcSrv)
 		log.Printf("gRPC listening on %s", addr)
 		go func() { <-ctx.Done(); grpcSrv.GracefulStop() }()
@@ -82,6 +122,66 @@ func main() {
 	log.Println("Shutdown signal received, exiting…")
 }
 
+// loadKeys prefers an asymmetric signing key; without one it falls back to
+// the shared JWT_SECRET.
+func loadKeys(cfg config.Config) *auth.KeySet {
+	if cfg.JWTSigningKey == "" {
+		log.Println("JWT_SIGNING_KEY_FILE not set, signing tokens with shared secret (HS256)")
+		return auth.NewHMACKeySet(cfg.JWTSecret)
+	}
+	keys, err := auth.LoadKeySet(cfg.JWTSigningKey, cfg.JWTVerifyKeys)
+	if err != nil {
+		log.Fatalf("failed to load JWT keys: %v", err)
+	}
+	return keys
+}
+
+func loadPermissions(cfg config.Config) *auth.Permissions {
+	if cfg.RolePermissions == "" {
+		return auth.DefaultPermissions()
+	}
+	perms, err := auth.LoadPermissions(cfg.RolePermissions)
+	if err != nil {
+		log.Fatalf("failed to load role permissions: %v", err)
+	}
+	return perms
+}
+
+func loadOIDC(cfg config.Config, perms *auth.Permissions) *auth.OIDCProvider {
+	oc := auth.OIDCConfig{
+		Issuer:       cfg.OIDCIssuer,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		Scopes:       cfg.OIDCScopes,
+		RoleClaim:    cfg.OIDCRoleClaim,
+		DefaultRole:  cfg.OIDCDefaultRole,
+		JWKSCacheTTL: cfg.OIDCJWKSCacheTTL,
+	}
+	for _, m := range cfg.OIDCRoleMapping {
+		if !perms.IsRole(m.Role) {
+			log.Fatalf("invalid OIDC_ROLE_MAPPING: unknown role %q", m.Role)
+		}
+		oc.RoleMapping = append(oc.RoleMapping, auth.RoleMapping{Value: m.Value, Role: m.Role})
+	}
+	if oc.DefaultRole != "" && !perms.IsRole(oc.DefaultRole) {
+		log.Fatalf("invalid OIDC_DEFAULT_ROLE: unknown role %q", oc.DefaultRole)
+	}
+	return auth.NewOIDCProvider(oc)
+}
+
+func loadPasswordPolicy(cfg config.Config) auth.PasswordPolicy {
+	policy := auth.PasswordPolicy{MinLength: cfg.PasswordMinLen, MinClasses: cfg.PasswordClasses}
+	if cfg.BreachedList != "" {
+		list, err := auth.LoadBreachedPasswords(cfg.BreachedList)
+		if err != nil {
+			log.Fatalf("failed to load breached password list: %v", err)
+		}
+		policy.Breached = list
+	}
+	return policy
+}
+
 func connectDB(ctx context.Context, cfg config.Config) *pgxpool.Pool {
 	for i := 0; i < cfg.DBMaxRetries; i++ {
 		if db, err := pgxpool.New(ctx, cfg.DatabaseURL); err == nil {
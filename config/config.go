@@ -1,36 +1,150 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	EnvDev  = "dev"
+	EnvTest = "test"
+	EnvProd = "prod"
+
+	defaultJWTSecret = "secret"
+)
+
 type Config struct {
-	AppPort      string
-	GRPCPort     string
-	MetricsPort  string
-	DatabaseURL  string
-	JWTSecret    string
-	DBMaxRetries int
-	DBRetryDelay time.Duration
+	Env              string
+	AppPort          string
+	GRPCPort         string
+	MetricsPort      string
+	DatabaseURL      string
+	JWTSecret        string
+	JWTSigningKey    string
+	JWTVerifyKeys    []string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	DBMaxRetries     int
+	DBRetryDelay     time.Duration
+	TxIsolation      string
+	TxMaxRetries     int
+	RegistrationMode string
+	InviteTTL        time.Duration
+	TrustedProxies   []string
+	LoginMaxFailures int
+	LoginIPFailures  int
+	LoginWindow      time.Duration
+	LoginLockout     time.Duration
+	LoginMaxLockout  time.Duration
+	PasswordMinLen   int
+	PasswordClasses  int
+	BreachedList     string
+	RolePermissions  string
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCScopes       []string
+	OIDCRoleClaim    string
+	OIDCRoleMapping  []RoleMapping
+	OIDCDefaultRole  string
+	OIDCJWKSCacheTTL time.Duration
+}
+
+// RoleMapping assigns Role to SSO users whose role claim contains Value.
+type RoleMapping struct {
+	Value string
+	Role  string
 }
 
 func Load() Config {
 	godotenv.Load()
 	return Config{
-		AppPort:      getenv("APP_PORT", "8080"),
-		GRPCPort:     getenv("GRPC_PORT", "3000"),
-		MetricsPort:  getenv("METRICS_PORT", "9000"),
-		DatabaseURL:  getenv("DATABASE_URL", "postgres://postgres:pass@db:5432/pvz?sslmode=disable"),
-		JWTSecret:    getenv("JWT_SECRET", "secret"),
-		DBMaxRetries: atoi(getenv("DB_MAX_RETRIES", "5")),
-		DBRetryDelay: parseDuration(getenv("DB_RETRY_DELAY", "2s")),
+		Env:              getenv("APP_ENV", EnvDev),
+		AppPort:          getenv("APP_PORT", "8080"),
+		GRPCPort:         getenv("GRPC_PORT", "3000"),
+		MetricsPort:      getenv("METRICS_PORT", "9000"),
+		DatabaseURL:      getenv("DATABASE_URL", "postgres://postgres:pass@db:5432/pvz?sslmode=disable"),
+		JWTSecret:        getenv("JWT_SECRET", defaultJWTSecret),
+		JWTSigningKey:    os.Getenv("JWT_SIGNING_KEY_FILE"),
+		JWTVerifyKeys:    splitList(os.Getenv("JWT_VERIFY_KEY_FILES")),
+		AccessTokenTTL:   parseDuration(getenv("ACCESS_TOKEN_TTL", "15m")),
+		RefreshTokenTTL:  parseDuration(getenv("REFRESH_TOKEN_TTL", "720h")),
+		DBMaxRetries:     atoi(getenv("DB_MAX_RETRIES", "5")),
+		DBRetryDelay:     parseDuration(getenv("DB_RETRY_DELAY", "2s")),
+		TxIsolation:      getenv("TX_ISOLATION", "read committed"),
+		TxMaxRetries:     atoi(getenv("TX_MAX_RETRIES", "3")),
+		RegistrationMode: getenv("REGISTRATION_MODE", "open"),
+		InviteTTL:        parseDuration(getenv("INVITE_TTL", "72h")),
+		TrustedProxies:   splitList(os.Getenv("TRUSTED_PROXIES")),
+		LoginMaxFailures: atoi(getenv("LOGIN_MAX_FAILURES", "5")),
+		LoginIPFailures:  atoi(getenv("LOGIN_IP_MAX_FAILURES", "20")),
+		LoginWindow:      parseDuration(getenv("LOGIN_FAILURE_WINDOW", "15m")),
+		LoginLockout:     parseDuration(getenv("LOGIN_LOCKOUT", "1m")),
+		LoginMaxLockout:  parseDuration(getenv("LOGIN_MAX_LOCKOUT", "1h")),
+		PasswordMinLen:   atoi(getenv("PASSWORD_MIN_LENGTH", "8")),
+		PasswordClasses:  atoi(getenv("PASSWORD_MIN_CLASSES", "2")),
+		BreachedList:     os.Getenv("PASSWORD_BREACHED_LIST_FILE"),
+		RolePermissions:  os.Getenv("ROLE_PERMISSIONS_FILE"),
+		OIDCIssuer:       os.Getenv("OIDC_ISSUER_URL"),
+		OIDCClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		OIDCClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		OIDCScopes:       splitList(getenv("OIDC_SCOPES", "openid,email,profile")),
+		OIDCRoleClaim:    getenv("OIDC_ROLE_CLAIM", "groups"),
+		OIDCRoleMapping:  parseRoleMapping(os.Getenv("OIDC_ROLE_MAPPING")),
+		OIDCDefaultRole:  os.Getenv("OIDC_DEFAULT_ROLE"),
+		OIDCJWKSCacheTTL: parseDuration(getenv("OIDC_JWKS_CACHE_TTL", "1h")),
 	}
 }
 
+// DevFeatures reports whether development-only endpoints such as /dummyLogin
+// are enabled.
+func (c Config) DevFeatures() bool {
+	return c.Env != EnvProd
+}
+
+func (c Config) Validate() error {
+	switch c.Env {
+	case EnvDev, EnvTest, EnvProd:
+	default:
+		return fmt.Errorf("unknown APP_ENV %q (want %s, %s or %s)", c.Env, EnvDev, EnvTest, EnvProd)
+	}
+	switch c.RegistrationMode {
+	case "", "open", "employee", "invite":
+	default:
+		return fmt.Errorf("unknown REGISTRATION_MODE %q (want open, employee or invite)", c.RegistrationMode)
+	}
+	switch c.TxIsolation {
+	case "", "serializable", "repeatable read", "read committed", "read uncommitted":
+	default:
+		return fmt.Errorf("unknown TX_ISOLATION %q (want serializable, repeatable read, read committed or read uncommitted)", c.TxIsolation)
+	}
+	if c.PasswordClasses < 0 || c.PasswordClasses > 4 {
+		return fmt.Errorf("PASSWORD_MIN_CLASSES must be between 0 and 4, got %d", c.PasswordClasses)
+	}
+	if c.OIDCIssuer != "" {
+		if c.OIDCClientID == "" || c.OIDCRedirectURL == "" {
+			return errors.New("OIDC_ISSUER_URL requires OIDC_CLIENT_ID and OIDC_REDIRECT_URL")
+		}
+		for _, m := range c.OIDCRoleMapping {
+			if m.Value == "" || m.Role == "" {
+				return fmt.Errorf("invalid OIDC_ROLE_MAPPING entry %q (want claim=role)", m.Value+"="+m.Role)
+			}
+		}
+	}
+	if c.Env == EnvProd && c.JWTSigningKey == "" && (c.JWTSecret == defaultJWTSecret || c.JWTSecret == "") {
+		return errors.New("refusing to start in prod with the default JWT secret: set JWT_SECRET or JWT_SIGNING_KEY_FILE")
+	}
+	return nil
+}
+
 func getenv(key, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -38,6 +152,27 @@ func getenv(key, def string) string {
 	return def
 }
 
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// parseRoleMapping reads "value=role" pairs separated by commas, keeping their
+// order since the first match wins.
+func parseRoleMapping(s string) []RoleMapping {
+	var out []RoleMapping
+	for _, pair := range splitList(s) {
+		value, role, _ := strings.Cut(pair, "=")
+		out = append(out, RoleMapping{Value: strings.TrimSpace(value), Role: strings.TrimSpace(role)})
+	}
+	return out
+}
+
 func atoi(s string) int {
 	i, _ := strconv.Atoi(s)
 	return i
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"dev default secret", Config{Env: EnvDev, JWTSecret: defaultJWTSecret}, false},
+		{"test default secret", Config{Env: EnvTest, JWTSecret: defaultJWTSecret}, false},
+		{"prod default secret", Config{Env: EnvProd, JWTSecret: defaultJWTSecret}, true},
+		{"prod empty secret", Config{Env: EnvProd}, true},
+		{"prod custom secret", Config{Env: EnvProd, JWTSecret: "s3cr3t-value"}, false},
+		{"prod signing key", Config{Env: EnvProd, JWTSecret: defaultJWTSecret, JWTSigningKey: "/keys/jwt.pem"}, false},
+		{"unknown env", Config{Env: "staging", JWTSecret: "x"}, true},
+		{"invite registration", Config{Env: EnvDev, RegistrationMode: "invite"}, false},
+		{"unknown registration", Config{Env: EnvDev, RegistrationMode: "closed"}, true},
+		{"repeatable read", Config{Env: EnvDev, TxIsolation: "repeatable read"}, false},
+		{"unknown isolation", Config{Env: EnvDev, TxIsolation: "snapshot"}, true},
+		{"isolation with underscore", Config{Env: EnvDev, TxIsolation: "read_committed"}, true},
+		{"password classes out of range", Config{Env: EnvDev, PasswordClasses: 5}, true},
+		{"oidc", Config{Env: EnvDev, OIDCIssuer: "https://sso", OIDCClientID: "pvz", OIDCRedirectURL: "https://pvz/oidc/callback"}, false},
+		{"oidc without client", Config{Env: EnvDev, OIDCIssuer: "https://sso"}, true},
+		{"oidc bad mapping", Config{Env: EnvDev, OIDCIssuer: "https://sso", OIDCClientID: "pvz", OIDCRedirectURL: "https://pvz/cb",
+			OIDCRoleMapping: []RoleMapping{{Value: "staff"}}}, true},
+	}
+	for _, tc := range cases {
+		err := tc.cfg.Validate()
+		assert.Equal(t, tc.wantErr, err != nil, tc.name)
+	}
+}
+
+func TestParseRoleMapping(t *testing.T) {
+	assert.Equal(t, []RoleMapping{{"pvz-admins", "admin"}, {"pvz-staff", "employee"}, {"broken", ""}},
+		parseRoleMapping("pvz-admins=admin, pvz-staff = employee,broken"))
+	assert.Nil(t, parseRoleMapping(""))
+}
+
+func TestDevFeatures(t *testing.T) {
+	assert.True(t, Config{Env: EnvDev}.DevFeatures())
+	assert.True(t, Config{Env: EnvTest}.DevFeatures())
+	assert.False(t, Config{Env: EnvProd}.DevFeatures())
+}
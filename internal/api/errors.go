@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"pvz-backend-service/internal/service"
+	"pvz-backend-service/lib/e"
+)
+
+func httpStatus(kind e.Kind) int {
+	switch kind {
+	case e.KindNotFound:
+		return http.StatusNotFound
+	case e.KindConflict, e.KindPrecondition:
+		return http.StatusConflict
+	case e.KindValidation:
+		return http.StatusUnprocessableEntity
+	case e.KindForbidden:
+		return http.StatusForbidden
+	case e.KindUnauthorized:
+		return http.StatusUnauthorized
+	case e.KindTooManyRequests:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+func grpcCode(kind e.Kind) codes.Code {
+	switch kind {
+	case e.KindNotFound:
+		return codes.NotFound
+	case e.KindConflict:
+		return codes.AlreadyExists
+	case e.KindPrecondition:
+		return codes.FailedPrecondition
+	case e.KindValidation:
+		return codes.InvalidArgument
+	case e.KindForbidden:
+		return codes.PermissionDenied
+	case e.KindUnauthorized:
+		return codes.Unauthenticated
+	case e.KindTooManyRequests:
+		return codes.ResourceExhausted
+	}
+	return codes.Internal
+}
+
+func writeError(c *gin.Context, err error) {
+	kind := e.KindOf(err)
+	if kind == e.KindInternal {
+		log.Error().Err(err).Str("path", c.FullPath()).Msg("request failed")
+	}
+	var locked *service.LoginLockedError
+	if errors.As(err, &locked) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+	}
+	c.JSON(httpStatus(kind), gin.H{"message": e.Message(err)})
+}
+
+func grpcError(err error) error {
+	kind := e.KindOf(err)
+	if kind == e.KindInternal {
+		log.Error().Err(err).Msg("grpc call failed")
+	}
+	return status.Error(grpcCode(kind), e.Message(err))
+}
@@ -2,33 +2,285 @@ package api
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
 
 	pvzpb "pvz-backend-service/api/pvz/v1"
-	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/service"
 
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionalphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var GRPCAccess = auth.GRPCRules{
+	Public: map[string]bool{
+		pvzpb.PVZService_Login_FullMethodName:        true,
+		pvzpb.PVZService_Register_FullMethodName:     true,
+		pvzpb.PVZService_RefreshToken_FullMethodName: true,
+		pvzpb.PVZService_AcceptInvite_FullMethodName: true,
+
+		reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName:      true,
+		reflectionalphapb.ServerReflection_ServerReflectionInfo_FullMethodName: true,
+	},
+	Permissions: map[string]auth.Permission{
+		pvzpb.PVZService_GetPVZList_FullMethodName:            auth.PermPVZRead,
+		pvzpb.PVZService_ListPVZ_FullMethodName:               auth.PermPVZRead,
+		pvzpb.PVZService_CreatePVZ_FullMethodName:             auth.PermPVZCreate,
+		pvzpb.PVZService_UpdatePVZ_FullMethodName:             auth.PermPVZManage,
+		pvzpb.PVZService_DeactivatePVZ_FullMethodName:         auth.PermPVZManage,
+		pvzpb.PVZService_NearbyPVZ_FullMethodName:             auth.PermPVZRead,
+		pvzpb.PVZService_OpenReception_FullMethodName:         auth.PermReceptionOpen,
+		pvzpb.PVZService_AddProduct_FullMethodName:            auth.PermProductAdd,
+		pvzpb.PVZService_AddProducts_FullMethodName:           auth.PermProductAdd,
+		pvzpb.PVZService_GetProductsByBarcode_FullMethodName:  auth.PermPVZRead,
+		pvzpb.PVZService_DeleteLastProduct_FullMethodName:     auth.PermProductDelete,
+		pvzpb.PVZService_DeleteProduct_FullMethodName:         auth.PermProductDelete,
+		pvzpb.PVZService_CorrectProductType_FullMethodName:    auth.PermProductAdd,
+		pvzpb.PVZService_UndoProductOperations_FullMethodName: auth.PermProductDelete,
+		pvzpb.PVZService_RedoProductOperations_FullMethodName: auth.PermProductDelete,
+		pvzpb.PVZService_ListProductOperations_FullMethodName: auth.PermPVZRead,
+		pvzpb.PVZService_CloseReception_FullMethodName:        auth.PermReceptionClose,
+		pvzpb.PVZService_GetReceptionReport_FullMethodName:    auth.PermPVZRead,
+		pvzpb.PVZService_AcceptReceptionReport_FullMethodName: auth.PermReceptionAccept,
+		pvzpb.PVZService_ListUsers_FullMethodName:             auth.PermUserRead,
+		pvzpb.PVZService_ChangeUserRole_FullMethodName:        auth.PermUserManage,
+		pvzpb.PVZService_DisableUser_FullMethodName:           auth.PermUserManage,
+		pvzpb.PVZService_EnableUser_FullMethodName:            auth.PermUserManage,
+		pvzpb.PVZService_ResetUserPassword_FullMethodName:     auth.PermUserManage,
+		pvzpb.PVZService_InviteUser_FullMethodName:            auth.PermUserInvite,
+		pvzpb.PVZService_ListPVZEmployees_FullMethodName:      auth.PermUserRead,
+		pvzpb.PVZService_AssignEmployee_FullMethodName:        auth.PermPVZAssign,
+		pvzpb.PVZService_UnassignEmployee_FullMethodName:      auth.PermPVZAssign,
+		pvzpb.PVZService_CreateAPIKey_FullMethodName:          auth.PermAPIKeyManage,
+		pvzpb.PVZService_ListAPIKeys_FullMethodName:           auth.PermAPIKeyManage,
+		pvzpb.PVZService_RotateAPIKey_FullMethodName:          auth.PermAPIKeyManage,
+		pvzpb.PVZService_RevokeAPIKey_FullMethodName:          auth.PermAPIKeyManage,
+		pvzpb.PVZService_ListCities_FullMethodName:            auth.PermPVZRead,
+		pvzpb.PVZService_CreateCity_FullMethodName:            auth.PermCityManage,
+		pvzpb.PVZService_RenameCity_FullMethodName:            auth.PermCityManage,
+		pvzpb.PVZService_DeleteCity_FullMethodName:            auth.PermCityManage,
+		pvzpb.PVZService_ListProductTypes_FullMethodName:      auth.PermPVZRead,
+		pvzpb.PVZService_CreateProductType_FullMethodName:     auth.PermCatalogManage,
+		pvzpb.PVZService_UpdateProductType_FullMethodName:     auth.PermCatalogManage,
+		pvzpb.PVZService_DeleteProductType_FullMethodName:     auth.PermCatalogManage,
+	},
+}
+
 type grpcServer struct {
 	pvzpb.UnimplementedPVZServiceServer
-	store repo.Repository
+	svc service.Service
 }
 
-func RegisterGRPC(s grpc.ServiceRegistrar, store repo.Repository) {
-	srv := &grpcServer{store: store}
+func RegisterGRPC(s grpc.ServiceRegistrar, svc service.Service) {
+	srv := &grpcServer{svc: svc}
 	pvzpb.RegisterPVZServiceServer(s, srv)
 }
 
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+func toPbPVZ(p model.PVZ) *pvzpb.PVZ {
+	pb := &pvzpb.PVZ{
+		Id: p.ID, City: p.City, RegistrationDate: timestamppb.New(p.RegistrationDate),
+		Address: p.Address, Latitude: p.Latitude, Longitude: p.Longitude, Timezone: p.Timezone, Status: p.Status,
+	}
+	for _, wh := range p.WorkingHours {
+		pb.WorkingHours = append(pb.WorkingHours, &pvzpb.WorkingHours{Weekday: int32(wh.Weekday), Opens: wh.Opens, Closes: wh.Closes})
+	}
+	if p.Capacity != nil {
+		c := int32(*p.Capacity)
+		pb.Capacity = &c
+	}
+	return pb
+}
+
+func fromPbWorkingHours(days []*pvzpb.WorkingHours) []model.WorkingHours {
+	hours := []model.WorkingHours{}
+	for _, d := range days {
+		hours = append(hours, model.WorkingHours{Weekday: int(d.GetWeekday()), Opens: d.GetOpens(), Closes: d.GetCloses()})
+	}
+	return hours
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func toPbCity(c model.City) *pvzpb.City {
+	return &pvzpb.City{Name: c.Name, CreatedAt: timestamppb.New(c.CreatedAt)}
+}
+
+func toPbProductType(t model.ProductType) *pvzpb.ProductType {
+	pb := &pvzpb.ProductType{Name: t.Name, Fragile: t.Fragile, RequiresIdCheck: t.RequiresIDCheck, CreatedAt: timestamppb.New(t.CreatedAt)}
+	if t.MaxWeightGrams != nil {
+		w := int32(*t.MaxWeightGrams)
+		pb.MaxWeightGrams = &w
+	}
+	if t.DeprecatedAt != nil {
+		pb.DeprecatedAt = timestamppb.New(*t.DeprecatedAt)
+	}
+	return pb
+}
+
+func toPbReception(r model.Reception) *pvzpb.Reception {
+	pb := &pvzpb.Reception{Id: r.ID, DateTime: timestamppb.New(r.DateTime), PvzId: r.PVZID, Status: r.Status}
+	if r.Manifest != nil {
+		pb.Manifest = &pvzpb.Manifest{Supplier: r.Manifest.Supplier, Barcodes: r.Manifest.Barcodes}
+		for _, it := range r.Manifest.Items {
+			pb.Manifest.Items = append(pb.Manifest.Items, &pvzpb.ManifestItem{Type: it.Type, Count: int32(it.Count)})
+		}
+	}
+	return pb
+}
+
+func fromPbManifest(m *pvzpb.Manifest) *model.Manifest {
+	if m == nil {
+		return nil
+	}
+	res := &model.Manifest{Supplier: m.GetSupplier(), Barcodes: m.GetBarcodes()}
+	for _, it := range m.GetItems() {
+		res.Items = append(res.Items, model.ManifestItem{Type: it.GetType(), Count: int(it.GetCount())})
+	}
+	return res
+}
+
+func toPbLines(lines []model.DiscrepancyLine) []*pvzpb.DiscrepancyLine {
+	var res []*pvzpb.DiscrepancyLine
+	for _, l := range lines {
+		res = append(res, &pvzpb.DiscrepancyLine{Type: l.Type, Expected: int32(l.Expected), Received: int32(l.Received)})
+	}
+	return res
+}
+
+func toPbReport(r model.ReceptionReport) *pvzpb.ReceptionReport {
+	pb := &pvzpb.ReceptionReport{
+		ReceptionId: r.ReceptionID, PvzId: r.PVZID, Supplier: r.Supplier, Status: r.Status,
+		Missing: toPbLines(r.Missing), Surplus: toPbLines(r.Surplus), Unexpected: toPbLines(r.Unexpected),
+		MissingBarcodes: r.MissingBarcodes, UnexpectedBarcodes: r.UnexpectedBarcodes,
+		CreatedAt: timestamppb.New(r.CreatedAt), Comment: r.Comment,
+	}
+	if r.AcceptedBy != nil {
+		pb.AcceptedBy = *r.AcceptedBy
+	}
+	if r.AcceptedAt != nil {
+		pb.AcceptedAt = timestamppb.New(*r.AcceptedAt)
+	}
+	return pb
+}
+
+func toPbProduct(p model.Product) *pvzpb.Product {
+	pb := &pvzpb.Product{Id: p.ID, DateTime: timestamppb.New(p.DateTime), Type: p.Type, ReceptionId: p.ReceptionID, Barcode: p.Barcode, Sku: p.SKU}
+	if p.WeightGrams != nil {
+		w := int32(*p.WeightGrams)
+		pb.WeightGrams = &w
+	}
+	if d := p.Dimensions; d != nil {
+		pb.Dimensions = &pvzpb.Dimensions{LengthMm: int32(d.LengthMm), WidthMm: int32(d.WidthMm), HeightMm: int32(d.HeightMm)}
+	}
+	return pb
+}
+
+func toPbOperations(ops []model.ProductOperation) *pvzpb.ProductOperationsResponse {
+	resp := &pvzpb.ProductOperationsResponse{}
+	for _, op := range ops {
+		resp.Operations = append(resp.Operations, &pvzpb.ProductOperation{
+			Id: op.ID, ReceptionId: op.ReceptionID, Kind: op.Kind, Product: toPbProduct(op.Product),
+			PreviousType: op.PreviousType, TargetId: op.TargetID, State: op.State,
+			UserId: op.UserID, ApiKeyId: op.APIKeyID, CreatedAt: timestamppb.New(op.CreatedAt),
+		})
+	}
+	return resp
+}
+
+func fromPbNewProduct(p *pvzpb.NewProduct) model.Product {
+	if p == nil {
+		return model.Product{}
+	}
+	return model.Product{
+		Type: p.GetType(), Barcode: p.GetBarcode(), SKU: p.GetSku(),
+		WeightGrams: intPtr(p.WeightGrams), Dimensions: fromPbDimensions(p.GetDimensions()),
+	}
+}
+
+func toPbBatchResult(res model.BatchResult) *pvzpb.AddProductsResponse {
+	pb := &pvzpb.AddProductsResponse{Added: int32(res.Added), Failed: int32(res.Failed)}
+	for _, it := range res.Items {
+		item := &pvzpb.BatchItem{Index: int32(it.Index), Status: it.Status, Error: it.Error}
+		if it.Product != nil {
+			item.Product = toPbProduct(*it.Product)
+		}
+		pb.Items = append(pb.Items, item)
+	}
+	return pb
+}
+
+func fromPbDimensions(d *pvzpb.Dimensions) *model.Dimensions {
+	if d == nil {
+		return nil
+	}
+	return &model.Dimensions{LengthMm: int(d.GetLengthMm()), WidthMm: int(d.GetWidthMm()), HeightMm: int(d.GetHeightMm())}
+}
+
+func toPbUser(u model.User) *pvzpb.User {
+	pb := &pvzpb.User{Id: u.ID, Email: u.Email, Role: u.Role, CreatedAt: timestamppb.New(u.CreatedAt)}
+	if u.DisabledAt != nil {
+		pb.DisabledAt = timestamppb.New(*u.DisabledAt)
+	}
+	return pb
+}
+
+func toPbAPIKey(k model.APIKey, key string) *pvzpb.APIKey {
+	pb := &pvzpb.APIKey{Id: k.ID, Name: k.Name, Prefix: k.Prefix, Scopes: k.Scopes, CreatedBy: k.CreatedBy, CreatedAt: timestamppb.New(k.CreatedAt), Key: key}
+	if k.ExpiresAt != nil {
+		pb.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+	if k.LastUsedAt != nil {
+		pb.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		pb.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	return pb
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
 func (g *grpcServer) GetPVZList(ctx context.Context, _ *emptypb.Empty) (*pvzpb.GetPVZListResponse, error) {
-	pvzs, err := g.store.ListPVZ(ctx, "", "", 100, 0)
+	list, err := g.svc.ListAllPVZ(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list PVZs: %v", err)
+		log.Error().Err(err).Msg("GetPVZList failed")
+		return nil, status.Error(codes.Internal, "failed to list PVZs")
 	}
 	resp := &pvzpb.GetPVZListResponse{}
-	for _, p := range pvzs {
+	for _, p := range list {
 		resp.Pvz = append(resp.Pvz, &pvzpb.PVZ{
 			Id:   p.ID,
 			City: p.City,
@@ -36,3 +288,446 @@ func (g *grpcServer) GetPVZList(ctx context.Context, _ *emptypb.Empty) (*pvzpb.G
 	}
 	return resp, nil
 }
+
+func (g *grpcServer) ListPVZ(ctx context.Context, req *pvzpb.ListPVZRequest) (*pvzpb.ListPVZResponse, error) {
+	list, next, err := g.svc.ListPVZ(ctx,
+		formatTimestamp(req.GetStartDate()), formatTimestamp(req.GetEndDate()),
+		int(req.GetPage()), int(req.GetLimit()), req.GetAfter(),
+	)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ListPVZResponse{NextCursor: next}
+	for _, p := range list {
+		item := &pvzpb.PVZWithReceptions{Pvz: toPbPVZ(p.PVZ)}
+		for _, r := range p.Receptions {
+			rec := &pvzpb.ReceptionWithProducts{Reception: toPbReception(r.Reception)}
+			for _, pr := range r.Products {
+				rec.Products = append(rec.Products, toPbProduct(pr))
+			}
+			item.Receptions = append(item.Receptions, rec)
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) CreatePVZ(ctx context.Context, req *pvzpb.CreatePVZRequest) (*pvzpb.PVZ, error) {
+	p, err := g.svc.CreatePVZ(ctx, auth.ActorFromContext(ctx), model.PVZ{
+		City: req.GetCity(), Address: req.GetAddress(), Latitude: req.Latitude, Longitude: req.Longitude,
+		Timezone: req.GetTimezone(), WorkingHours: fromPbWorkingHours(req.GetWorkingHours()), Capacity: intPtr(req.Capacity),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbPVZ(p), nil
+}
+
+func (g *grpcServer) UpdatePVZ(ctx context.Context, req *pvzpb.UpdatePVZRequest) (*pvzpb.PVZ, error) {
+	u := model.PVZUpdate{
+		City: req.City, Address: req.Address, Latitude: req.Latitude, Longitude: req.Longitude,
+		Timezone: req.Timezone, Capacity: intPtr(req.Capacity), Status: req.Status,
+	}
+	if req.WorkingHours != nil {
+		hours := fromPbWorkingHours(req.WorkingHours.GetDays())
+		u.WorkingHours = &hours
+	}
+	p, err := g.svc.UpdatePVZ(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), u)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbPVZ(p), nil
+}
+
+func (g *grpcServer) DeactivatePVZ(ctx context.Context, req *pvzpb.DeactivatePVZRequest) (*pvzpb.PVZ, error) {
+	p, err := g.svc.DeactivatePVZ(ctx, auth.ActorFromContext(ctx), req.GetPvzId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbPVZ(p), nil
+}
+
+func (g *grpcServer) NearbyPVZ(ctx context.Context, req *pvzpb.NearbyPVZRequest) (*pvzpb.NearbyPVZResponse, error) {
+	list, err := g.svc.NearbyPVZ(ctx, auth.ActorFromContext(ctx),
+		req.GetLatitude(), req.GetLongitude(), req.GetRadiusKm(), int(req.GetLimit()), req.GetOpenOnly())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.NearbyPVZResponse{}
+	for _, n := range list {
+		resp.Items = append(resp.Items, &pvzpb.NearbyPVZ{Pvz: toPbPVZ(n.PVZ), DistanceKm: n.DistanceKm, OpenNow: n.OpenNow})
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) OpenReception(ctx context.Context, req *pvzpb.OpenReceptionRequest) (*pvzpb.Reception, error) {
+	r, err := g.svc.OpenReception(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), fromPbManifest(req.GetManifest()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbReception(r), nil
+}
+
+func (g *grpcServer) AddProduct(ctx context.Context, req *pvzpb.AddProductRequest) (*pvzpb.Product, error) {
+	in := model.Product{
+		Type: req.GetType(), Barcode: req.GetBarcode(), SKU: req.GetSku(),
+		WeightGrams: intPtr(req.WeightGrams), Dimensions: fromPbDimensions(req.GetDimensions()),
+	}
+	p, err := g.svc.AddProduct(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), in)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbProduct(p), nil
+}
+
+// AddProducts collects a client-streamed batch and adds it at once. A batch
+// that added nothing still answers OK; the items say why.
+func (g *grpcServer) AddProducts(stream pvzpb.PVZService_AddProductsServer) error {
+	ctx := stream.Context()
+	var pvzID string
+	var partial bool
+	var products []model.Product
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			pvzID, partial = req.GetPvzId(), req.GetPartial()
+		} else if req.GetPvzId() != "" && req.GetPvzId() != pvzID {
+			return status.Error(codes.InvalidArgument, "all products of a batch must be for the same pvz")
+		}
+		if len(products) == service.MaxBatchProducts {
+			return status.Errorf(codes.InvalidArgument, "a batch must hold at most %d products", service.MaxBatchProducts)
+		}
+		products = append(products, fromPbNewProduct(req.GetProduct()))
+	}
+	res, err := g.svc.AddProducts(ctx, auth.ActorFromContext(ctx), pvzID, products, partial)
+	if err != nil {
+		return grpcError(err)
+	}
+	return stream.SendAndClose(toPbBatchResult(res))
+}
+
+func (g *grpcServer) GetProductsByBarcode(ctx context.Context, req *pvzpb.BarcodeRequest) (*pvzpb.ProductsResponse, error) {
+	products, err := g.svc.ProductsByBarcode(ctx, auth.ActorFromContext(ctx), req.GetBarcode())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ProductsResponse{}
+	for _, p := range products {
+		resp.Products = append(resp.Products, toPbProduct(p))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) DeleteLastProduct(ctx context.Context, req *pvzpb.DeleteLastProductRequest) (*emptypb.Empty, error) {
+	if err := g.svc.DeleteLastProduct(ctx, auth.ActorFromContext(ctx), req.GetPvzId()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) DeleteProduct(ctx context.Context, req *pvzpb.DeleteProductRequest) (*pvzpb.Product, error) {
+	p, err := g.svc.DeleteProduct(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), req.GetProductId(), req.GetBarcode())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbProduct(p), nil
+}
+
+func (g *grpcServer) CorrectProductType(ctx context.Context, req *pvzpb.CorrectProductTypeRequest) (*pvzpb.Product, error) {
+	p, err := g.svc.CorrectProductType(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), req.GetProductId(), req.GetType())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbProduct(p), nil
+}
+
+func (g *grpcServer) UndoProductOperations(ctx context.Context, req *pvzpb.ProductOperationsRequest) (*pvzpb.ProductOperationsResponse, error) {
+	ops, err := g.svc.UndoProductOperations(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), int(req.GetSteps()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbOperations(ops), nil
+}
+
+func (g *grpcServer) RedoProductOperations(ctx context.Context, req *pvzpb.ProductOperationsRequest) (*pvzpb.ProductOperationsResponse, error) {
+	ops, err := g.svc.RedoProductOperations(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), int(req.GetSteps()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbOperations(ops), nil
+}
+
+func (g *grpcServer) ListProductOperations(ctx context.Context, req *pvzpb.ListProductOperationsRequest) (*pvzpb.ProductOperationsResponse, error) {
+	ops, err := g.svc.ProductOperations(ctx, auth.ActorFromContext(ctx), req.GetPvzId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbOperations(ops), nil
+}
+
+func (g *grpcServer) CloseReception(ctx context.Context, req *pvzpb.CloseReceptionRequest) (*pvzpb.Reception, error) {
+	r, err := g.svc.CloseReception(ctx, auth.ActorFromContext(ctx), req.GetPvzId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbReception(r), nil
+}
+
+func (g *grpcServer) GetReceptionReport(ctx context.Context, req *pvzpb.ReceptionReportRequest) (*pvzpb.ReceptionReport, error) {
+	rep, err := g.svc.ReceptionReport(ctx, auth.ActorFromContext(ctx), req.GetReceptionId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbReport(rep), nil
+}
+
+func (g *grpcServer) AcceptReceptionReport(ctx context.Context, req *pvzpb.AcceptReceptionReportRequest) (*pvzpb.ReceptionReport, error) {
+	rep, err := g.svc.AcceptReceptionReport(ctx, auth.ActorFromContext(ctx), req.GetReceptionId(), req.GetComment())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbReport(rep), nil
+}
+
+func (g *grpcServer) Login(ctx context.Context, req *pvzpb.LoginRequest) (*pvzpb.TokenResponse, error) {
+	pair, err := g.svc.Login(ctx, req.GetEmail(), req.GetPassword(), peerIP(ctx))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pvzpb.TokenResponse{Token: pair.Token, RefreshToken: pair.RefreshToken}, nil
+}
+
+func (g *grpcServer) Register(ctx context.Context, req *pvzpb.RegisterRequest) (*pvzpb.TokenResponse, error) {
+	pair, err := g.svc.Register(ctx, req.GetEmail(), req.GetPassword(), req.GetRole())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pvzpb.TokenResponse{Token: pair.Token, RefreshToken: pair.RefreshToken}, nil
+}
+
+func (g *grpcServer) RefreshToken(ctx context.Context, req *pvzpb.RefreshTokenRequest) (*pvzpb.TokenResponse, error) {
+	pair, err := g.svc.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pvzpb.TokenResponse{Token: pair.Token, RefreshToken: pair.RefreshToken}, nil
+}
+
+func (g *grpcServer) Logout(ctx context.Context, req *pvzpb.LogoutRequest) (*emptypb.Empty, error) {
+	if err := g.svc.Logout(ctx, auth.ActorFromContext(ctx), req.GetRefreshToken()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) LogoutAll(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := g.svc.LogoutAll(ctx, auth.ActorFromContext(ctx)); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) ListUsers(ctx context.Context, req *pvzpb.ListUsersRequest) (*pvzpb.ListUsersResponse, error) {
+	users, err := g.svc.ListUsers(ctx, auth.ActorFromContext(ctx), req.GetQuery(), req.GetRole(), int(req.GetPage()), int(req.GetLimit()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ListUsersResponse{}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toPbUser(u))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) ChangeUserRole(ctx context.Context, req *pvzpb.ChangeUserRoleRequest) (*pvzpb.User, error) {
+	u, err := g.svc.ChangeUserRole(ctx, auth.ActorFromContext(ctx), req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbUser(u), nil
+}
+
+func (g *grpcServer) DisableUser(ctx context.Context, req *pvzpb.UserIdRequest) (*pvzpb.User, error) {
+	u, err := g.svc.SetUserDisabled(ctx, auth.ActorFromContext(ctx), req.GetUserId(), true)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbUser(u), nil
+}
+
+func (g *grpcServer) EnableUser(ctx context.Context, req *pvzpb.UserIdRequest) (*pvzpb.User, error) {
+	u, err := g.svc.SetUserDisabled(ctx, auth.ActorFromContext(ctx), req.GetUserId(), false)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbUser(u), nil
+}
+
+func (g *grpcServer) ResetUserPassword(ctx context.Context, req *pvzpb.ResetUserPasswordRequest) (*emptypb.Empty, error) {
+	if err := g.svc.ResetUserPassword(ctx, auth.ActorFromContext(ctx), req.GetUserId(), req.GetPassword()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) InviteUser(ctx context.Context, req *pvzpb.InviteUserRequest) (*pvzpb.Invite, error) {
+	inv, token, err := g.svc.InviteUser(ctx, auth.ActorFromContext(ctx), req.GetEmail(), req.GetRole())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pvzpb.Invite{Id: inv.ID, Email: inv.Email, Role: inv.Role, ExpiresAt: timestamppb.New(inv.ExpiresAt), ActivationToken: token}, nil
+}
+
+func (g *grpcServer) AcceptInvite(ctx context.Context, req *pvzpb.AcceptInviteRequest) (*pvzpb.TokenResponse, error) {
+	pair, err := g.svc.AcceptInvite(ctx, req.GetToken(), req.GetPassword())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pvzpb.TokenResponse{Token: pair.Token, RefreshToken: pair.RefreshToken}, nil
+}
+
+func (g *grpcServer) ListPVZEmployees(ctx context.Context, req *pvzpb.ListPVZEmployeesRequest) (*pvzpb.ListUsersResponse, error) {
+	users, err := g.svc.ListPVZEmployees(ctx, auth.ActorFromContext(ctx), req.GetPvzId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ListUsersResponse{}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toPbUser(u))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) AssignEmployee(ctx context.Context, req *pvzpb.EmployeeAssignmentRequest) (*emptypb.Empty, error) {
+	if err := g.svc.AssignEmployee(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), req.GetUserId()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) UnassignEmployee(ctx context.Context, req *pvzpb.EmployeeAssignmentRequest) (*emptypb.Empty, error) {
+	if err := g.svc.UnassignEmployee(ctx, auth.ActorFromContext(ctx), req.GetPvzId(), req.GetUserId()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) CreateAPIKey(ctx context.Context, req *pvzpb.CreateAPIKeyRequest) (*pvzpb.APIKey, error) {
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+	k, key, err := g.svc.CreateAPIKey(ctx, auth.ActorFromContext(ctx), req.GetName(), req.GetScopes(), expiresAt)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbAPIKey(k, key), nil
+}
+
+func (g *grpcServer) ListAPIKeys(ctx context.Context, _ *emptypb.Empty) (*pvzpb.ListAPIKeysResponse, error) {
+	keys, err := g.svc.ListAPIKeys(ctx, auth.ActorFromContext(ctx))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ListAPIKeysResponse{}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, toPbAPIKey(k, ""))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) RotateAPIKey(ctx context.Context, req *pvzpb.APIKeyIdRequest) (*pvzpb.APIKey, error) {
+	k, key, err := g.svc.RotateAPIKey(ctx, auth.ActorFromContext(ctx), req.GetKeyId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbAPIKey(k, key), nil
+}
+
+func (g *grpcServer) RevokeAPIKey(ctx context.Context, req *pvzpb.APIKeyIdRequest) (*emptypb.Empty, error) {
+	if err := g.svc.RevokeAPIKey(ctx, auth.ActorFromContext(ctx), req.GetKeyId()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) ListCities(ctx context.Context, _ *emptypb.Empty) (*pvzpb.ListCitiesResponse, error) {
+	cities, err := g.svc.ListCities(ctx, auth.ActorFromContext(ctx))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ListCitiesResponse{}
+	for _, c := range cities {
+		resp.Cities = append(resp.Cities, toPbCity(c))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) CreateCity(ctx context.Context, req *pvzpb.CreateCityRequest) (*pvzpb.City, error) {
+	c, err := g.svc.CreateCity(ctx, auth.ActorFromContext(ctx), req.GetName())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbCity(c), nil
+}
+
+func (g *grpcServer) RenameCity(ctx context.Context, req *pvzpb.RenameCityRequest) (*pvzpb.City, error) {
+	c, err := g.svc.RenameCity(ctx, auth.ActorFromContext(ctx), req.GetName(), req.GetNewName())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbCity(c), nil
+}
+
+func (g *grpcServer) DeleteCity(ctx context.Context, req *pvzpb.DeleteCityRequest) (*emptypb.Empty, error) {
+	if err := g.svc.DeleteCity(ctx, auth.ActorFromContext(ctx), req.GetName()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServer) ListProductTypes(ctx context.Context, _ *emptypb.Empty) (*pvzpb.ListProductTypesResponse, error) {
+	types, err := g.svc.ListProductTypes(ctx, auth.ActorFromContext(ctx))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pvzpb.ListProductTypesResponse{}
+	for _, t := range types {
+		resp.ProductTypes = append(resp.ProductTypes, toPbProductType(t))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) CreateProductType(ctx context.Context, req *pvzpb.CreateProductTypeRequest) (*pvzpb.ProductType, error) {
+	t, err := g.svc.CreateProductType(ctx, auth.ActorFromContext(ctx), model.ProductType{
+		Name: req.GetName(), Fragile: req.GetFragile(), RequiresIDCheck: req.GetRequiresIdCheck(), MaxWeightGrams: intPtr(req.MaxWeightGrams),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbProductType(t), nil
+}
+
+func (g *grpcServer) UpdateProductType(ctx context.Context, req *pvzpb.UpdateProductTypeRequest) (*pvzpb.ProductType, error) {
+	t, err := g.svc.UpdateProductType(ctx, auth.ActorFromContext(ctx), req.GetName(), model.ProductTypeUpdate{
+		Fragile: req.Fragile, RequiresIDCheck: req.RequiresIdCheck, MaxWeightGrams: intPtr(req.MaxWeightGrams), Deprecated: req.Deprecated,
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPbProductType(t), nil
+}
+
+func (g *grpcServer) DeleteProductType(ctx context.Context, req *pvzpb.DeleteProductTypeRequest) (*emptypb.Empty, error) {
+	if err := g.svc.DeleteProductType(ctx, auth.ActorFromContext(ctx), req.GetName()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
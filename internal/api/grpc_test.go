@@ -3,80 +3,185 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	pvzpb "pvz-backend-service/api/pvz/v1"
+	"pvz-backend-service/internal/auth"
 	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/internal/service"
+	"pvz-backend-service/lib/e"
 )
 
 type stubRepo struct {
-	listFn func(ctx context.Context, start, end string, limit, offset int) ([]model.PVZ, error)
+	repo.Repository
+	listFn     func(ctx context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error)
+	pvzFn      func(ctx context.Context, f model.PVZFilter) ([]model.PVZ, error)
+	unassigned bool
+	open       bool
 }
 
 func (s *stubRepo) CreateUser(ctx context.Context, email, hash, role string) (model.User, error) {
-	panic("not used")
+	return model.User{ID: "u1", Email: email, PasswordHash: hash, Role: role}, nil
 }
 func (s *stubRepo) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
-	panic("not used")
+	return model.User{}, e.NotFound("get user: not found")
 }
-func (s *stubRepo) CreatePVZ(ctx context.Context, city string) (model.PVZ, error) {
-	panic("not used")
+func (s *stubRepo) CreatePVZ(ctx context.Context, p model.PVZ) (model.PVZ, error) {
+	p.ID = "p1"
+	return p, nil
 }
-func (s *stubRepo) ListPVZ(ctx context.Context, start, end string, limit, offset int) ([]model.PVZ, error) {
-	return s.listFn(ctx, start, end, limit, offset)
+func (s *stubRepo) ListPVZ(ctx context.Context, f model.PVZFilter) ([]model.PVZ, error) {
+	return s.pvzFn(ctx, f)
 }
-func (s *stubRepo) OpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
-	panic("not used")
+func (s *stubRepo) ListPVZWithReceptions(ctx context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error) {
+	return s.listFn(ctx, f)
+}
+func (s *stubRepo) OpenReception(ctx context.Context, pvzID string, m *model.Manifest) (model.Reception, error) {
+	return model.Reception{ID: "r1", PVZID: pvzID, Status: "in_progress", Manifest: m}, nil
+}
+func (s *stubRepo) GetReceptionReport(ctx context.Context, receptionID string) (model.ReceptionReport, error) {
+	return model.ReceptionReport{}, e.NotFound("get reception report: not found")
+}
+func (s *stubRepo) AddProduct(ctx context.Context, receptionID string, p model.Product) (model.Product, error) {
+	p.ID, p.ReceptionID = "pr1", receptionID
+	return p, nil
+}
+func (s *stubRepo) GetProductType(ctx context.Context, name string) (model.ProductType, error) {
+	return model.ProductType{Name: name}, nil
+}
+func (s *stubRepo) ListProductTypes(ctx context.Context) ([]model.ProductType, error) {
+	return []model.ProductType{{Name: "обувь"}}, nil
+}
+func (s *stubRepo) UpdateProductType(ctx context.Context, name string, u model.ProductTypeUpdate) (model.ProductType, error) {
+	now := time.Now()
+	return model.ProductType{Name: name, DeprecatedAt: &now}, nil
+}
+func (s *stubRepo) ProductsByBarcode(ctx context.Context, barcode string, limit int) ([]model.Product, error) {
+	return nil, nil
+}
+func (s *stubRepo) FindOpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
+	return s.GetOpenReception(ctx, pvzID)
 }
 func (s *stubRepo) GetOpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
-	panic("not used")
+	if s.open {
+		return model.Reception{ID: "r1", PVZID: pvzID, Status: "in_progress"}, nil
+	}
+	return model.Reception{}, e.NotFound("get open reception: not found")
+}
+func (s *stubRepo) ListReceptionBarcodes(ctx context.Context, receptionID string) ([]string, error) {
+	return []string{"4006381333931"}, nil
+}
+func (s *stubRepo) AddProducts(ctx context.Context, receptionID string, ps []model.Product) ([]model.Product, error) {
+	for i := range ps {
+		ps[i].ID, ps[i].ReceptionID = fmt.Sprintf("pr%d", i+1), receptionID
+	}
+	return ps, nil
+}
+func (s *stubRepo) GetReceptionProduct(ctx context.Context, receptionID, id, barcode string) (model.Product, error) {
+	if id != "pr1" && barcode != "4006381333931" {
+		return model.Product{}, e.NotFound("get product: not found")
+	}
+	return model.Product{ID: "pr1", ReceptionID: receptionID, Type: "обувь", Barcode: "4006381333931"}, nil
+}
+func (s *stubRepo) DeleteProduct(ctx context.Context, id string) error {
+	return nil
 }
-func (s *stubRepo) AddProduct(ctx context.Context, receptionID, typ string) (model.Product, error) {
-	panic("not used")
+func (s *stubRepo) SetProductType(ctx context.Context, id, productType string) (model.Product, error) {
+	return model.Product{ID: id, ReceptionID: "r1", Type: productType}, nil
 }
-func (s *stubRepo) DeleteLastProduct(ctx context.Context, receptionID string) error {
-	panic("not used")
+func (s *stubRepo) DiscardUndoneOperations(ctx context.Context, receptionID string) error {
+	return nil
 }
-func (s *stubRepo) CloseReception(ctx context.Context, receptionID string) error {
-	panic("not used")
+func (s *stubRepo) RecordProductOperations(ctx context.Context, ops []model.ProductOperation) ([]model.ProductOperation, error) {
+	return ops, nil
+}
+func (s *stubRepo) ListProductOperations(ctx context.Context, receptionID string) ([]model.ProductOperation, error) {
+	return []model.ProductOperation{{ID: "op1", ReceptionID: receptionID, Kind: service.OpAdd, State: service.OpDone, UserID: "u1",
+		Product: model.Product{ID: "pr1", ReceptionID: receptionID, Type: "обувь"}}}, nil
+}
+func (s *stubRepo) LockUndoStack(ctx context.Context, receptionID string, limit int) ([]model.ProductOperation, error) {
+	return nil, nil
+}
+func (s *stubRepo) LoginLockedUntil(ctx context.Context, keys []string) (time.Time, error) {
+	return time.Time{}, nil
+}
+func (s *stubRepo) RecordLoginFailure(ctx context.Context, key string, since time.Time) (int, error) {
+	return 1, nil
+}
+func (s *stubRepo) CreateRefreshToken(ctx context.Context, t model.RefreshToken) error {
+	return nil
+}
+func (s *stubRepo) LockPVZ(ctx context.Context, pvzID string) (model.PVZ, error) {
+	return model.PVZ{ID: pvzID, Status: "active"}, nil
+}
+func (s *stubRepo) NearbyPVZ(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]model.NearbyPVZ, error) {
+	return []model.NearbyPVZ{{PVZ: model.PVZ{ID: "p1", City: "Москва", Status: "active"}, DistanceKm: 1.2}}, nil
+}
+func (s *stubRepo) IsAssigned(ctx context.Context, userID, pvzID string) (bool, error) {
+	return !s.unassigned, nil
+}
+func (s *stubRepo) WithTx(ctx context.Context, fn func(repo.Repository) error) error {
+	return fn(s)
+}
+
+func newGRPCServer(r *stubRepo) *grpcServer {
+	return &grpcServer{svc: service.New(r, auth.NewTokenManager(auth.NewHMACKeySet("secret"), time.Minute, time.Hour, nil))}
+}
+
+func withRole(role string) context.Context {
+	return auth.WithActor(context.Background(), model.Actor{UserID: "u1", Role: role})
 }
 
 func TestGetPVZList_Success(t *testing.T) {
-	entries := []model.PVZ{
-		{ID: "p1", City: "Москва"},
-		{ID: "p2", City: "Казань"},
+	// More PVZs than one batch, so the list is read in two queries.
+	var all []model.PVZ
+	for i := range 501 {
+		all = append(all, model.PVZ{ID: fmt.Sprintf("p%d", i), City: "Москва"})
 	}
+	all[1].City = "Казань"
+	var calls []model.PVZFilter
 	stub := &stubRepo{
-		listFn: func(ctx context.Context, start, end string, limit, offset int) ([]model.PVZ, error) {
-			assert.Equal(t, "", start)
-			assert.Equal(t, "", end)
-			assert.Equal(t, 100, limit)
-			assert.Equal(t, 0, offset)
-			return entries, nil
+		pvzFn: func(ctx context.Context, f model.PVZFilter) ([]model.PVZ, error) {
+			calls = append(calls, f)
+			from := 0
+			if f.After != nil {
+				from = slices.IndexFunc(all, func(p model.PVZ) bool { return p.ID == f.After.ID }) + 1
+			}
+			return all[from:min(from+f.Limit, len(all))], nil
 		},
 	}
 
-	server := &grpcServer{store: stub}
+	server := newGRPCServer(stub)
 	resp, err := server.GetPVZList(context.Background(), &emptypb.Empty{})
 	assert.NoError(t, err)
-	assert.Len(t, resp.Pvz, 2)
-	assert.Equal(t, "p1", resp.Pvz[0].Id)
-	assert.Equal(t, "Москва", resp.Pvz[0].City)
-	assert.Equal(t, "p2", resp.Pvz[1].Id)
+	assert.Len(t, resp.Pvz, 501)
+	assert.Equal(t, "p0", resp.Pvz[0].Id)
 	assert.Equal(t, "Казань", resp.Pvz[1].City)
+	assert.Equal(t, "p500", resp.Pvz[500].Id)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, "", calls[0].Start)
+	assert.Nil(t, calls[0].After)
+	assert.Equal(t, "p499", calls[1].After.ID)
 }
 
 func TestGetPVZList_Failure(t *testing.T) {
 	stub := &stubRepo{
-		listFn: func(ctx context.Context, start, end string, limit, offset int) ([]model.PVZ, error) {
+		pvzFn: func(ctx context.Context, f model.PVZFilter) ([]model.PVZ, error) {
 			return nil, errors.New("db error")
 		},
 	}
 
-	server := &grpcServer{store: stub}
+	server := newGRPCServer(stub)
 	resp, err := server.GetPVZList(context.Background(), &emptypb.Empty{})
 	assert.Nil(t, resp)
 	st, ok := status.FromError(err)
@@ -84,3 +189,303 @@ func TestGetPVZList_Failure(t *testing.T) {
 	assert.Equal(t, codes.Internal, st.Code())
 	assert.Contains(t, st.Message(), "failed to list PVZs")
 }
+
+func TestListPVZ_Nested(t *testing.T) {
+	stub := &stubRepo{
+		listFn: func(ctx context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error) {
+			assert.Equal(t, 6, f.Limit)
+			assert.Equal(t, 5, f.Offset)
+			return []model.PVZWithReceptions{{
+				PVZ: model.PVZ{ID: "p1", City: "Москва"},
+				Receptions: []model.ReceptionWithProducts{{
+					Reception: model.Reception{ID: "r1", PVZID: "p1", Status: "close"},
+					Products:  []model.Product{{ID: "pr1", ReceptionID: "r1", Type: "обувь"}},
+				}},
+			}}, nil
+		},
+	}
+
+	resp, err := newGRPCServer(stub).ListPVZ(context.Background(), &pvzpb.ListPVZRequest{Page: 2, Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, "r1", resp.Items[0].Receptions[0].Reception.Id)
+	assert.Equal(t, "pr1", resp.Items[0].Receptions[0].Products[0].Id)
+	assert.Empty(t, resp.NextCursor)
+}
+
+func TestListPVZ_NextCursor(t *testing.T) {
+	day := time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)
+	stub := &stubRepo{
+		listFn: func(ctx context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error) {
+			if f.After != nil {
+				assert.Equal(t, "7c3c0a5e-4b1e-4d2a-9d55-2f1f0b6f3a01", f.After.ID)
+				assert.True(t, day.Equal(f.After.RegistrationDate))
+				return nil, nil
+			}
+			return []model.PVZWithReceptions{
+				{PVZ: model.PVZ{ID: "7c3c0a5e-4b1e-4d2a-9d55-2f1f0b6f3a01", RegistrationDate: day}},
+				{PVZ: model.PVZ{ID: "0b9d7f0e-8f7a-4a51-a3a4-8a1c7d9e2b02", RegistrationDate: day}},
+			}, nil
+		},
+	}
+
+	server := newGRPCServer(stub)
+	resp, err := server.ListPVZ(context.Background(), &pvzpb.ListPVZRequest{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 1)
+	assert.NotEmpty(t, resp.NextCursor)
+
+	resp, err = server.ListPVZ(context.Background(), &pvzpb.ListPVZRequest{Limit: 1, After: resp.NextCursor})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Items)
+	assert.Empty(t, resp.NextCursor)
+
+	_, err = server.ListPVZ(context.Background(), &pvzpb.ListPVZRequest{After: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestNearbyPVZ_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{})
+
+	resp, err := server.NearbyPVZ(withRole("employee"), &pvzpb.NearbyPVZRequest{Latitude: 55.75, Longitude: 37.62})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, "p1", resp.Items[0].Pvz.Id)
+	assert.Equal(t, 1.2, resp.Items[0].DistanceKm)
+
+	_, err = server.NearbyPVZ(withRole("employee"), &pvzpb.NearbyPVZRequest{Latitude: 95})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreatePVZ_RoleEnforced(t *testing.T) {
+	server := newGRPCServer(&stubRepo{})
+
+	_, err := server.CreatePVZ(withRole("employee"), &pvzpb.CreatePVZRequest{City: "Казань"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	lat, lon, capacity := 55.79, 49.12, int32(120)
+	p, err := server.CreatePVZ(withRole("moderator"), &pvzpb.CreatePVZRequest{
+		City: "Казань", Address: "Баумана, 5", Latitude: &lat, Longitude: &lon, Capacity: &capacity,
+		WorkingHours: []*pvzpb.WorkingHours{{Weekday: 1, Opens: "09:00", Closes: "21:00"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Казань", p.City)
+	assert.Equal(t, "active", p.Status)
+	assert.Equal(t, "Europe/Moscow", p.Timezone)
+	assert.Equal(t, int32(120), p.GetCapacity())
+	assert.Len(t, p.WorkingHours, 1)
+}
+
+func TestOpenReception_GRPC(t *testing.T) {
+	rec, err := newGRPCServer(&stubRepo{}).OpenReception(withRole("employee"), &pvzpb.OpenReceptionRequest{PvzId: "p1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", rec.PvzId)
+}
+
+func TestOpenReception_Manifest_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{})
+	rec, err := server.OpenReception(withRole("employee"), &pvzpb.OpenReceptionRequest{PvzId: "p1", Manifest: &pvzpb.Manifest{
+		Supplier: "ООО Поставка", Items: []*pvzpb.ManifestItem{{Type: "обувь", Count: 3}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "ООО Поставка", rec.Manifest.Supplier)
+	assert.Equal(t, int32(3), rec.Manifest.Items[0].Count)
+
+	_, err = server.OpenReception(withRole("employee"), &pvzpb.OpenReceptionRequest{PvzId: "p1", Manifest: &pvzpb.Manifest{}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.GetReceptionReport(withRole("employee"), &pvzpb.ReceptionReportRequest{ReceptionId: "r1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAddProduct_Identity_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{})
+	weight := int32(450)
+	_, err := server.AddProduct(withRole("employee"), &pvzpb.AddProductRequest{PvzId: "p1", Type: "обувь"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.AddProduct(withRole("employee"), &pvzpb.AddProductRequest{PvzId: "p1", Type: "обувь", Barcode: "4006381333932"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.AddProduct(withRole("employee"), &pvzpb.AddProductRequest{
+		PvzId: "p1", Type: "обувь", WeightGrams: &weight, Dimensions: &pvzpb.Dimensions{LengthMm: 300, WidthMm: 200},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.GetProductsByBarcode(withRole("employee"), &pvzpb.BarcodeRequest{Barcode: "4006381333931"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	grams := 450
+	pb := toPbProduct(model.Product{ID: "pr1", Barcode: "4006381333931", WeightGrams: &grams, Dimensions: &model.Dimensions{LengthMm: 300, WidthMm: 200, HeightMm: 120}})
+	assert.Equal(t, int32(450), pb.GetWeightGrams())
+	assert.Equal(t, int32(120), pb.Dimensions.HeightMm)
+	assert.Nil(t, toPbProduct(model.Product{ID: "pr2"}).WeightGrams)
+}
+
+type batchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*pvzpb.AddProductsRequest
+	resp *pvzpb.AddProductsResponse
+}
+
+func (s *batchStream) Context() context.Context { return s.ctx }
+func (s *batchStream) Recv() (*pvzpb.AddProductsRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+func (s *batchStream) SendAndClose(resp *pvzpb.AddProductsResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func TestAddProducts_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{open: true})
+	stream := &batchStream{ctx: withRole("employee"), reqs: []*pvzpb.AddProductsRequest{
+		{PvzId: "p1", Partial: true, Product: &pvzpb.NewProduct{Type: "обувь", Barcode: "5901234123457"}},
+		{Product: &pvzpb.NewProduct{Type: "обувь", Barcode: "4006381333931"}},
+		{Product: &pvzpb.NewProduct{Type: "мебель"}},
+		{Product: &pvzpb.NewProduct{Type: "обувь"}},
+	}}
+	assert.NoError(t, server.AddProducts(stream))
+	assert.Equal(t, int32(2), stream.resp.Added)
+	assert.Equal(t, int32(2), stream.resp.Failed)
+	assert.Equal(t, "added", stream.resp.Items[0].Status)
+	assert.Equal(t, "5901234123457", stream.resp.Items[0].Product.Barcode)
+	assert.Equal(t, "failed", stream.resp.Items[1].Status)
+	assert.Equal(t, int32(3), stream.resp.Items[3].Index)
+
+	stream = &batchStream{ctx: withRole("employee"), reqs: []*pvzpb.AddProductsRequest{
+		{PvzId: "p1", Product: &pvzpb.NewProduct{Type: "обувь"}},
+		{PvzId: "p2", Product: &pvzpb.NewProduct{Type: "обувь"}},
+	}}
+	assert.Equal(t, codes.InvalidArgument, status.Code(server.AddProducts(stream)))
+
+	stream = &batchStream{ctx: withRole("employee")}
+	assert.Equal(t, codes.InvalidArgument, status.Code(server.AddProducts(stream)))
+
+	stream = &batchStream{ctx: withRole("employee")}
+	for range service.MaxBatchProducts + 1 {
+		stream.reqs = append(stream.reqs, &pvzpb.AddProductsRequest{PvzId: "p1", Product: &pvzpb.NewProduct{Type: "обувь"}})
+	}
+	assert.Equal(t, codes.InvalidArgument, status.Code(server.AddProducts(stream)))
+}
+
+func TestProductCorrections_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{open: true})
+	ctx := withRole("employee")
+
+	p, err := server.DeleteProduct(ctx, &pvzpb.DeleteProductRequest{PvzId: "p1", Barcode: "4006381333931"})
+	assert.NoError(t, err)
+	assert.Equal(t, "pr1", p.Id)
+	_, err = server.DeleteProduct(ctx, &pvzpb.DeleteProductRequest{PvzId: "p1", ProductId: "pr2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = server.DeleteProduct(ctx, &pvzpb.DeleteProductRequest{PvzId: "p1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	p, err = server.CorrectProductType(ctx, &pvzpb.CorrectProductTypeRequest{PvzId: "p1", ProductId: "pr1", Type: "электроника"})
+	assert.NoError(t, err)
+	assert.Equal(t, "электроника", p.Type)
+
+	_, err = server.UndoProductOperations(ctx, &pvzpb.ProductOperationsRequest{PvzId: "p1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = server.RedoProductOperations(ctx, &pvzpb.ProductOperationsRequest{PvzId: "p1", Steps: 51})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ops, err := server.ListProductOperations(withRole("auditor"), &pvzpb.ListProductOperationsRequest{PvzId: "p1"})
+	assert.NoError(t, err)
+	assert.Len(t, ops.Operations, 1)
+	assert.Equal(t, "add", ops.Operations[0].Kind)
+	assert.Equal(t, "u1", ops.Operations[0].UserId)
+	assert.Equal(t, "pr1", ops.Operations[0].Product.Id)
+}
+
+func TestProductTypes_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{})
+	resp, err := server.ListProductTypes(withRole("auditor"), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "обувь", resp.ProductTypes[0].Name)
+
+	deprecated, limit := true, int32(-1)
+	_, err = server.UpdateProductType(withRole("moderator"), &pvzpb.UpdateProductTypeRequest{Name: "обувь", MaxWeightGrams: &limit})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	pt, err := server.UpdateProductType(withRole("moderator"), &pvzpb.UpdateProductTypeRequest{Name: "обувь", Deprecated: &deprecated})
+	assert.NoError(t, err)
+	assert.NotNil(t, pt.DeprecatedAt)
+	assert.Nil(t, pt.MaxWeightGrams)
+}
+
+func TestOpenReception_NotAssigned(t *testing.T) {
+	_, err := newGRPCServer(&stubRepo{unassigned: true}).OpenReception(withRole("employee"), &pvzpb.OpenReceptionRequest{PvzId: "p1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = newGRPCServer(&stubRepo{unassigned: true}).OpenReception(withRole("admin"), &pvzpb.OpenReceptionRequest{PvzId: "p1"})
+	assert.NoError(t, err)
+}
+
+func TestCloseReception_NoOpenReception(t *testing.T) {
+	_, err := newGRPCServer(&stubRepo{}).CloseReception(withRole("employee"), &pvzpb.CloseReceptionRequest{PvzId: "p1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "no open reception found", status.Convert(err).Message())
+}
+
+func TestLoginRegister_GRPC(t *testing.T) {
+	server := newGRPCServer(&stubRepo{})
+
+	_, err := server.Login(context.Background(), &pvzpb.LoginRequest{Email: "a@b", Password: "p"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := server.Register(context.Background(), &pvzpb.RegisterRequest{Email: "a@b", Password: "Secret-pass1", Role: "employee"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+}
+
+func TestGRPCCodeMapping(t *testing.T) {
+	cases := map[e.Kind]codes.Code{
+		e.KindNotFound:        codes.NotFound,
+		e.KindConflict:        codes.AlreadyExists,
+		e.KindValidation:      codes.InvalidArgument,
+		e.KindForbidden:       codes.PermissionDenied,
+		e.KindUnauthorized:    codes.Unauthenticated,
+		e.KindTooManyRequests: codes.ResourceExhausted,
+		e.KindPrecondition:    codes.FailedPrecondition,
+		e.KindInternal:        codes.Internal,
+	}
+	for kind, code := range cases {
+		assert.Equal(t, code, status.Code(grpcError(e.New(kind, "msg", nil))), kind.String())
+	}
+	st := status.Convert(grpcError(errors.New("pq: connection refused")))
+	assert.Equal(t, "internal error", st.Message())
+}
+
+func TestGRPCAccess_Permissions(t *testing.T) {
+	cases := map[string]auth.Permission{
+		pvzpb.PVZService_ListPVZ_FullMethodName:               auth.PermPVZRead,
+		pvzpb.PVZService_CreatePVZ_FullMethodName:             auth.PermPVZCreate,
+		pvzpb.PVZService_DeleteLastProduct_FullMethodName:     auth.PermProductDelete,
+		pvzpb.PVZService_DeleteProduct_FullMethodName:         auth.PermProductDelete,
+		pvzpb.PVZService_UndoProductOperations_FullMethodName: auth.PermProductDelete,
+		pvzpb.PVZService_ListProductOperations_FullMethodName: auth.PermPVZRead,
+		pvzpb.PVZService_ListUsers_FullMethodName:             auth.PermUserRead,
+		pvzpb.PVZService_ChangeUserRole_FullMethodName:        auth.PermUserManage,
+		pvzpb.PVZService_DisableUser_FullMethodName:           auth.PermUserManage,
+		pvzpb.PVZService_EnableUser_FullMethodName:            auth.PermUserManage,
+		pvzpb.PVZService_ResetUserPassword_FullMethodName:     auth.PermUserManage,
+		pvzpb.PVZService_InviteUser_FullMethodName:            auth.PermUserInvite,
+		pvzpb.PVZService_AssignEmployee_FullMethodName:        auth.PermPVZAssign,
+		pvzpb.PVZService_UnassignEmployee_FullMethodName:      auth.PermPVZAssign,
+		pvzpb.PVZService_ListCities_FullMethodName:            auth.PermPVZRead,
+		pvzpb.PVZService_CreateCity_FullMethodName:            auth.PermCityManage,
+		pvzpb.PVZService_ListProductTypes_FullMethodName:      auth.PermPVZRead,
+		pvzpb.PVZService_UpdateProductType_FullMethodName:     auth.PermCatalogManage,
+	}
+	for m, perm := range cases {
+		assert.Equal(t, perm, GRPCAccess.Permissions[m], m)
+	}
+	assert.True(t, GRPCAccess.Public[pvzpb.PVZService_AcceptInvite_FullMethodName])
+}
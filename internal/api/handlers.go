@@ -0,0 +1,719 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	api "pvz-backend-service/internal/api/types"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/service"
+)
+
+var _ api.ServerInterface = (*httpHandlers)(nil)
+
+type httpHandlers struct {
+	svc service.Service
+}
+
+func NewHTTPHandlers(svc service.Service) api.ServerInterface {
+	return &httpHandlers{svc: svc}
+}
+
+func actor(c *gin.Context) model.Actor {
+	return auth.GinActor(c)
+}
+
+func (h *httpHandlers) PostDummyLogin(c *gin.Context) {
+	var body struct {
+		Role string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		return
+	}
+	tok, err := h.svc.DummyLogin(c.Request.Context(), body.Role)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": tok})
+}
+
+func (h *httpHandlers) PostRegister(c *gin.Context) {
+	var body struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		Role     string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid registration data"})
+		return
+	}
+	pair, err := h.svc.Register(c.Request.Context(), body.Email, body.Password, body.Role)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, pair)
+}
+
+func (h *httpHandlers) PostLogin(c *gin.Context) {
+	var body struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid login data"})
+		return
+	}
+	pair, err := h.svc.Login(c.Request.Context(), body.Email, body.Password, c.ClientIP())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pair)
+}
+
+func (h *httpHandlers) PostTokenRefresh(c *gin.Context) {
+	var body api.PostTokenRefreshJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid refresh data"})
+		return
+	}
+	pair, err := h.svc.Refresh(c.Request.Context(), body.RefreshToken)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pair)
+}
+
+func (h *httpHandlers) PostLogout(c *gin.Context) {
+	var body api.PostLogoutJSONRequestBody
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid logout data"})
+			return
+		}
+	}
+	var refresh string
+	if body.RefreshToken != nil {
+		refresh = *body.RefreshToken
+	}
+	if err := h.svc.Logout(c.Request.Context(), actor(c), refresh); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) PostLogoutAll(c *gin.Context) {
+	if err := h.svc.LogoutAll(c.Request.Context(), actor(c)); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) GetWellKnownJwksJson(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.svc.JWKS())
+}
+
+func (h *httpHandlers) GetOidcLogin(c *gin.Context) {
+	url, err := h.svc.OIDCLoginURL(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+func (h *httpHandlers) GetOidcCallback(c *gin.Context, params api.GetOidcCallbackParams) {
+	if params.Error != nil {
+		msg := "sso login failed: " + *params.Error
+		if params.ErrorDescription != nil {
+			msg += ": " + *params.ErrorDescription
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"message": msg})
+		return
+	}
+	if params.Code == nil || *params.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "authorization code is required"})
+		return
+	}
+	pair, err := h.svc.OIDCCallback(c.Request.Context(), params.State, *params.Code)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pair)
+}
+
+func (h *httpHandlers) GetUsers(c *gin.Context, params api.GetUsersParams) {
+	var query, role string
+	if params.Q != nil {
+		query = *params.Q
+	}
+	if params.Role != nil {
+		role = string(*params.Role)
+	}
+	page, limit := 0, 0
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	users, err := h.svc.ListUsers(c.Request.Context(), actor(c), query, role, page, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *httpHandlers) PostUsersUserIdRole(c *gin.Context, userId openapi_types.UUID) {
+	var body api.PostUsersUserIdRoleJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid role data"})
+		return
+	}
+	user, err := h.svc.ChangeUserRole(c.Request.Context(), actor(c), userId.String(), string(body.Role))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *httpHandlers) PostUsersUserIdDisable(c *gin.Context, userId openapi_types.UUID) {
+	h.setUserDisabled(c, userId, true)
+}
+
+func (h *httpHandlers) PostUsersUserIdEnable(c *gin.Context, userId openapi_types.UUID) {
+	h.setUserDisabled(c, userId, false)
+}
+
+func (h *httpHandlers) setUserDisabled(c *gin.Context, userId openapi_types.UUID, disabled bool) {
+	user, err := h.svc.SetUserDisabled(c.Request.Context(), actor(c), userId.String(), disabled)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *httpHandlers) PostUsersUserIdResetPassword(c *gin.Context, userId openapi_types.UUID) {
+	var body api.PostUsersUserIdResetPasswordJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid password data"})
+		return
+	}
+	if err := h.svc.ResetUserPassword(c.Request.Context(), actor(c), userId.String(), body.Password); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) PostInvites(c *gin.Context) {
+	var body api.PostInvitesJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid invite data"})
+		return
+	}
+	var role string
+	if body.Role != nil {
+		role = string(*body.Role)
+	}
+	inv, token, err := h.svc.InviteUser(c.Request.Context(), actor(c), string(body.Email), role)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"id":              inv.ID,
+		"email":           inv.Email,
+		"role":            inv.Role,
+		"invitedBy":       inv.InvitedBy,
+		"expiresAt":       inv.ExpiresAt,
+		"activationToken": token,
+	})
+}
+
+func (h *httpHandlers) PostInvitesAccept(c *gin.Context) {
+	var body api.PostInvitesAcceptJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid activation data"})
+		return
+	}
+	pair, err := h.svc.AcceptInvite(c.Request.Context(), body.Token, body.Password)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, pair)
+}
+
+func (h *httpHandlers) GetApiKeys(c *gin.Context) {
+	keys, err := h.svc.ListAPIKeys(c.Request.Context(), actor(c))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+func (h *httpHandlers) PostApiKeys(c *gin.Context) {
+	var body api.PostApiKeysJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid api key data"})
+		return
+	}
+	k, key, err := h.svc.CreateAPIKey(c.Request.Context(), actor(c), body.Name, body.Scopes, body.ExpiresAt)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, apiKeyWithSecret{APIKey: k, Key: key})
+}
+
+func (h *httpHandlers) PostApiKeysKeyIdRotate(c *gin.Context, keyId openapi_types.UUID) {
+	k, key, err := h.svc.RotateAPIKey(c.Request.Context(), actor(c), keyId.String())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, apiKeyWithSecret{APIKey: k, Key: key})
+}
+
+func (h *httpHandlers) DeleteApiKeysKeyId(c *gin.Context, keyId openapi_types.UUID) {
+	if err := h.svc.RevokeAPIKey(c.Request.Context(), actor(c), keyId.String()); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// apiKeyWithSecret is returned when a key is issued; the secret is never
+// shown again.
+type apiKeyWithSecret struct {
+	model.APIKey
+	Key string `json:"key"`
+}
+
+func (h *httpHandlers) GetCities(c *gin.Context) {
+	cities, err := h.svc.ListCities(c.Request.Context(), actor(c))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, cities)
+}
+
+func (h *httpHandlers) PostCities(c *gin.Context) {
+	var body api.PostCitiesJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid city data"})
+		return
+	}
+	city, err := h.svc.CreateCity(c.Request.Context(), actor(c), body.Name)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, city)
+}
+
+func (h *httpHandlers) PatchCitiesName(c *gin.Context, name string) {
+	var body api.PatchCitiesNameJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid city data"})
+		return
+	}
+	city, err := h.svc.RenameCity(c.Request.Context(), actor(c), name, body.Name)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, city)
+}
+
+func (h *httpHandlers) DeleteCitiesName(c *gin.Context, name string) {
+	if err := h.svc.DeleteCity(c.Request.Context(), actor(c), name); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) GetProductTypes(c *gin.Context) {
+	types, err := h.svc.ListProductTypes(c.Request.Context(), actor(c))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, types)
+}
+
+func (h *httpHandlers) PostProductTypes(c *gin.Context) {
+	var body model.ProductType
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product type data"})
+		return
+	}
+	t, err := h.svc.CreateProductType(c.Request.Context(), actor(c), body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, t)
+}
+
+func (h *httpHandlers) PatchProductTypesName(c *gin.Context, name string) {
+	var body model.ProductTypeUpdate
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product type data"})
+		return
+	}
+	t, err := h.svc.UpdateProductType(c.Request.Context(), actor(c), name, body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+func (h *httpHandlers) DeleteProductTypesName(c *gin.Context, name string) {
+	if err := h.svc.DeleteProductType(c.Request.Context(), actor(c), name); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) PostPvz(c *gin.Context) {
+	var body model.PVZ
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid PVZ data"})
+		return
+	}
+	pvz, err := h.svc.CreatePVZ(c.Request.Context(), actor(c), body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, pvz)
+}
+
+func (h *httpHandlers) PatchPvzPvzId(c *gin.Context, pvzId openapi_types.UUID) {
+	var body model.PVZUpdate
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid PVZ data"})
+		return
+	}
+	pvz, err := h.svc.UpdatePVZ(c.Request.Context(), actor(c), pvzId.String(), body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pvz)
+}
+
+func (h *httpHandlers) PostPvzPvzIdDeactivate(c *gin.Context, pvzId openapi_types.UUID) {
+	pvz, err := h.svc.DeactivatePVZ(c.Request.Context(), actor(c), pvzId.String())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pvz)
+}
+
+func (h *httpHandlers) GetPvz(c *gin.Context, params api.GetPvzParams) {
+	start, end := "", ""
+	if params.StartDate != nil {
+		start = params.StartDate.Format(time.RFC3339)
+	}
+	if params.EndDate != nil {
+		end = params.EndDate.Format(time.RFC3339)
+	}
+	page, limit := 0, 0
+	if params.Page != nil {
+		page = int(*params.Page)
+	}
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+	after := ""
+	if params.After != nil {
+		after = *params.After
+	}
+	list, next, err := h.svc.ListPVZ(c.Request.Context(), start, end, page, limit, after)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if next != "" {
+		c.Header("X-Next-Cursor", next)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *httpHandlers) GetPvzNearby(c *gin.Context, params api.GetPvzNearbyParams) {
+	radius, limit, open := 0.0, 0, false
+	if params.Radius != nil {
+		radius = *params.Radius
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Open != nil {
+		open = *params.Open
+	}
+	list, err := h.svc.NearbyPVZ(c.Request.Context(), actor(c), params.Lat, params.Lon, radius, limit, open)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *httpHandlers) GetPvzPvzIdEmployees(c *gin.Context, pvzId openapi_types.UUID) {
+	users, err := h.svc.ListPVZEmployees(c.Request.Context(), actor(c), pvzId.String())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *httpHandlers) PostPvzPvzIdEmployees(c *gin.Context, pvzId openapi_types.UUID) {
+	var body api.PostPvzPvzIdEmployeesJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid assignment data"})
+		return
+	}
+	if err := h.svc.AssignEmployee(c.Request.Context(), actor(c), pvzId.String(), body.UserId.String()); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) DeletePvzPvzIdEmployeesUserId(c *gin.Context, pvzId openapi_types.UUID, userId openapi_types.UUID) {
+	if err := h.svc.UnassignEmployee(c.Request.Context(), actor(c), pvzId.String(), userId.String()); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *httpHandlers) PostReceptions(c *gin.Context) {
+	var body struct {
+		PVZID    openapi_types.UUID `json:"pvzId"`
+		Manifest *model.Manifest    `json:"manifest"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid reception data"})
+		return
+	}
+	rec, err := h.svc.OpenReception(c.Request.Context(), actor(c), body.PVZID.String(), body.Manifest)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, rec)
+}
+
+func (h *httpHandlers) PostProducts(c *gin.Context) {
+	var body struct {
+		PVZID       openapi_types.UUID `json:"pvzId"`
+		Type        string             `json:"type"`
+		Barcode     string             `json:"barcode"`
+		SKU         string             `json:"sku"`
+		WeightGrams *int               `json:"weightGrams"`
+		Dimensions  *model.Dimensions  `json:"dimensions"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product data"})
+		return
+	}
+	p := model.Product{Type: body.Type, Barcode: body.Barcode, SKU: body.SKU, WeightGrams: body.WeightGrams, Dimensions: body.Dimensions}
+	prod, err := h.svc.AddProduct(c.Request.Context(), actor(c), body.PVZID.String(), p)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, prod)
+}
+
+// PostProductsBatch answers 201 when every product was added, 200 when only
+// some were and 422 when none were; the body always lists each item.
+func (h *httpHandlers) PostProductsBatch(c *gin.Context) {
+	var body struct {
+		PVZID    openapi_types.UUID `json:"pvzId"`
+		Mode     string             `json:"mode"`
+		Products []model.Product    `json:"products"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product data"})
+		return
+	}
+	partial := body.Mode == string(api.Partial)
+	res, err := h.svc.AddProducts(c.Request.Context(), actor(c), body.PVZID.String(), body.Products, partial)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	code := http.StatusCreated
+	switch {
+	case res.Added == 0:
+		code = http.StatusUnprocessableEntity
+	case res.Failed > 0:
+		code = http.StatusOK
+	}
+	c.JSON(code, res)
+}
+
+func (h *httpHandlers) GetProductsByBarcodeCode(c *gin.Context, code string) {
+	products, err := h.svc.ProductsByBarcode(c.Request.Context(), actor(c), code)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, products)
+}
+
+func (h *httpHandlers) PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId openapi_types.UUID) {
+	if err := h.svc.DeleteLastProduct(c.Request.Context(), actor(c), pvzId.String()); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *httpHandlers) PostPvzPvzIdDeleteProduct(c *gin.Context, pvzId openapi_types.UUID) {
+	var body api.PostPvzPvzIdDeleteProductJSONBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product data"})
+		return
+	}
+	productID, barcode := "", ""
+	if body.ProductId != nil {
+		productID = body.ProductId.String()
+	}
+	if body.Barcode != nil {
+		barcode = *body.Barcode
+	}
+	p, err := h.svc.DeleteProduct(c.Request.Context(), actor(c), pvzId.String(), productID, barcode)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+func (h *httpHandlers) PatchPvzPvzIdProductsProductId(c *gin.Context, pvzId openapi_types.UUID, productId openapi_types.UUID) {
+	var body api.PatchPvzPvzIdProductsProductIdJSONBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product data"})
+		return
+	}
+	p, err := h.svc.CorrectProductType(c.Request.Context(), actor(c), pvzId.String(), productId.String(), body.Type)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// undoSteps reads the optional {"steps": n} body of undo and redo.
+func undoSteps(c *gin.Context) (int, bool) {
+	var body api.PostPvzPvzIdUndoJSONBody
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid undo data"})
+		return 0, false
+	}
+	if body.Steps == nil {
+		return 1, true
+	}
+	return *body.Steps, true
+}
+
+func (h *httpHandlers) PostPvzPvzIdUndo(c *gin.Context, pvzId openapi_types.UUID) {
+	steps, ok := undoSteps(c)
+	if !ok {
+		return
+	}
+	ops, err := h.svc.UndoProductOperations(c.Request.Context(), actor(c), pvzId.String(), steps)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ops)
+}
+
+func (h *httpHandlers) PostPvzPvzIdRedo(c *gin.Context, pvzId openapi_types.UUID) {
+	steps, ok := undoSteps(c)
+	if !ok {
+		return
+	}
+	ops, err := h.svc.RedoProductOperations(c.Request.Context(), actor(c), pvzId.String(), steps)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ops)
+}
+
+func (h *httpHandlers) GetPvzPvzIdProductOperations(c *gin.Context, pvzId openapi_types.UUID) {
+	ops, err := h.svc.ProductOperations(c.Request.Context(), actor(c), pvzId.String())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ops)
+}
+
+func (h *httpHandlers) PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId openapi_types.UUID) {
+	rec, err := h.svc.CloseReception(c.Request.Context(), actor(c), pvzId.String())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rec)
+}
+
+func (h *httpHandlers) GetReceptionsReceptionIdReport(c *gin.Context, receptionId openapi_types.UUID) {
+	rep, err := h.svc.ReceptionReport(c.Request.Context(), actor(c), receptionId.String())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
+
+func (h *httpHandlers) PostReceptionsReceptionIdReportAccept(c *gin.Context, receptionId openapi_types.UUID) {
+	var body api.PostReceptionsReceptionIdReportAcceptJSONBody
+	// The body is optional: a bare POST accepts without a comment.
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid report acceptance data"})
+		return
+	}
+	comment := ""
+	if body.Comment != nil {
+		comment = *body.Comment
+	}
+	rep, err := h.svc.AcceptReceptionReport(c.Request.Context(), actor(c), receptionId.String(), comment)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
@@ -0,0 +1,478 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	api "pvz-backend-service/internal/api/types"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/service"
+	"pvz-backend-service/lib/e"
+)
+
+type fakeService struct {
+	err       error
+	lastActor model.Actor
+	lastSteps int
+}
+
+var _ service.Service = (*fakeService)(nil)
+
+func (f *fakeService) DummyLogin(_ context.Context, _ string) (string, error) {
+	return "tok", f.err
+}
+func (f *fakeService) Register(_ context.Context, _, _, _ string) (model.TokenPair, error) {
+	return model.TokenPair{Token: "tok", RefreshToken: "rt"}, f.err
+}
+func (f *fakeService) Login(_ context.Context, _, _, _ string) (model.TokenPair, error) {
+	return model.TokenPair{Token: "tok", RefreshToken: "rt"}, f.err
+}
+func (f *fakeService) Refresh(_ context.Context, _ string) (model.TokenPair, error) {
+	return model.TokenPair{Token: "tok2", RefreshToken: "rt2"}, f.err
+}
+func (f *fakeService) Logout(_ context.Context, a model.Actor, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) LogoutAll(_ context.Context, a model.Actor) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) JWKS() auth.JWKS {
+	return auth.JWKS{Keys: []auth.JWK{{Kty: "OKP", Kid: "k1", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "x"}}}
+}
+func (f *fakeService) ListUsers(_ context.Context, a model.Actor, _, _ string, _, _ int) ([]model.User, error) {
+	f.lastActor = a
+	return []model.User{{ID: "u1", Email: "a@b", Role: "employee"}}, f.err
+}
+func (f *fakeService) ChangeUserRole(_ context.Context, a model.Actor, id, role string) (model.User, error) {
+	f.lastActor = a
+	return model.User{ID: id, Role: role}, f.err
+}
+func (f *fakeService) SetUserDisabled(_ context.Context, a model.Actor, id string, _ bool) (model.User, error) {
+	f.lastActor = a
+	return model.User{ID: id}, f.err
+}
+func (f *fakeService) ResetUserPassword(_ context.Context, a model.Actor, _, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) InviteUser(_ context.Context, a model.Actor, email, role string) (model.Invite, string, error) {
+	f.lastActor = a
+	return model.Invite{ID: "i1", Email: email, Role: role}, "act", f.err
+}
+func (f *fakeService) AcceptInvite(_ context.Context, _, _ string) (model.TokenPair, error) {
+	return model.TokenPair{Token: "tok", RefreshToken: "rt"}, f.err
+}
+func (f *fakeService) ListPVZEmployees(_ context.Context, a model.Actor, _ string) ([]model.User, error) {
+	f.lastActor = a
+	return []model.User{{ID: "u1", Role: "employee"}}, f.err
+}
+func (f *fakeService) AssignEmployee(_ context.Context, a model.Actor, _, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) UnassignEmployee(_ context.Context, a model.Actor, _, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) OIDCLoginURL(context.Context) (string, error) {
+	return "https://sso.example/authorize?state=s", f.err
+}
+func (f *fakeService) OIDCCallback(_ context.Context, state, code string) (model.TokenPair, error) {
+	return model.TokenPair{Token: "tok", RefreshToken: "rt"}, f.err
+}
+func (f *fakeService) CreateAPIKey(_ context.Context, a model.Actor, name string, scopes []string, _ *time.Time) (model.APIKey, string, error) {
+	f.lastActor = a
+	return model.APIKey{ID: "k1", Name: name, Prefix: "abcd1234", Scopes: scopes}, "pvz_abcd1234_secret", f.err
+}
+func (f *fakeService) ListAPIKeys(_ context.Context, a model.Actor) ([]model.APIKey, error) {
+	f.lastActor = a
+	return []model.APIKey{}, f.err
+}
+func (f *fakeService) RotateAPIKey(_ context.Context, a model.Actor, id string) (model.APIKey, string, error) {
+	f.lastActor = a
+	return model.APIKey{ID: "k2"}, "pvz_ef567890_secret", f.err
+}
+func (f *fakeService) RevokeAPIKey(_ context.Context, a model.Actor, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) ListCities(_ context.Context, a model.Actor) ([]model.City, error) {
+	f.lastActor = a
+	return []model.City{{Name: "Москва"}}, f.err
+}
+func (f *fakeService) CreateCity(_ context.Context, a model.Actor, name string) (model.City, error) {
+	f.lastActor = a
+	return model.City{Name: name}, f.err
+}
+func (f *fakeService) RenameCity(_ context.Context, a model.Actor, _, newName string) (model.City, error) {
+	f.lastActor = a
+	return model.City{Name: newName}, f.err
+}
+func (f *fakeService) DeleteCity(_ context.Context, a model.Actor, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) ListProductTypes(_ context.Context, a model.Actor) ([]model.ProductType, error) {
+	f.lastActor = a
+	return []model.ProductType{{Name: "обувь"}}, f.err
+}
+func (f *fakeService) CreateProductType(_ context.Context, a model.Actor, t model.ProductType) (model.ProductType, error) {
+	f.lastActor = a
+	return t, f.err
+}
+func (f *fakeService) UpdateProductType(_ context.Context, a model.Actor, name string, u model.ProductTypeUpdate) (model.ProductType, error) {
+	f.lastActor = a
+	return model.ProductType{Name: name, Fragile: u.Fragile != nil && *u.Fragile}, f.err
+}
+func (f *fakeService) DeleteProductType(_ context.Context, a model.Actor, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) CreatePVZ(_ context.Context, a model.Actor, p model.PVZ) (model.PVZ, error) {
+	f.lastActor = a
+	p.ID = "p1"
+	return p, f.err
+}
+func (f *fakeService) UpdatePVZ(_ context.Context, a model.Actor, id string, u model.PVZUpdate) (model.PVZ, error) {
+	f.lastActor = a
+	p := model.PVZ{ID: id, Status: "active"}
+	if u.Status != nil {
+		p.Status = *u.Status
+	}
+	return p, f.err
+}
+func (f *fakeService) DeactivatePVZ(_ context.Context, a model.Actor, id string) (model.PVZ, error) {
+	f.lastActor = a
+	return model.PVZ{ID: id, Status: "closed"}, f.err
+}
+func (f *fakeService) NearbyPVZ(_ context.Context, a model.Actor, _, _, _ float64, _ int, _ bool) ([]model.NearbyPVZ, error) {
+	f.lastActor = a
+	return []model.NearbyPVZ{{PVZ: model.PVZ{ID: "p1"}, DistanceKm: 1.5, OpenNow: true}}, f.err
+}
+func (f *fakeService) ListPVZ(_ context.Context, _, _ string, _, _ int, after string) ([]model.PVZWithReceptions, string, error) {
+	if after != "" {
+		return []model.PVZWithReceptions{}, "", f.err
+	}
+	return []model.PVZWithReceptions{}, "next", f.err
+}
+func (f *fakeService) ListAllPVZ(_ context.Context) ([]model.PVZ, error) {
+	return []model.PVZ{}, f.err
+}
+func (f *fakeService) OpenReception(_ context.Context, a model.Actor, pvzID string, m *model.Manifest) (model.Reception, error) {
+	f.lastActor = a
+	return model.Reception{ID: "r1", PVZID: pvzID, Manifest: m}, f.err
+}
+func (f *fakeService) AddProduct(_ context.Context, a model.Actor, _ string, p model.Product) (model.Product, error) {
+	f.lastActor = a
+	p.ID = "pr1"
+	return p, f.err
+}
+func (f *fakeService) AddProducts(_ context.Context, a model.Actor, _ string, products []model.Product, partial bool) (model.BatchResult, error) {
+	f.lastActor = a
+	res := model.BatchResult{}
+	for i, p := range products {
+		item := model.BatchItem{Index: i, Status: "added", Product: &p}
+		if p.Type == "мебель" {
+			item = model.BatchItem{Index: i, Status: "failed", Error: "unknown product type"}
+			res.Failed++
+		}
+		res.Items = append(res.Items, item)
+	}
+	if res.Failed == 0 || partial {
+		res.Added = len(products) - res.Failed
+	}
+	return res, f.err
+}
+func (f *fakeService) ProductsByBarcode(_ context.Context, a model.Actor, barcode string) ([]model.Product, error) {
+	f.lastActor = a
+	return []model.Product{{ID: "pr1", Type: "обувь", Barcode: barcode}}, f.err
+}
+func (f *fakeService) DeleteLastProduct(_ context.Context, a model.Actor, _ string) error {
+	f.lastActor = a
+	return f.err
+}
+func (f *fakeService) DeleteProduct(_ context.Context, a model.Actor, _, productID, barcode string) (model.Product, error) {
+	f.lastActor = a
+	return model.Product{ID: productID, Type: "обувь", Barcode: barcode}, f.err
+}
+func (f *fakeService) CorrectProductType(_ context.Context, a model.Actor, _, productID, productType string) (model.Product, error) {
+	f.lastActor = a
+	return model.Product{ID: productID, Type: productType}, f.err
+}
+func (f *fakeService) UndoProductOperations(_ context.Context, a model.Actor, _ string, steps int) ([]model.ProductOperation, error) {
+	f.lastActor, f.lastSteps = a, steps
+	return []model.ProductOperation{{ID: "op1", Kind: service.OpAdd, State: service.OpUndone}}, f.err
+}
+func (f *fakeService) RedoProductOperations(_ context.Context, a model.Actor, _ string, steps int) ([]model.ProductOperation, error) {
+	f.lastActor, f.lastSteps = a, steps
+	return []model.ProductOperation{{ID: "op1", Kind: service.OpAdd, State: service.OpDone}}, f.err
+}
+func (f *fakeService) ProductOperations(_ context.Context, a model.Actor, _ string) ([]model.ProductOperation, error) {
+	f.lastActor = a
+	return []model.ProductOperation{{ID: "op1", Kind: service.OpAdd, State: service.OpDone}}, f.err
+}
+func (f *fakeService) CloseReception(_ context.Context, a model.Actor, pvzID string) (model.Reception, error) {
+	f.lastActor = a
+	return model.Reception{ID: "r1", PVZID: pvzID}, f.err
+}
+func (f *fakeService) ReceptionReport(_ context.Context, a model.Actor, id string) (model.ReceptionReport, error) {
+	f.lastActor = a
+	return model.ReceptionReport{ReceptionID: id, Status: "discrepancies"}, f.err
+}
+func (f *fakeService) AcceptReceptionReport(_ context.Context, a model.Actor, id, comment string) (model.ReceptionReport, error) {
+	f.lastActor = a
+	return model.ReceptionReport{ReceptionID: id, Status: "accepted", Comment: comment}, f.err
+}
+
+func newContext(method, path, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	if body != "" {
+		c.Request.Header.Set("Content-Type", "application/json")
+	}
+	return c, w
+}
+
+func TestHandlers_Success(t *testing.T) {
+	id := uuid.New()
+	cases := []struct {
+		name string
+		call func(h api.ServerInterface, c *gin.Context)
+		body string
+		want int
+	}{
+		{"dummyLogin", func(h api.ServerInterface, c *gin.Context) { h.PostDummyLogin(c) }, `{"role":"employee"}`, http.StatusOK},
+		{"register", func(h api.ServerInterface, c *gin.Context) { h.PostRegister(c) }, `{"email":"a@b","password":"p","role":"employee"}`, http.StatusCreated},
+		{"login", func(h api.ServerInterface, c *gin.Context) { h.PostLogin(c) }, `{"email":"a@b","password":"p"}`, http.StatusOK},
+		{"refresh", func(h api.ServerInterface, c *gin.Context) { h.PostTokenRefresh(c) }, `{"refreshToken":"rt"}`, http.StatusOK},
+		{"logout", func(h api.ServerInterface, c *gin.Context) { h.PostLogout(c) }, `{"refreshToken":"rt"}`, http.StatusNoContent},
+		{"logoutNoBody", func(h api.ServerInterface, c *gin.Context) { h.PostLogout(c) }, ``, http.StatusNoContent},
+		{"logoutAll", func(h api.ServerInterface, c *gin.Context) { h.PostLogoutAll(c) }, ``, http.StatusNoContent},
+		{"listUsers", func(h api.ServerInterface, c *gin.Context) { h.GetUsers(c, api.GetUsersParams{}) }, ``, http.StatusOK},
+		{"changeRole", func(h api.ServerInterface, c *gin.Context) { h.PostUsersUserIdRole(c, id) }, `{"role":"moderator"}`, http.StatusOK},
+		{"disable", func(h api.ServerInterface, c *gin.Context) { h.PostUsersUserIdDisable(c, id) }, ``, http.StatusOK},
+		{"enable", func(h api.ServerInterface, c *gin.Context) { h.PostUsersUserIdEnable(c, id) }, ``, http.StatusOK},
+		{"resetPassword", func(h api.ServerInterface, c *gin.Context) { h.PostUsersUserIdResetPassword(c, id) }, `{"password":"new"}`, http.StatusNoContent},
+		{"invite", func(h api.ServerInterface, c *gin.Context) { h.PostInvites(c) }, `{"email":"new@pvz.ru"}`, http.StatusCreated},
+		{"acceptInvite", func(h api.ServerInterface, c *gin.Context) { h.PostInvitesAccept(c) }, `{"token":"act","password":"p"}`, http.StatusCreated},
+		{"listKeys", func(h api.ServerInterface, c *gin.Context) { h.GetApiKeys(c) }, ``, http.StatusOK},
+		{"createKey", func(h api.ServerInterface, c *gin.Context) { h.PostApiKeys(c) }, `{"name":"erp","scopes":["pvz:read"]}`, http.StatusCreated},
+		{"rotateKey", func(h api.ServerInterface, c *gin.Context) { h.PostApiKeysKeyIdRotate(c, id) }, ``, http.StatusCreated},
+		{"revokeKey", func(h api.ServerInterface, c *gin.Context) { h.DeleteApiKeysKeyId(c, id) }, ``, http.StatusNoContent},
+		{"listCities", func(h api.ServerInterface, c *gin.Context) { h.GetCities(c) }, ``, http.StatusOK},
+		{"createCity", func(h api.ServerInterface, c *gin.Context) { h.PostCities(c) }, `{"name":"Тверь"}`, http.StatusCreated},
+		{"renameCity", func(h api.ServerInterface, c *gin.Context) { h.PatchCitiesName(c, "Питер") }, `{"name":"Санкт-Петербург"}`, http.StatusOK},
+		{"deleteCity", func(h api.ServerInterface, c *gin.Context) { h.DeleteCitiesName(c, "Тверь") }, ``, http.StatusNoContent},
+		{"productTypes", func(h api.ServerInterface, c *gin.Context) { h.GetProductTypes(c) }, ``, http.StatusOK},
+		{"createProductType", func(h api.ServerInterface, c *gin.Context) { h.PostProductTypes(c) }, `{"name":"косметика","fragile":true}`, http.StatusCreated},
+		{"updateProductType", func(h api.ServerInterface, c *gin.Context) { h.PatchProductTypesName(c, "обувь") }, `{"deprecated":true}`, http.StatusOK},
+		{"deleteProductType", func(h api.ServerInterface, c *gin.Context) { h.DeleteProductTypesName(c, "обувь") }, ``, http.StatusNoContent},
+		{"pvz", func(h api.ServerInterface, c *gin.Context) { h.PostPvz(c) }, `{"city":"Казань"}`, http.StatusCreated},
+		{"updatePvz", func(h api.ServerInterface, c *gin.Context) { h.PatchPvzPvzId(c, id) }, `{"status":"suspended"}`, http.StatusOK},
+		{"deactivatePvz", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdDeactivate(c, id) }, ``, http.StatusOK},
+		{"listPvz", func(h api.ServerInterface, c *gin.Context) { h.GetPvz(c, api.GetPvzParams{}) }, ``, http.StatusOK},
+		{"nearbyPvz", func(h api.ServerInterface, c *gin.Context) {
+			h.GetPvzNearby(c, api.GetPvzNearbyParams{Lat: 55.75, Lon: 37.62})
+		}, ``, http.StatusOK},
+		{"employees", func(h api.ServerInterface, c *gin.Context) { h.GetPvzPvzIdEmployees(c, id) }, ``, http.StatusOK},
+		{"assign", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdEmployees(c, id) }, `{"userId":"` + id.String() + `"}`, http.StatusNoContent},
+		{"unassign", func(h api.ServerInterface, c *gin.Context) { h.DeletePvzPvzIdEmployeesUserId(c, id, id) }, ``, http.StatusNoContent},
+		{"receptions", func(h api.ServerInterface, c *gin.Context) { h.PostReceptions(c) }, `{"pvzId":"` + id.String() + `"}`, http.StatusCreated},
+		{"products", func(h api.ServerInterface, c *gin.Context) { h.PostProducts(c) }, `{"pvzId":"` + id.String() + `","type":"электроника"}`, http.StatusCreated},
+		{"productsByBarcode", func(h api.ServerInterface, c *gin.Context) { h.GetProductsByBarcodeCode(c, "4006381333931") }, ``, http.StatusOK},
+		{"deleteLast", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdDeleteLastProduct(c, id) }, ``, http.StatusOK},
+		{"deleteProduct", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdDeleteProduct(c, id) }, `{"barcode":"4006381333931"}`, http.StatusOK},
+		{"correctProduct", func(h api.ServerInterface, c *gin.Context) { h.PatchPvzPvzIdProductsProductId(c, id, id) }, `{"type":"одежда"}`, http.StatusOK},
+		{"undo", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdUndo(c, id) }, ``, http.StatusOK},
+		{"redo", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdRedo(c, id) }, `{"steps":3}`, http.StatusOK},
+		{"productOperations", func(h api.ServerInterface, c *gin.Context) { h.GetPvzPvzIdProductOperations(c, id) }, ``, http.StatusOK},
+		{"closeLast", func(h api.ServerInterface, c *gin.Context) { h.PostPvzPvzIdCloseLastReception(c, id) }, ``, http.StatusOK},
+		{"report", func(h api.ServerInterface, c *gin.Context) { h.GetReceptionsReceptionIdReport(c, id) }, ``, http.StatusOK},
+		{"acceptReport", func(h api.ServerInterface, c *gin.Context) { h.PostReceptionsReceptionIdReportAccept(c, id) }, ``, http.StatusOK},
+		{"acceptReportComment", func(h api.ServerInterface, c *gin.Context) { h.PostReceptionsReceptionIdReportAccept(c, id) }, `{"comment":"ok"}`, http.StatusOK},
+	}
+	for _, tc := range cases {
+		c, _ := newContext("POST", "/", tc.body)
+		tc.call(NewHTTPHandlers(&fakeService{}), c)
+		assert.Equal(t, tc.want, c.Writer.Status(), tc.name)
+	}
+}
+
+func TestHandlers_PassActor(t *testing.T) {
+	svc := &fakeService{}
+	c, _ := newContext("POST", "/receptions", `{"pvzId":"`+uuid.NewString()+`"}`)
+	c.Request = c.Request.WithContext(auth.WithActor(c.Request.Context(), model.Actor{UserID: "u1", Role: "employee"}))
+	NewHTTPHandlers(svc).PostReceptions(c)
+	assert.Equal(t, model.Actor{UserID: "u1", Role: "employee"}, svc.lastActor)
+}
+
+func TestHandlers_ListPVZNextCursor(t *testing.T) {
+	c, w := newContext("GET", "/pvz", ``)
+	NewHTTPHandlers(&fakeService{}).GetPvz(c, api.GetPvzParams{})
+	assert.Equal(t, "next", w.Header().Get("X-Next-Cursor"))
+
+	after := "next"
+	c, w = newContext("GET", "/pvz", ``)
+	NewHTTPHandlers(&fakeService{}).GetPvz(c, api.GetPvzParams{After: &after})
+	assert.Empty(t, w.Header().Values("X-Next-Cursor"))
+}
+
+func TestHandlers_LoginReturnsTokenPair(t *testing.T) {
+	c, w := newContext("POST", "/login", `{"email":"a@b","password":"p"}`)
+	NewHTTPHandlers(&fakeService{}).PostLogin(c)
+	assert.JSONEq(t, `{"token":"tok","refreshToken":"rt"}`, w.Body.String())
+
+	c, w = newContext("POST", "/token/refresh", `{"refreshToken":"rt"}`)
+	NewHTTPHandlers(&fakeService{err: service.ErrRefreshTokenReused}).PostTokenRefresh(c)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestHandlers_InviteReturnsActivationToken(t *testing.T) {
+	c, w := newContext("POST", "/invites", `{"email":"new@pvz.ru","role":"employee"}`)
+	NewHTTPHandlers(&fakeService{}).PostInvites(c)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"activationToken":"act"`)
+	assert.Contains(t, w.Body.String(), `"email":"new@pvz.ru"`)
+}
+
+func TestHandlers_APIKeyReturnsSecret(t *testing.T) {
+	c, w := newContext("POST", "/api_keys", `{"name":"erp","scopes":["pvz:read"]}`)
+	NewHTTPHandlers(&fakeService{}).PostApiKeys(c)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"pvz_abcd1234_secret"`)
+	assert.NotContains(t, w.Body.String(), "keyHash")
+}
+
+func TestHandlers_OIDC(t *testing.T) {
+	c, w := newContext("GET", "/oidc/login", "")
+	NewHTTPHandlers(&fakeService{}).GetOidcLogin(c)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://sso.example/authorize?state=s", w.Header().Get("Location"))
+
+	code := "c1"
+	c, w = newContext("GET", "/oidc/callback", "")
+	NewHTTPHandlers(&fakeService{}).GetOidcCallback(c, api.GetOidcCallbackParams{State: "s", Code: &code})
+	assert.JSONEq(t, `{"token":"tok","refreshToken":"rt"}`, w.Body.String())
+
+	denied := "access_denied"
+	c, w = newContext("GET", "/oidc/callback", "")
+	NewHTTPHandlers(&fakeService{}).GetOidcCallback(c, api.GetOidcCallbackParams{State: "s", Error: &denied})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	c, w = newContext("GET", "/oidc/callback", "")
+	NewHTTPHandlers(&fakeService{}).GetOidcCallback(c, api.GetOidcCallbackParams{State: "s"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandlers_LoginLockedOut(t *testing.T) {
+	c, w := newContext("POST", "/login", `{"email":"a@b","password":"p"}`)
+	NewHTTPHandlers(&fakeService{err: &service.LoginLockedError{RetryAfter: 90 * time.Second}}).PostLogin(c)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "90", w.Header().Get("Retry-After"))
+}
+
+func TestHandlers_JWKS(t *testing.T) {
+	c, w := newContext("GET", "/.well-known/jwks.json", "")
+	NewHTTPHandlers(&fakeService{}).GetWellKnownJwksJson(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"keys":[{"kty":"OKP","kid":"k1","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"x"}]}`, w.Body.String())
+}
+
+func TestHandlers_ErrorMapping(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{e.Forbidden("access forbidden"), http.StatusForbidden},
+		{service.ErrInvalidCredentials, http.StatusUnauthorized},
+		{service.ErrNoOpenReception, http.StatusNotFound},
+		{service.ErrOpenReceptionExists, http.StatusConflict},
+		{e.Precondition("pvz is closed"), http.StatusConflict},
+		{e.Validation("invalid city"), http.StatusUnprocessableEntity},
+		{errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		c, w := newContext("POST", "/receptions", `{"pvzId":"`+uuid.NewString()+`"}`)
+		NewHTTPHandlers(&fakeService{err: tc.err}).PostReceptions(c)
+		assert.Equal(t, tc.want, w.Code, tc.err.Error())
+	}
+
+	c, w := newContext("GET", "/pvz", "")
+	NewHTTPHandlers(&fakeService{err: errors.New("pq: password authentication failed")}).GetPvz(c, api.GetPvzParams{})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"message":"internal error"}`, w.Body.String())
+}
+
+func TestHandlers_InvalidJSON(t *testing.T) {
+	h := NewHTTPHandlers(&fakeService{})
+	calls := []func(c *gin.Context){h.PostDummyLogin, h.PostRegister, h.PostLogin, h.PostTokenRefresh, h.PostInvites, h.PostInvitesAccept, h.PostCities, h.PostPvz, h.PostReceptions, h.PostProducts}
+	for _, call := range calls {
+		c, w := newContext("POST", "/", `{bad}`)
+		call(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestHandlers_AddProductIdentity(t *testing.T) {
+	c, w := newContext("POST", "/products", `{"pvzId":"`+uuid.NewString()+`","type":"обувь","barcode":"4006381333931","sku":"SH-42","weightGrams":450,"dimensions":{"lengthMm":300,"widthMm":200,"heightMm":120}}`)
+	NewHTTPHandlers(&fakeService{}).PostProducts(c)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	for _, want := range []string{`"barcode":"4006381333931"`, `"sku":"SH-42"`, `"weightGrams":450`, `"dimensions":{"lengthMm":300,"widthMm":200,"heightMm":120}`} {
+		assert.Contains(t, w.Body.String(), want)
+	}
+}
+
+func TestHandlers_AddProductsBatch(t *testing.T) {
+	pvzID := uuid.NewString()
+	cases := []struct {
+		name, body string
+		want       int
+	}{
+		{"allAdded", `{"pvzId":"` + pvzID + `","products":[{"type":"обувь"},{"type":"одежда"}]}`, http.StatusCreated},
+		{"atomicRejected", `{"pvzId":"` + pvzID + `","products":[{"type":"обувь"},{"type":"мебель"}]}`, http.StatusUnprocessableEntity},
+		{"partial", `{"pvzId":"` + pvzID + `","mode":"partial","products":[{"type":"обувь"},{"type":"мебель"}]}`, http.StatusOK},
+		{"partialNoneAdded", `{"pvzId":"` + pvzID + `","mode":"partial","products":[{"type":"мебель"}]}`, http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		c, w := newContext("POST", "/products/batch", tc.body)
+		NewHTTPHandlers(&fakeService{}).PostProductsBatch(c)
+		assert.Equal(t, tc.want, w.Code, tc.name)
+		assert.Contains(t, w.Body.String(), `"items":[`, tc.name)
+	}
+}
+
+func TestHandlers_UndoSteps(t *testing.T) {
+	id := uuid.New()
+	svc := &fakeService{}
+	h := NewHTTPHandlers(svc)
+	c, w := newContext("POST", "/pvz/"+id.String()+"/undo", ``)
+	h.PostPvzPvzIdUndo(c, id)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, svc.lastSteps)
+	assert.Contains(t, w.Body.String(), `"state":"undone"`)
+
+	c, _ = newContext("POST", "/pvz/"+id.String()+"/redo", `{"steps":3}`)
+	h.PostPvzPvzIdRedo(c, id)
+	assert.Equal(t, 3, svc.lastSteps)
+
+	c, w = newContext("POST", "/pvz/"+id.String()+"/undo", `{bad}`)
+	h.PostPvzPvzIdUndo(c, id)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	c, w = newContext("POST", "/pvz/"+id.String()+"/delete_last_product", ``)
+	NewHTTPHandlers(&fakeService{err: e.NotFound("no products to delete in the open reception")}).PostPvzPvzIdDeleteLastProduct(c, id)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -4,19 +4,97 @@ import (
 	"context"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"log"
+	"net/http"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	oapimw "github.com/oapi-codegen/gin-middleware"
 	api "pvz-backend-service/internal/api/types"
+	"pvz-backend-service/internal/auth"
 )
 
-func RegisterHTTP(r *gin.Engine, svc api.ServerInterface) {
+// DevOnlyPaths are served only when dev features are enabled.
+var DevOnlyPaths = []string{"/dummyLogin"}
+
+// OIDCPaths are served only when single sign-on is configured.
+var OIDCPaths = []string{"/oidc/login", "/oidc/callback"}
+
+var publicPaths = []string{"/register", "/login", "/token/refresh", "/invites/accept", "/.well-known/jwks.json", "/oidc/login", "/oidc/callback"}
+
+// HTTPPermissions maps gin routes to the permission auth.RequirePermissions
+// demands; authenticated routes missing here are denied.
+var HTTPPermissions = map[string]auth.Permission{
+	"GET /cities":                                 auth.PermPVZRead,
+	"POST /cities":                                auth.PermCityManage,
+	"PATCH /cities/:name":                         auth.PermCityManage,
+	"DELETE /cities/:name":                        auth.PermCityManage,
+	"GET /product_types":                          auth.PermPVZRead,
+	"POST /product_types":                         auth.PermCatalogManage,
+	"PATCH /product_types/:name":                  auth.PermCatalogManage,
+	"DELETE /product_types/:name":                 auth.PermCatalogManage,
+	"GET /pvz":                                    auth.PermPVZRead,
+	"POST /pvz":                                   auth.PermPVZCreate,
+	"GET /pvz/nearby":                             auth.PermPVZRead,
+	"PATCH /pvz/:pvzId":                           auth.PermPVZManage,
+	"POST /pvz/:pvzId/deactivate":                 auth.PermPVZManage,
+	"POST /receptions":                            auth.PermReceptionOpen,
+	"GET /receptions/:receptionId/report":         auth.PermPVZRead,
+	"POST /receptions/:receptionId/report/accept": auth.PermReceptionAccept,
+	"POST /products":                              auth.PermProductAdd,
+	"POST /products/batch":                        auth.PermProductAdd,
+	"GET /products/by-barcode/:code":              auth.PermPVZRead,
+	"POST /pvz/:pvzId/delete_last_product":        auth.PermProductDelete,
+	"POST /pvz/:pvzId/delete_product":             auth.PermProductDelete,
+	"PATCH /pvz/:pvzId/products/:productId":       auth.PermProductAdd,
+	"POST /pvz/:pvzId/undo":                       auth.PermProductDelete,
+	"POST /pvz/:pvzId/redo":                       auth.PermProductDelete,
+	"GET /pvz/:pvzId/product_operations":          auth.PermPVZRead,
+	"GET /pvz/:pvzId/employees":                   auth.PermUserRead,
+	"POST /pvz/:pvzId/employees":                  auth.PermPVZAssign,
+	"DELETE /pvz/:pvzId/employees/:userId":        auth.PermPVZAssign,
+	"POST /pvz/:pvzId/close_last_reception":       auth.PermReceptionClose,
+	"GET /users":                                  auth.PermUserRead,
+	"POST /users/:userId/role":                    auth.PermUserManage,
+	"POST /users/:userId/disable":                 auth.PermUserManage,
+	"POST /users/:userId/enable":                  auth.PermUserManage,
+	"POST /users/:userId/reset_password":          auth.PermUserManage,
+	"POST /invites":                               auth.PermUserInvite,
+	"GET /api_keys":                               auth.PermAPIKeyManage,
+	"POST /api_keys":                              auth.PermAPIKeyManage,
+	"POST /api_keys/:keyId/rotate":                auth.PermAPIKeyManage,
+	"DELETE /api_keys/:keyId":                     auth.PermAPIKeyManage,
+	"POST /logout":                                auth.PermAuthenticated,
+	"POST /logout/all":                            auth.PermAuthenticated,
+}
+
+// PublicHTTPPaths lists the routes auth.Middleware lets through without a token.
+func PublicHTTPPaths(devFeatures bool) map[string]bool {
+	paths := map[string]bool{}
+	for _, p := range publicPaths {
+		paths[p] = true
+	}
+	if devFeatures {
+		for _, p := range DevOnlyPaths {
+			paths[p] = true
+		}
+	}
+	return paths
+}
+
+// RegisterHTTP mounts the generated handlers behind the OpenAPI validator.
+// Disabled paths are dropped both from the router and from the spec, so they
+// are indistinguishable from routes that never existed.
+func RegisterHTTP(r *gin.Engine, svc api.ServerInterface, disabled ...string) {
 	loader := openapi3.NewLoader()
 	doc, err := loader.LoadFromFile("api/openapi.yaml")
 	if err != nil {
 		log.Fatalf("failed to load OpenAPI spec: %v", err)
 	}
+	skip := map[string]bool{}
+	for _, p := range disabled {
+		skip[p] = true
+		doc.Paths.Delete(p)
+	}
 	if err := doc.Validate(context.Background()); err != nil {
 		log.Fatalf("OpenAPI spec validation failed: %v", err)
 	}
@@ -32,5 +110,37 @@ func RegisterHTTP(r *gin.Engine, svc api.ServerInterface) {
 		},
 	}))
 
-	api.RegisterHandlers(r, svc)
+	api.RegisterHandlers(filteredRouter{IRouter: r, skip: skip}, svc)
+}
+
+type filteredRouter struct {
+	gin.IRouter
+	skip map[string]bool
+}
+
+func (r filteredRouter) handle(method, path string, h []gin.HandlerFunc) gin.IRoutes {
+	if r.skip[path] {
+		return r
+	}
+	return r.IRouter.Handle(method, path, h...)
+}
+
+func (r filteredRouter) GET(path string, h ...gin.HandlerFunc) gin.IRoutes {
+	return r.handle(http.MethodGet, path, h)
+}
+
+func (r filteredRouter) POST(path string, h ...gin.HandlerFunc) gin.IRoutes {
+	return r.handle(http.MethodPost, path, h)
+}
+
+func (r filteredRouter) PUT(path string, h ...gin.HandlerFunc) gin.IRoutes {
+	return r.handle(http.MethodPut, path, h)
+}
+
+func (r filteredRouter) PATCH(path string, h ...gin.HandlerFunc) gin.IRoutes {
+	return r.handle(http.MethodPatch, path, h)
+}
+
+func (r filteredRouter) DELETE(path string, h ...gin.HandlerFunc) gin.IRoutes {
+	return r.handle(http.MethodDelete, path, h)
 }
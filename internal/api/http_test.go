@@ -9,10 +9,14 @@ import (
 	"os"
 	"path/filepath"
 	api "pvz-backend-service/internal/api/types"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
 )
 
 var _ api.ServerInterface = (*stubService)(nil)
@@ -41,12 +45,136 @@ func (s stubService) PostLogin(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"token": "tok"})
 }
 
+func (s stubService) PostTokenRefresh(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"token": "tok", "refreshToken": "rt"})
+}
+
+func (s stubService) PostLogout(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) PostLogoutAll(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) GetWellKnownJwksJson(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"keys": []gin.H{}})
+}
+
+func (s stubService) GetUsers(c *gin.Context, params api.GetUsersParams) {
+	c.JSON(http.StatusOK, []gin.H{})
+}
+
+func (s stubService) PostUsersUserIdRole(c *gin.Context, userId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": userId})
+}
+
+func (s stubService) PostUsersUserIdDisable(c *gin.Context, userId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": userId})
+}
+
+func (s stubService) PostUsersUserIdEnable(c *gin.Context, userId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": userId})
+}
+
+func (s stubService) PostUsersUserIdResetPassword(c *gin.Context, userId openapi_types.UUID) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) PostInvites(c *gin.Context) {
+	c.JSON(http.StatusCreated, gin.H{"id": "i1"})
+}
+
+func (s stubService) PostInvitesAccept(c *gin.Context) {
+	c.JSON(http.StatusCreated, gin.H{"token": "tok"})
+}
+
 func (s stubService) PostPvz(c *gin.Context) {
 	var req struct{ City string }
 	_ = c.BindJSON(&req)
 	c.JSON(http.StatusCreated, gin.H{"id": "p1", "city": req.City})
 }
 
+func (s stubService) GetPvzNearby(c *gin.Context, params api.GetPvzNearbyParams) {
+	c.JSON(http.StatusOK, []gin.H{})
+}
+
+func (s stubService) PatchPvzPvzId(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": pvzId, "city": "Москва"})
+}
+
+func (s stubService) PostPvzPvzIdDeactivate(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": pvzId, "city": "Москва", "status": "closed"})
+}
+
+func (s stubService) GetPvzPvzIdEmployees(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, []gin.H{})
+}
+
+func (s stubService) PostPvzPvzIdEmployees(c *gin.Context, pvzId openapi_types.UUID) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) DeletePvzPvzIdEmployeesUserId(c *gin.Context, pvzId openapi_types.UUID, userId openapi_types.UUID) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) GetOidcLogin(c *gin.Context) {
+	c.Redirect(http.StatusFound, "https://sso.example/authorize")
+}
+
+func (s stubService) GetOidcCallback(c *gin.Context, params api.GetOidcCallbackParams) {
+	c.JSON(http.StatusOK, gin.H{"token": "tok", "refreshToken": "rt"})
+}
+
+func (s stubService) GetApiKeys(c *gin.Context) {
+	c.JSON(http.StatusOK, []any{})
+}
+
+func (s stubService) PostApiKeys(c *gin.Context) {
+	c.Status(http.StatusCreated)
+}
+
+func (s stubService) PostApiKeysKeyIdRotate(c *gin.Context, keyId openapi_types.UUID) {
+	c.Status(http.StatusCreated)
+}
+
+func (s stubService) DeleteApiKeysKeyId(c *gin.Context, keyId openapi_types.UUID) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) GetCities(c *gin.Context) {
+	c.JSON(http.StatusOK, []gin.H{{"name": "Москва"}})
+}
+
+func (s stubService) PostCities(c *gin.Context) {
+	c.Status(http.StatusCreated)
+}
+
+func (s stubService) PatchCitiesName(c *gin.Context, name string) {
+	c.JSON(http.StatusOK, gin.H{"name": name})
+}
+
+func (s stubService) DeleteCitiesName(c *gin.Context, name string) {
+	c.Status(http.StatusNoContent)
+}
+
+func (s stubService) GetProductTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, []gin.H{{"name": "обувь"}})
+}
+
+func (s stubService) PostProductTypes(c *gin.Context) {
+	c.JSON(http.StatusCreated, gin.H{"name": "косметика"})
+}
+
+func (s stubService) PatchProductTypesName(c *gin.Context, name string) {
+	c.JSON(http.StatusOK, gin.H{"name": name})
+}
+
+func (s stubService) DeleteProductTypesName(c *gin.Context, name string) {
+	c.Status(http.StatusNoContent)
+}
+
 func (s stubService) GetPvz(c *gin.Context, params api.GetPvzParams) {
 	c.JSON(http.StatusOK, gin.H{"items": []string{"p1"}, "count": 1})
 }
@@ -59,19 +187,55 @@ func (s stubService) PostProducts(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"id": "pr1"})
 }
 
+func (s stubService) PostProductsBatch(c *gin.Context) {
+	c.JSON(http.StatusCreated, gin.H{"added": 1, "failed": 0, "items": []gin.H{{"index": 0, "status": "added"}}})
+}
+
+func (s stubService) GetProductsByBarcodeCode(c *gin.Context, code string) {
+	c.JSON(http.StatusOK, []gin.H{{"id": "pr1", "barcode": code}})
+}
+
 func (s stubService) PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId openapi_types.UUID) {
 	c.Status(http.StatusOK)
 }
 
+func (s stubService) PostPvzPvzIdDeleteProduct(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": "pr1", "type": "обувь"})
+}
+
+func (s stubService) PatchPvzPvzIdProductsProductId(c *gin.Context, pvzId openapi_types.UUID, productId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"id": productId, "type": "одежда"})
+}
+
+func (s stubService) PostPvzPvzIdUndo(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, []gin.H{{"id": "op1", "kind": "add", "state": "undone"}})
+}
+
+func (s stubService) PostPvzPvzIdRedo(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, []gin.H{{"id": "op1", "kind": "add", "state": "done"}})
+}
+
+func (s stubService) GetPvzPvzIdProductOperations(c *gin.Context, pvzId openapi_types.UUID) {
+	c.JSON(http.StatusOK, []gin.H{{"id": "op1", "kind": "add", "state": "done"}})
+}
+
 func (s stubService) PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId openapi_types.UUID) {
 	c.JSON(http.StatusOK, gin.H{"id": "r1"})
 }
 
+func (s stubService) GetReceptionsReceptionIdReport(c *gin.Context, receptionId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"receptionId": receptionId, "status": "matched"})
+}
+
+func (s stubService) PostReceptionsReceptionIdReportAccept(c *gin.Context, receptionId openapi_types.UUID) {
+	c.JSON(http.StatusOK, gin.H{"receptionId": receptionId, "status": "accepted"})
+}
+
 func setupRouterNoAuth() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	r.Use(func(c *gin.Context) {
-		c.Set("role", "moderator")
+		c.Request = c.Request.WithContext(auth.WithActor(c.Request.Context(), model.Actor{Role: "moderator"}))
 		c.Next()
 	})
 	RegisterHTTP(r, &stubService{})
@@ -121,6 +285,116 @@ func TestMissingContentType(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestDisabledDevOnlyPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterHTTP(r, &stubService{}, DevOnlyPaths...)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/dummyLogin", bytes.NewBufferString(`{"role":"moderator"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/login", bytes.NewBufferString(`{"email":"a@b","password":"p"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestOIDCPaths(t *testing.T) {
+	r := setupRouterNoAuth()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/oidc/callback?state=s&code=c", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/oidc/callback?code=c", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "state is required")
+
+	gin.SetMode(gin.TestMode)
+	r = gin.New()
+	RegisterHTTP(r, &stubService{}, OIDCPaths...)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/oidc/login", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPublicHTTPPaths(t *testing.T) {
+	assert.True(t, PublicHTTPPaths(false)["/oidc/callback"])
+	assert.True(t, PublicHTTPPaths(true)["/dummyLogin"])
+	assert.False(t, PublicHTTPPaths(false)["/dummyLogin"])
+	assert.True(t, PublicHTTPPaths(false)["/login"])
+	assert.False(t, PublicHTTPPaths(false)["/pvz"])
+}
+
+func TestHTTPPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithActor(c.Request.Context(), model.Actor{Role: c.GetHeader("X-Role")}))
+		c.Next()
+	}, auth.RequirePermissions(auth.DefaultPermissions(), HTTPPermissions, PublicHTTPPaths(true)))
+	RegisterHTTP(r, &stubService{})
+
+	routes := map[string]bool{}
+	public := PublicHTTPPaths(true)
+	for _, rt := range r.Routes() {
+		routes[rt.Method+" "+rt.Path] = true
+		_, ok := HTTPPermissions[rt.Method+" "+rt.Path]
+		assert.True(t, ok || public[rt.Path], "route %s %s has no permission rule", rt.Method, rt.Path)
+	}
+	for route := range HTTPPermissions {
+		assert.True(t, routes[route], "permission rule for unknown route %s", route)
+	}
+
+	cases := []struct {
+		role string
+		want int
+	}{
+		{auth.RoleAuditor, http.StatusForbidden},
+		{auth.RoleEmployee, http.StatusForbidden},
+		{auth.RoleModerator, http.StatusCreated},
+		{auth.RoleAdmin, http.StatusCreated},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/pvz", bytes.NewBufferString(`{"city":"Москва"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Role", tc.role)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tc.want, w.Code, tc.role)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/pvz", nil)
+	req.Header.Set("X-Role", auth.RoleAuditor)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUserManagementValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/"+uuid.NewString()+"/role", bytes.NewBufferString(`{"role":"root"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/users?limit=1000", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/invites", bytes.NewBufferString(`{"role":"employee"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestNotFoundRoute(t *testing.T) {
 	r := setupRouterNoAuth()
 	w := httptest.NewRecorder()
@@ -183,3 +457,162 @@ func TestPathParamValidationErrors(t *testing.T) {
 	json.Unmarshal(w.Body.Bytes(), &errBody)
 	assert.Contains(t, errBody["msg"], "pvzId")
 }
+
+func TestPVZUpdateValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	for _, body := range []string{`{"status":"closed"}`, `{"latitude":120}`, `{"workingHours":[{"weekday":8,"opens":"09:00","closes":"18:00"}]}`} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("PATCH", "/pvz/"+uuid.NewString(), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/pvz/"+uuid.NewString(), bytes.NewBufferString(`{"status":"suspended","capacity":50}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPVZNearbyValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	for _, q := range []string{"", "?lat=55.7", "?lat=91&lon=37.6", "?lat=55.7&lon=181", "?lat=55.7&lon=37.6&radius=0", "?lat=55.7&lon=37.6&radius=101", "?lat=55.7&lon=37.6&limit=0"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/pvz/nearby"+q, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/pvz/nearby?lat=55.75&lon=37.62&radius=2.5&limit=5&open=true", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReceptionManifestValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	pvzID := uuid.NewString()
+	for _, manifest := range []string{`{"items":[]}`, `{"barcodes":[]}`, `{"items":[{"type":"обувь","count":0}]}`} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/receptions", bytes.NewBufferString(`{"pvzId":"`+pvzID+`","manifest":`+manifest+`}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, manifest)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/receptions", bytes.NewBufferString(`{"pvzId":"`+pvzID+`","manifest":{"supplier":"ООО Поставка","items":[{"type":"обувь","count":3}]}}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/receptions", bytes.NewBufferString(`{"pvzId":"`+pvzID+`","manifest":{"barcodes":["4006381333931"]}}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/receptions/"+uuid.NewString()+"/report/accept", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/receptions/not-a-uuid/report", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProductIdentityValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	pvzID := uuid.NewString()
+	for _, extra := range []string{`"weightGrams":0`, `"dimensions":{"lengthMm":10,"widthMm":10}`, `"dimensions":{"lengthMm":10,"widthMm":0,"heightMm":5}`, `"sku":1`} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/products", bytes.NewBufferString(`{"pvzId":"`+pvzID+`","type":"обувь",`+extra+`}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, extra)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/products", bytes.NewBufferString(`{"pvzId":"`+pvzID+`","type":"обувь","barcode":"4006381333931","sku":"SH-42","weightGrams":450,"dimensions":{"lengthMm":300,"widthMm":200,"heightMm":120}}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/products/by-barcode/4006381333931", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestProductTypeValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	for _, tc := range []struct{ method, path, body string }{
+		{"POST", "/product_types", `{}`},
+		{"POST", "/product_types", `{"name":"косметика","maxWeightGrams":0}`},
+		{"PATCH", "/product_types/обувь", `{"maxWeightGrams":-1}`},
+		{"PATCH", "/product_types/обувь", `{"deprecated":"yes"}`},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.body)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/products", bytes.NewBufferString(`{"pvzId":"`+uuid.NewString()+`","type":"косметика"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestProductsBatchValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	pvzID := uuid.NewString()
+	tooMany := strings.Repeat(`{"type":"обувь"},`, 500) + `{"type":"обувь"}`
+	for _, body := range []string{
+		`{"pvzId":"` + pvzID + `","products":[]}`,
+		`{"pvzId":"` + pvzID + `"}`,
+		`{"pvzId":"` + pvzID + `","mode":"best-effort","products":[{"type":"обувь"}]}`,
+		`{"pvzId":"` + pvzID + `","products":[{"barcode":"4006381333931"}]}`,
+		`{"pvzId":"` + pvzID + `","products":[` + tooMany + `]}`,
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/products/batch", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body[:min(len(body), 80)])
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/products/batch", bytes.NewBufferString(`{"pvzId":"`+pvzID+`","mode":"partial","products":[{"type":"обувь","barcode":"4006381333931"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestProductCorrectionValidation(t *testing.T) {
+	r := setupRouterNoAuth()
+	pvz := "/pvz/" + uuid.NewString()
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", pvz + "/delete_product", `{"barcode":"4006381333931"}`, http.StatusOK},
+		{"POST", pvz + "/delete_product", `{"productId":42}`, http.StatusBadRequest},
+		{"PATCH", pvz + "/products/" + uuid.NewString(), `{"type":"одежда"}`, http.StatusOK},
+		{"PATCH", pvz + "/products/" + uuid.NewString(), `{}`, http.StatusBadRequest},
+		{"PATCH", pvz + "/products/not-uuid", `{"type":"одежда"}`, http.StatusBadRequest},
+		{"POST", pvz + "/undo", ``, http.StatusOK},
+		{"POST", pvz + "/undo", `{"steps":0}`, http.StatusBadRequest},
+		{"POST", pvz + "/redo", `{"steps":51}`, http.StatusBadRequest},
+		{"GET", pvz + "/product_operations", ``, http.StatusOK},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+		if tc.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tc.want, w.Code, tc.method+" "+tc.path+" "+tc.body)
+	}
+}
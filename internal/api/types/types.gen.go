@@ -14,21 +14,59 @@ import (
 )
 
 const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
-// Defines values for PVZCity.
+// Defines values for BatchResultItemsStatus.
 const (
-	Казань         PVZCity = "Казань"
-	Москва         PVZCity = "Москва"
-	СанктПетербург PVZCity = "Санкт-Петербург"
+	Added   BatchResultItemsStatus = "added"
+	Failed  BatchResultItemsStatus = "failed"
+	Skipped BatchResultItemsStatus = "skipped"
 )
 
-// Defines values for ProductType.
+// Defines values for InviteRole.
 const (
-	ProductTypeОбувь       ProductType = "обувь"
-	ProductTypeОдежда      ProductType = "одежда"
-	ProductTypeЭлектроника ProductType = "электроника"
+	InviteRoleAdmin     InviteRole = "admin"
+	InviteRoleAuditor   InviteRole = "auditor"
+	InviteRoleEmployee  InviteRole = "employee"
+	InviteRoleModerator InviteRole = "moderator"
+)
+
+// Defines values for NearbyPVZStatus.
+const (
+	NearbyPVZStatusActive    NearbyPVZStatus = "active"
+	NearbyPVZStatusClosed    NearbyPVZStatus = "closed"
+	NearbyPVZStatusSuspended NearbyPVZStatus = "suspended"
+)
+
+// Defines values for PVZStatus.
+const (
+	PVZStatusActive    PVZStatus = "active"
+	PVZStatusClosed    PVZStatus = "closed"
+	PVZStatusSuspended PVZStatus = "suspended"
+)
+
+// Defines values for PVZUpdateStatus.
+const (
+	Active    PVZUpdateStatus = "active"
+	Suspended PVZUpdateStatus = "suspended"
+)
+
+// Defines values for ProductOperationKind.
+const (
+	Add    ProductOperationKind = "add"
+	Delete ProductOperationKind = "delete"
+	Redo   ProductOperationKind = "redo"
+	Retype ProductOperationKind = "retype"
+	Undo   ProductOperationKind = "undo"
+)
+
+// Defines values for ProductOperationState.
+const (
+	Discarded ProductOperationState = "discarded"
+	Done      ProductOperationState = "done"
+	Undone    ProductOperationState = "undone"
 )
 
 // Defines values for ReceptionStatus.
@@ -37,81 +75,401 @@ const (
 	InProgress ReceptionStatus = "in_progress"
 )
 
+// Defines values for ReceptionReportStatus.
+const (
+	Accepted      ReceptionReportStatus = "accepted"
+	Discrepancies ReceptionReportStatus = "discrepancies"
+	Matched       ReceptionReportStatus = "matched"
+)
+
 // Defines values for UserRole.
 const (
+	UserRoleAdmin     UserRole = "admin"
+	UserRoleAuditor   UserRole = "auditor"
 	UserRoleEmployee  UserRole = "employee"
 	UserRoleModerator UserRole = "moderator"
 )
 
 // Defines values for PostDummyLoginJSONBodyRole.
 const (
+	PostDummyLoginJSONBodyRoleAdmin     PostDummyLoginJSONBodyRole = "admin"
+	PostDummyLoginJSONBodyRoleAuditor   PostDummyLoginJSONBodyRole = "auditor"
 	PostDummyLoginJSONBodyRoleEmployee  PostDummyLoginJSONBodyRole = "employee"
 	PostDummyLoginJSONBodyRoleModerator PostDummyLoginJSONBodyRole = "moderator"
 )
 
-// Defines values for PostProductsJSONBodyType.
+// Defines values for PostInvitesJSONBodyRole.
+const (
+	PostInvitesJSONBodyRoleAdmin     PostInvitesJSONBodyRole = "admin"
+	PostInvitesJSONBodyRoleAuditor   PostInvitesJSONBodyRole = "auditor"
+	PostInvitesJSONBodyRoleEmployee  PostInvitesJSONBodyRole = "employee"
+	PostInvitesJSONBodyRoleModerator PostInvitesJSONBodyRole = "moderator"
+)
+
+// Defines values for PostProductsBatchJSONBodyMode.
 const (
-	PostProductsJSONBodyTypeОбувь       PostProductsJSONBodyType = "обувь"
-	PostProductsJSONBodyTypeОдежда      PostProductsJSONBodyType = "одежда"
-	PostProductsJSONBodyTypeЭлектроника PostProductsJSONBodyType = "электроника"
+	Atomic  PostProductsBatchJSONBodyMode = "atomic"
+	Partial PostProductsBatchJSONBodyMode = "partial"
 )
 
 // Defines values for PostRegisterJSONBodyRole.
 const (
-	Employee  PostRegisterJSONBodyRole = "employee"
-	Moderator PostRegisterJSONBodyRole = "moderator"
+	PostRegisterJSONBodyRoleAuditor   PostRegisterJSONBodyRole = "auditor"
+	PostRegisterJSONBodyRoleEmployee  PostRegisterJSONBodyRole = "employee"
+	PostRegisterJSONBodyRoleModerator PostRegisterJSONBodyRole = "moderator"
+)
+
+// Defines values for GetUsersParamsRole.
+const (
+	GetUsersParamsRoleAdmin     GetUsersParamsRole = "admin"
+	GetUsersParamsRoleAuditor   GetUsersParamsRole = "auditor"
+	GetUsersParamsRoleEmployee  GetUsersParamsRole = "employee"
+	GetUsersParamsRoleModerator GetUsersParamsRole = "moderator"
 )
 
+// Defines values for PostUsersUserIdRoleJSONBodyRole.
+const (
+	Admin     PostUsersUserIdRoleJSONBodyRole = "admin"
+	Auditor   PostUsersUserIdRoleJSONBodyRole = "auditor"
+	Employee  PostUsersUserIdRoleJSONBodyRole = "employee"
+	Moderator PostUsersUserIdRoleJSONBodyRole = "moderator"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt time.Time          `json:"createdAt"`
+	CreatedBy *string            `json:"createdBy,omitempty"`
+	ExpiresAt *time.Time         `json:"expiresAt,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// Key Ключ целиком, возвращается только при создании и ротации
+	Key        *string    `json:"key,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix Открытая часть ключа, по которой его можно опознать
+	Prefix    string     `json:"prefix"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	Scopes    []string   `json:"scopes"`
+}
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	Added  int `json:"added"`
+	Failed int `json:"failed"`
+
+	// Items Результат по каждому товару в порядке запроса
+	Items []struct {
+		Error   *string  `json:"error,omitempty"`
+		Index   int      `json:"index"`
+		Product *Product `json:"product,omitempty"`
+
+		// Status skipped — товар корректен, но пакет отклонен целиком
+		Status BatchResultItemsStatus `json:"status"`
+	} `json:"items"`
+}
+
+// BatchResultItemsStatus skipped — товар корректен, но пакет отклонен целиком
+type BatchResultItemsStatus string
+
+// City defines model for City.
+type City struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	Name      string     `json:"name"`
+}
+
+// Dimensions Габариты в миллиметрах
+type Dimensions struct {
+	HeightMm int `json:"heightMm"`
+	LengthMm int `json:"lengthMm"`
+	WidthMm  int `json:"widthMm"`
+}
+
+// DiscrepancyLine defines model for DiscrepancyLine.
+type DiscrepancyLine struct {
+	Expected int    `json:"expected"`
+	Received int    `json:"received"`
+	Type     string `json:"type"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
 }
 
+// Invite defines model for Invite.
+type Invite struct {
+	// ActivationToken Одноразовый токен активации, возвращается только при создании
+	ActivationToken string              `json:"activationToken"`
+	Email           openapi_types.Email `json:"email"`
+	ExpiresAt       time.Time           `json:"expiresAt"`
+	Id              openapi_types.UUID  `json:"id"`
+	InvitedBy       *string             `json:"invitedBy,omitempty"`
+	Role            InviteRole          `json:"role"`
+}
+
+// InviteRole defines model for Invite.Role.
+type InviteRole string
+
+// JWK defines model for JWK.
+type JWK struct {
+	Alg string  `json:"alg"`
+	Crv *string `json:"crv,omitempty"`
+	E   *string `json:"e,omitempty"`
+	Kid string  `json:"kid"`
+	Kty string  `json:"kty"`
+	N   *string `json:"n,omitempty"`
+	Use string  `json:"use"`
+	X   *string `json:"x,omitempty"`
+}
+
+// JWKS defines model for JWKS.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Manifest Ожидаемая поставка; при закрытии приемки с ней сверяется фактический состав
+type Manifest struct {
+	// Barcodes Ожидаемые штрихкоды; сверяются с отсканированными товарами
+	Barcodes *[]string `json:"barcodes,omitempty"`
+	Items    *[]struct {
+		Count int    `json:"count"`
+		Type  string `json:"type"`
+	} `json:"items,omitempty"`
+	Supplier *string `json:"supplier,omitempty"`
+}
+
+// NearbyPVZ defines model for NearbyPVZ.
+type NearbyPVZ struct {
+	Address  *string `json:"address,omitempty"`
+	Capacity *int    `json:"capacity,omitempty"`
+
+	// City Название города из справочника GET /cities
+	City string `json:"city"`
+
+	// DistanceKm Расстояние от точки поиска по дуге большого круга
+	DistanceKm float64             `json:"distanceKm"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	Latitude   *float64            `json:"latitude,omitempty"`
+	Longitude  *float64            `json:"longitude,omitempty"`
+
+	// OpenNow Работает ли ПВЗ сейчас по своему графику и часовому поясу
+	OpenNow          bool       `json:"openNow"`
+	RegistrationDate *time.Time `json:"registrationDate,omitempty"`
+
+	// Status Новый ПВЗ создаётся активным; приёмки открываются только в активных ПВЗ
+	Status *NearbyPVZStatus `json:"status,omitempty"`
+
+	// Timezone Часовой пояс IANA, по умолчанию Europe/Moscow
+	Timezone     *string         `json:"timezone,omitempty"`
+	WorkingHours *[]WorkingHours `json:"workingHours,omitempty"`
+}
+
+// NearbyPVZStatus Новый ПВЗ создаётся активным; приёмки открываются только в активных ПВЗ
+type NearbyPVZStatus string
+
+// NewProduct defines model for NewProduct.
+type NewProduct struct {
+	Barcode *string `json:"barcode,omitempty"`
+
+	// Dimensions Габариты в миллиметрах
+	Dimensions  *Dimensions `json:"dimensions,omitempty"`
+	Sku         *string     `json:"sku,omitempty"`
+	Type        string      `json:"type"`
+	WeightGrams *int        `json:"weightGrams,omitempty"`
+}
+
 // PVZ defines model for PVZ.
 type PVZ struct {
-	City             PVZCity             `json:"city"`
+	Address  *string `json:"address,omitempty"`
+	Capacity *int    `json:"capacity,omitempty"`
+
+	// City Название города из справочника GET /cities
+	City             string              `json:"city"`
 	Id               *openapi_types.UUID `json:"id,omitempty"`
+	Latitude         *float64            `json:"latitude,omitempty"`
+	Longitude        *float64            `json:"longitude,omitempty"`
 	RegistrationDate *time.Time          `json:"registrationDate,omitempty"`
+
+	// Status Новый ПВЗ создаётся активным; приёмки открываются только в активных ПВЗ
+	Status *PVZStatus `json:"status,omitempty"`
+
+	// Timezone Часовой пояс IANA, по умолчанию Europe/Moscow
+	Timezone     *string         `json:"timezone,omitempty"`
+	WorkingHours *[]WorkingHours `json:"workingHours,omitempty"`
+}
+
+// PVZStatus Новый ПВЗ создаётся активным; приёмки открываются только в активных ПВЗ
+type PVZStatus string
+
+// PVZUpdate Изменяемые поля ПВЗ; отсутствующие поля не меняются
+type PVZUpdate struct {
+	Address   *string  `json:"address,omitempty"`
+	Capacity  *int     `json:"capacity,omitempty"`
+	City      *string  `json:"city,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// Status Закрыть ПВЗ можно только через POST /pvz/{pvzId}/deactivate
+	Status       *PVZUpdateStatus `json:"status,omitempty"`
+	Timezone     *string          `json:"timezone,omitempty"`
+	WorkingHours *[]WorkingHours  `json:"workingHours,omitempty"`
 }
 
-// PVZCity defines model for PVZ.City.
-type PVZCity string
+// PVZUpdateStatus Закрыть ПВЗ можно только через POST /pvz/{pvzId}/deactivate
+type PVZUpdateStatus string
 
 // Product defines model for Product.
 type Product struct {
-	DateTime    *time.Time          `json:"dateTime,omitempty"`
+	Barcode  *string    `json:"barcode,omitempty"`
+	DateTime *time.Time `json:"dateTime,omitempty"`
+
+	// Dimensions Габариты в миллиметрах
+	Dimensions  *Dimensions         `json:"dimensions,omitempty"`
 	Id          *openapi_types.UUID `json:"id,omitempty"`
 	ReceptionId openapi_types.UUID  `json:"receptionId"`
-	Type        ProductType         `json:"type"`
+	Sku         *string             `json:"sku,omitempty"`
+
+	// Type Название типа из справочника /product_types
+	Type        string `json:"type"`
+	WeightGrams *int   `json:"weightGrams,omitempty"`
+}
+
+// ProductOperation Запись журнала изменений товаров приемки
+type ProductOperation struct {
+	ApiKeyId  *openapi_types.UUID `json:"apiKeyId,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+	Id        openapi_types.UUID  `json:"id"`
+
+	// Kind undo и redo ссылаются на отмененную или повторенную операцию в targetId
+	Kind ProductOperationKind `json:"kind"`
+
+	// PreviousType Тип товара до исправления (retype)
+	PreviousType *string            `json:"previousType,omitempty"`
+	Product      Product            `json:"product"`
+	ReceptionId  openapi_types.UUID `json:"receptionId"`
+
+	// State discarded — отмененная операция, которую уже нельзя повторить
+	State    ProductOperationState `json:"state"`
+	TargetId *openapi_types.UUID   `json:"targetId,omitempty"`
+	UserId   *openapi_types.UUID   `json:"userId,omitempty"`
 }
 
-// ProductType defines model for Product.Type.
-type ProductType string
+// ProductOperationKind undo и redo ссылаются на отмененную или повторенную операцию в targetId
+type ProductOperationKind string
+
+// ProductOperationState discarded — отмененная операция, которую уже нельзя повторить
+type ProductOperationState string
+
+// ProductType defines model for ProductType.
+type ProductType struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// DeprecatedAt Тип выведен из оборота — новые товары с ним не принимаются
+	DeprecatedAt *time.Time `json:"deprecatedAt,omitempty"`
+	Fragile      bool       `json:"fragile"`
+
+	// MaxWeightGrams Предельный вес товара этого типа
+	MaxWeightGrams *int   `json:"maxWeightGrams,omitempty"`
+	Name           string `json:"name"`
+
+	// RequiresIdCheck При выдаче нужно проверить документ получателя
+	RequiresIdCheck bool `json:"requiresIdCheck"`
+}
 
 // Reception defines model for Reception.
 type Reception struct {
 	DateTime time.Time           `json:"dateTime"`
 	Id       *openapi_types.UUID `json:"id,omitempty"`
-	PvzId    openapi_types.UUID  `json:"pvzId"`
-	Status   ReceptionStatus     `json:"status"`
+
+	// Manifest Ожидаемая поставка; при закрытии приемки с ней сверяется фактический состав
+	Manifest *Manifest          `json:"manifest,omitempty"`
+	PvzId    openapi_types.UUID `json:"pvzId"`
+	Status   ReceptionStatus    `json:"status"`
 }
 
 // ReceptionStatus defines model for Reception.Status.
 type ReceptionStatus string
 
+// ReceptionReport defines model for ReceptionReport.
+type ReceptionReport struct {
+	AcceptedAt *time.Time          `json:"acceptedAt,omitempty"`
+	AcceptedBy *openapi_types.UUID `json:"acceptedBy,omitempty"`
+	Comment    *string             `json:"comment,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+
+	// Missing Недостача — получено меньше ожидаемого
+	Missing []DiscrepancyLine `json:"missing"`
+
+	// MissingBarcodes Штрихкоды из манифеста, которые не были отсканированы
+	MissingBarcodes *[]string             `json:"missingBarcodes,omitempty"`
+	PvzId           openapi_types.UUID    `json:"pvzId"`
+	ReceptionId     openapi_types.UUID    `json:"receptionId"`
+	Status          ReceptionReportStatus `json:"status"`
+	Supplier        *string               `json:"supplier,omitempty"`
+
+	// Surplus Излишки — получено больше ожидаемого
+	Surplus []DiscrepancyLine `json:"surplus"`
+
+	// Unexpected Товары типов, которых нет в манифесте
+	Unexpected []DiscrepancyLine `json:"unexpected"`
+
+	// UnexpectedBarcodes Отсканированные штрихкоды, которых нет в манифесте
+	UnexpectedBarcodes *[]string `json:"unexpectedBarcodes,omitempty"`
+}
+
+// ReceptionReportStatus defines model for ReceptionReport.Status.
+type ReceptionReportStatus string
+
 // Token defines model for Token.
 type Token = string
 
+// TokenPair defines model for TokenPair.
+type TokenPair struct {
+	RefreshToken *string `json:"refreshToken,omitempty"`
+	Token        string  `json:"token"`
+}
+
 // User defines model for User.
 type User struct {
-	Email openapi_types.Email `json:"email"`
-	Id    *openapi_types.UUID `json:"id,omitempty"`
-	Role  UserRole            `json:"role"`
+	CreatedAt  *time.Time          `json:"createdAt,omitempty"`
+	DisabledAt *time.Time          `json:"disabledAt,omitempty"`
+	Email      openapi_types.Email `json:"email"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	Role       UserRole            `json:"role"`
 }
 
 // UserRole defines model for User.Role.
 type UserRole string
 
+// WorkingHours Часы работы в один день недели по местному времени; дни без записи — выходные
+type WorkingHours struct {
+	Closes string `json:"closes"`
+	Opens  string `json:"opens"`
+
+	// Weekday День недели по ISO 8601, 1 — понедельник
+	Weekday int `json:"weekday"`
+}
+
+// PostApiKeysJSONBody defines parameters for PostApiKeys.
+type PostApiKeysJSONBody struct {
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Name      string     `json:"name"`
+
+	// Scopes Разрешения ключа, например reception:open или product:add
+	Scopes []string `json:"scopes"`
+}
+
+// PostCitiesJSONBody defines parameters for PostCities.
+type PostCitiesJSONBody struct {
+	Name string `json:"name"`
+}
+
+// PatchCitiesNameJSONBody defines parameters for PatchCitiesName.
+type PatchCitiesNameJSONBody struct {
+	Name string `json:"name"`
+}
+
 // PostDummyLoginJSONBody defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBody struct {
 	Role PostDummyLoginJSONBodyRole `json:"role"`
@@ -120,20 +478,81 @@ type PostDummyLoginJSONBody struct {
 // PostDummyLoginJSONBodyRole defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBodyRole string
 
+// PostInvitesJSONBody defines parameters for PostInvites.
+type PostInvitesJSONBody struct {
+	Email openapi_types.Email      `json:"email"`
+	Role  *PostInvitesJSONBodyRole `json:"role,omitempty"`
+}
+
+// PostInvitesJSONBodyRole defines parameters for PostInvites.
+type PostInvitesJSONBodyRole string
+
+// PostInvitesAcceptJSONBody defines parameters for PostInvitesAccept.
+type PostInvitesAcceptJSONBody struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
 // PostLoginJSONBody defines parameters for PostLogin.
 type PostLoginJSONBody struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
 }
 
+// PostLogoutJSONBody defines parameters for PostLogout.
+type PostLogoutJSONBody struct {
+	RefreshToken *string `json:"refreshToken,omitempty"`
+}
+
+// GetOidcCallbackParams defines parameters for GetOidcCallback.
+type GetOidcCallbackParams struct {
+	State            string  `form:"state" json:"state"`
+	Code             *string `form:"code,omitempty" json:"code,omitempty"`
+	Error            *string `form:"error,omitempty" json:"error,omitempty"`
+	ErrorDescription *string `form:"error_description,omitempty" json:"error_description,omitempty"`
+}
+
+// PostProductTypesJSONBody defines parameters for PostProductTypes.
+type PostProductTypesJSONBody struct {
+	Fragile         *bool  `json:"fragile,omitempty"`
+	MaxWeightGrams  *int   `json:"maxWeightGrams,omitempty"`
+	Name            string `json:"name"`
+	RequiresIdCheck *bool  `json:"requiresIdCheck,omitempty"`
+}
+
+// PatchProductTypesNameJSONBody defines parameters for PatchProductTypesName.
+type PatchProductTypesNameJSONBody struct {
+	// Deprecated Устаревший тип остается у принятых товаров, но новые с ним не принимаются
+	Deprecated *bool `json:"deprecated,omitempty"`
+	Fragile    *bool `json:"fragile,omitempty"`
+
+	// MaxWeightGrams 0 снимает ограничение веса
+	MaxWeightGrams  *int  `json:"maxWeightGrams,omitempty"`
+	RequiresIdCheck *bool `json:"requiresIdCheck,omitempty"`
+}
+
 // PostProductsJSONBody defines parameters for PostProducts.
 type PostProductsJSONBody struct {
-	PvzId openapi_types.UUID       `json:"pvzId"`
-	Type  PostProductsJSONBodyType `json:"type"`
+	// Barcode EAN-13 (проверяется контрольная цифра) или внутренний код из печатных ASCII-символов
+	Barcode *string `json:"barcode,omitempty"`
+
+	// Dimensions Габариты в миллиметрах
+	Dimensions  *Dimensions        `json:"dimensions,omitempty"`
+	PvzId       openapi_types.UUID `json:"pvzId"`
+	Sku         *string            `json:"sku,omitempty"`
+	Type        string             `json:"type"`
+	WeightGrams *int               `json:"weightGrams,omitempty"`
 }
 
-// PostProductsJSONBodyType defines parameters for PostProducts.
-type PostProductsJSONBodyType string
+// PostProductsBatchJSONBody defines parameters for PostProductsBatch.
+type PostProductsBatchJSONBody struct {
+	Mode     *PostProductsBatchJSONBodyMode `json:"mode,omitempty"`
+	Products []NewProduct                   `json:"products"`
+	PvzId    openapi_types.UUID             `json:"pvzId"`
+}
+
+// PostProductsBatchJSONBodyMode defines parameters for PostProductsBatch.
+type PostProductsBatchJSONBodyMode string
 
 // GetPvzParams defines parameters for GetPvz.
 type GetPvzParams struct {
@@ -148,11 +567,60 @@ type GetPvzParams struct {
 
 	// Limit Количество элементов на странице
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// After Курсор из заголовка X-Next-Cursor предыдущего ответа; список продолжается сразу после него без пропусков и повторов, даже если тем временем появились новые ПВЗ. Не сочетается с page > 1
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+}
+
+// GetPvzNearbyParams defines parameters for GetPvzNearby.
+type GetPvzNearbyParams struct {
+	Lat float64 `form:"lat" json:"lat"`
+	Lon float64 `form:"lon" json:"lon"`
+
+	// Radius Радиус поиска в километрах
+	Radius *float64 `form:"radius,omitempty" json:"radius,omitempty"`
+	Limit  *int     `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Open Только ПВЗ, работающие в данный момент
+	Open *bool `form:"open,omitempty" json:"open,omitempty"`
+}
+
+// PostPvzPvzIdDeleteProductJSONBody defines parameters for PostPvzPvzIdDeleteProduct.
+type PostPvzPvzIdDeleteProductJSONBody struct {
+	Barcode   *string             `json:"barcode,omitempty"`
+	ProductId *openapi_types.UUID `json:"productId,omitempty"`
+}
+
+// PostPvzPvzIdEmployeesJSONBody defines parameters for PostPvzPvzIdEmployees.
+type PostPvzPvzIdEmployeesJSONBody struct {
+	UserId openapi_types.UUID `json:"userId"`
+}
+
+// PatchPvzPvzIdProductsProductIdJSONBody defines parameters for PatchPvzPvzIdProductsProductId.
+type PatchPvzPvzIdProductsProductIdJSONBody struct {
+	Type string `json:"type"`
+}
+
+// PostPvzPvzIdRedoJSONBody defines parameters for PostPvzPvzIdRedo.
+type PostPvzPvzIdRedoJSONBody struct {
+	Steps *int `json:"steps,omitempty"`
+}
+
+// PostPvzPvzIdUndoJSONBody defines parameters for PostPvzPvzIdUndo.
+type PostPvzPvzIdUndoJSONBody struct {
+	Steps *int `json:"steps,omitempty"`
 }
 
 // PostReceptionsJSONBody defines parameters for PostReceptions.
 type PostReceptionsJSONBody struct {
-	PvzId openapi_types.UUID `json:"pvzId"`
+	// Manifest Ожидаемая поставка; при закрытии приемки с ней сверяется фактический состав
+	Manifest *Manifest          `json:"manifest,omitempty"`
+	PvzId    openapi_types.UUID `json:"pvzId"`
+}
+
+// PostReceptionsReceptionIdReportAcceptJSONBody defines parameters for PostReceptionsReceptionIdReportAccept.
+type PostReceptionsReceptionIdReportAcceptJSONBody struct {
+	Comment *string `json:"comment,omitempty"`
 }
 
 // PostRegisterJSONBody defines parameters for PostRegister.
@@ -165,53 +633,260 @@ type PostRegisterJSONBody struct {
 // PostRegisterJSONBodyRole defines parameters for PostRegister.
 type PostRegisterJSONBodyRole string
 
+// PostTokenRefreshJSONBody defines parameters for PostTokenRefresh.
+type PostTokenRefreshJSONBody struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Q Подстрока email
+	Q     *string             `form:"q,omitempty" json:"q,omitempty"`
+	Role  *GetUsersParamsRole `form:"role,omitempty" json:"role,omitempty"`
+	Page  *int                `form:"page,omitempty" json:"page,omitempty"`
+	Limit *int                `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersParamsRole defines parameters for GetUsers.
+type GetUsersParamsRole string
+
+// PostUsersUserIdResetPasswordJSONBody defines parameters for PostUsersUserIdResetPassword.
+type PostUsersUserIdResetPasswordJSONBody struct {
+	Password string `json:"password"`
+}
+
+// PostUsersUserIdRoleJSONBody defines parameters for PostUsersUserIdRole.
+type PostUsersUserIdRoleJSONBody struct {
+	Role PostUsersUserIdRoleJSONBodyRole `json:"role"`
+}
+
+// PostUsersUserIdRoleJSONBodyRole defines parameters for PostUsersUserIdRole.
+type PostUsersUserIdRoleJSONBodyRole string
+
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody PostApiKeysJSONBody
+
+// PostCitiesJSONRequestBody defines body for PostCities for application/json ContentType.
+type PostCitiesJSONRequestBody PostCitiesJSONBody
+
+// PatchCitiesNameJSONRequestBody defines body for PatchCitiesName for application/json ContentType.
+type PatchCitiesNameJSONRequestBody PatchCitiesNameJSONBody
+
 // PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
 type PostDummyLoginJSONRequestBody PostDummyLoginJSONBody
 
+// PostInvitesJSONRequestBody defines body for PostInvites for application/json ContentType.
+type PostInvitesJSONRequestBody PostInvitesJSONBody
+
+// PostInvitesAcceptJSONRequestBody defines body for PostInvitesAccept for application/json ContentType.
+type PostInvitesAcceptJSONRequestBody PostInvitesAcceptJSONBody
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PostLogoutJSONRequestBody defines body for PostLogout for application/json ContentType.
+type PostLogoutJSONRequestBody PostLogoutJSONBody
+
+// PostProductTypesJSONRequestBody defines body for PostProductTypes for application/json ContentType.
+type PostProductTypesJSONRequestBody PostProductTypesJSONBody
+
+// PatchProductTypesNameJSONRequestBody defines body for PatchProductTypesName for application/json ContentType.
+type PatchProductTypesNameJSONRequestBody PatchProductTypesNameJSONBody
+
 // PostProductsJSONRequestBody defines body for PostProducts for application/json ContentType.
 type PostProductsJSONRequestBody PostProductsJSONBody
 
+// PostProductsBatchJSONRequestBody defines body for PostProductsBatch for application/json ContentType.
+type PostProductsBatchJSONRequestBody PostProductsBatchJSONBody
+
 // PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
 type PostPvzJSONRequestBody = PVZ
 
+// PatchPvzPvzIdJSONRequestBody defines body for PatchPvzPvzId for application/json ContentType.
+type PatchPvzPvzIdJSONRequestBody = PVZUpdate
+
+// PostPvzPvzIdDeleteProductJSONRequestBody defines body for PostPvzPvzIdDeleteProduct for application/json ContentType.
+type PostPvzPvzIdDeleteProductJSONRequestBody PostPvzPvzIdDeleteProductJSONBody
+
+// PostPvzPvzIdEmployeesJSONRequestBody defines body for PostPvzPvzIdEmployees for application/json ContentType.
+type PostPvzPvzIdEmployeesJSONRequestBody PostPvzPvzIdEmployeesJSONBody
+
+// PatchPvzPvzIdProductsProductIdJSONRequestBody defines body for PatchPvzPvzIdProductsProductId for application/json ContentType.
+type PatchPvzPvzIdProductsProductIdJSONRequestBody PatchPvzPvzIdProductsProductIdJSONBody
+
+// PostPvzPvzIdRedoJSONRequestBody defines body for PostPvzPvzIdRedo for application/json ContentType.
+type PostPvzPvzIdRedoJSONRequestBody PostPvzPvzIdRedoJSONBody
+
+// PostPvzPvzIdUndoJSONRequestBody defines body for PostPvzPvzIdUndo for application/json ContentType.
+type PostPvzPvzIdUndoJSONRequestBody PostPvzPvzIdUndoJSONBody
+
 // PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
 type PostReceptionsJSONRequestBody PostReceptionsJSONBody
 
+// PostReceptionsReceptionIdReportAcceptJSONRequestBody defines body for PostReceptionsReceptionIdReportAccept for application/json ContentType.
+type PostReceptionsReceptionIdReportAcceptJSONRequestBody PostReceptionsReceptionIdReportAcceptJSONBody
+
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody PostRegisterJSONBody
 
+// PostTokenRefreshJSONRequestBody defines body for PostTokenRefresh for application/json ContentType.
+type PostTokenRefreshJSONRequestBody PostTokenRefreshJSONBody
+
+// PostUsersUserIdResetPasswordJSONRequestBody defines body for PostUsersUserIdResetPassword for application/json ContentType.
+type PostUsersUserIdResetPasswordJSONRequestBody PostUsersUserIdResetPasswordJSONBody
+
+// PostUsersUserIdRoleJSONRequestBody defines body for PostUsersUserIdRole for application/json ContentType.
+type PostUsersUserIdRoleJSONRequestBody PostUsersUserIdRoleJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Публичные ключи для проверки JWT (JWKS)
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJson(c *gin.Context)
+	// Список API-ключей (только для модераторов)
+	// (GET /api_keys)
+	GetApiKeys(c *gin.Context)
+	// Выпуск API-ключа для межсервисного доступа (только для модераторов)
+	// (POST /api_keys)
+	PostApiKeys(c *gin.Context)
+	// Отзыв API-ключа (только для модераторов)
+	// (DELETE /api_keys/{keyId})
+	DeleteApiKeysKeyId(c *gin.Context, keyId openapi_types.UUID)
+	// Ротация API-ключа; старый ключ сразу перестаёт действовать (только для модераторов)
+	// (POST /api_keys/{keyId}/rotate)
+	PostApiKeysKeyIdRotate(c *gin.Context, keyId openapi_types.UUID)
+	// Справочник городов, в которых можно открыть ПВЗ
+	// (GET /cities)
+	GetCities(c *gin.Context)
+	// Добавление города (только для модераторов)
+	// (POST /cities)
+	PostCities(c *gin.Context)
+	// Удаление города без ПВЗ (только для модераторов)
+	// (DELETE /cities/{name})
+	DeleteCitiesName(c *gin.Context, name string)
+	// Переименование города вместе с его ПВЗ (только для модераторов)
+	// (PATCH /cities/{name})
+	PatchCitiesName(c *gin.Context, name string)
 	// Получение тестового токена
 	// (POST /dummyLogin)
 	PostDummyLogin(c *gin.Context)
+	// Приглашение сотрудника по email с одноразовым токеном активации (только для модераторов)
+	// (POST /invites)
+	PostInvites(c *gin.Context)
+	// Активация приглашения и установка пароля
+	// (POST /invites/accept)
+	PostInvitesAccept(c *gin.Context)
 	// Авторизация пользователя
 	// (POST /login)
 	PostLogin(c *gin.Context)
+	// Выход из текущей сессии
+	// (POST /logout)
+	PostLogout(c *gin.Context)
+	// Выход из всех сессий пользователя
+	// (POST /logout/all)
+	PostLogoutAll(c *gin.Context)
+	// Завершение входа через SSO, выдача токенов сервиса
+	// (GET /oidc/callback)
+	GetOidcCallback(c *gin.Context, params GetOidcCallbackParams)
+	// Вход через корпоративный SSO (OpenID Connect, authorization code + PKCE)
+	// (GET /oidc/login)
+	GetOidcLogin(c *gin.Context)
+	// Справочник типов товаров, включая устаревшие
+	// (GET /product_types)
+	GetProductTypes(c *gin.Context)
+	// Добавление типа товара (только для модераторов)
+	// (POST /product_types)
+	PostProductTypes(c *gin.Context)
+	// Удаление типа, по которому не было товаров (только для модераторов)
+	// (DELETE /product_types/{name})
+	DeleteProductTypesName(c *gin.Context, name string)
+	// Изменение атрибутов типа или вывод его из оборота (только для модераторов)
+	// (PATCH /product_types/{name})
+	PatchProductTypesName(c *gin.Context, name string)
 	// Добавление товара в текущую приемку (только для сотрудников ПВЗ)
 	// (POST /products)
 	PostProducts(c *gin.Context)
+	// Пакетное добавление товаров со сканера в текущую приемку
+	// (POST /products/batch)
+	PostProductsBatch(c *gin.Context)
+	// Поиск товаров по штрихкоду, сначала самые новые
+	// (GET /products/by-barcode/{code})
+	GetProductsByBarcodeCode(c *gin.Context, code string)
 	// Получение списка ПВЗ с фильтрацией по дате приемки и пагинацией
 	// (GET /pvz)
 	GetPvz(c *gin.Context, params GetPvzParams)
 	// Создание ПВЗ (только для модераторов)
 	// (POST /pvz)
 	PostPvz(c *gin.Context)
+	// Поиск активных ПВЗ рядом с точкой, ближайшие первыми
+	// (GET /pvz/nearby)
+	GetPvzNearby(c *gin.Context, params GetPvzNearbyParams)
+	// Изменение данных ПВЗ (только для модераторов)
+	// (PATCH /pvz/{pvzId})
+	PatchPvzPvzId(c *gin.Context, pvzId openapi_types.UUID)
 	// Закрытие последней открытой приемки товаров в рамках ПВЗ
 	// (POST /pvz/{pvzId}/close_last_reception)
 	PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId openapi_types.UUID)
+	// Закрытие ПВЗ (только для модераторов)
+	// (POST /pvz/{pvzId}/deactivate)
+	PostPvzPvzIdDeactivate(c *gin.Context, pvzId openapi_types.UUID)
 	// Удаление последнего добавленного товара из текущей приемки (LIFO, только для сотрудников ПВЗ)
 	// (POST /pvz/{pvzId}/delete_last_product)
 	PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId openapi_types.UUID)
+	// Удаление любого товара текущей приемки по id или штрихкоду (только для сотрудников ПВЗ)
+	// (POST /pvz/{pvzId}/delete_product)
+	PostPvzPvzIdDeleteProduct(c *gin.Context, pvzId openapi_types.UUID)
+	// Сотрудники, закреплённые за ПВЗ
+	// (GET /pvz/{pvzId}/employees)
+	GetPvzPvzIdEmployees(c *gin.Context, pvzId openapi_types.UUID)
+	// Закрепление сотрудника за ПВЗ (только для модераторов)
+	// (POST /pvz/{pvzId}/employees)
+	PostPvzPvzIdEmployees(c *gin.Context, pvzId openapi_types.UUID)
+	// Открепление сотрудника от ПВЗ (только для модераторов)
+	// (DELETE /pvz/{pvzId}/employees/{userId})
+	DeletePvzPvzIdEmployeesUserId(c *gin.Context, pvzId openapi_types.UUID, userId openapi_types.UUID)
+	// Журнал операций с товарами текущей приемки с указанием, кто их выполнил
+	// (GET /pvz/{pvzId}/product_operations)
+	GetPvzPvzIdProductOperations(c *gin.Context, pvzId openapi_types.UUID)
+	// Исправление типа ошибочно отсканированного товара текущей приемки (только для сотрудников ПВЗ)
+	// (PATCH /pvz/{pvzId}/products/{productId})
+	PatchPvzPvzIdProductsProductId(c *gin.Context, pvzId openapi_types.UUID, productId openapi_types.UUID)
+	// Повтор последних отмененных операций в исходном порядке (только для сотрудников ПВЗ)
+	// (POST /pvz/{pvzId}/redo)
+	PostPvzPvzIdRedo(c *gin.Context, pvzId openapi_types.UUID)
+	// Отмена последних операций с товарами текущей приемки, начиная с самой новой (только для сотрудников ПВЗ)
+	// (POST /pvz/{pvzId}/undo)
+	PostPvzPvzIdUndo(c *gin.Context, pvzId openapi_types.UUID)
 	// Создание новой приемки товаров (только для сотрудников ПВЗ)
 	// (POST /receptions)
 	PostReceptions(c *gin.Context)
+	// Отчет о расхождениях закрытой приемки с манифестом
+	// (GET /receptions/{receptionId}/report)
+	GetReceptionsReceptionIdReport(c *gin.Context, receptionId openapi_types.UUID)
+	// Принятие расхождений модератором
+	// (POST /receptions/{receptionId}/report/accept)
+	PostReceptionsReceptionIdReportAccept(c *gin.Context, receptionId openapi_types.UUID)
 	// Регистрация пользователя
 	// (POST /register)
 	PostRegister(c *gin.Context)
+	// Обновление пары токенов по refresh-токену (с ротацией)
+	// (POST /token/refresh)
+	PostTokenRefresh(c *gin.Context)
+	// Список пользователей с поиском по email (только для модераторов)
+	// (GET /users)
+	GetUsers(c *gin.Context, params GetUsersParams)
+	// Блокировка учётной записи, все сессии завершаются (только для модераторов)
+	// (POST /users/{userId}/disable)
+	PostUsersUserIdDisable(c *gin.Context, userId openapi_types.UUID)
+	// Разблокировка учётной записи (только для модераторов)
+	// (POST /users/{userId}/enable)
+	PostUsersUserIdEnable(c *gin.Context, userId openapi_types.UUID)
+	// Установка нового пароля, все сессии завершаются (только для модераторов)
+	// (POST /users/{userId}/reset_password)
+	PostUsersUserIdResetPassword(c *gin.Context, userId openapi_types.UUID)
+	// Смена роли пользователя (только для модераторов)
+	// (POST /users/{userId}/role)
+	PostUsersUserIdRole(c *gin.Context, userId openapi_types.UUID)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -223,8 +898,8 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// PostDummyLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostDummyLogin(c *gin.Context) {
+// GetWellKnownJwksJson operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownJwksJson(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -233,11 +908,15 @@ func (siw *ServerInterfaceWrapper) PostDummyLogin(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostDummyLogin(c)
+	siw.Handler.GetWellKnownJwksJson(c)
 }
 
-// PostLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -246,14 +925,16 @@ func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostLogin(c)
+	siw.Handler.GetApiKeys(c)
 }
 
-// PostProducts operation middleware
-func (siw *ServerInterfaceWrapper) PostProducts(c *gin.Context) {
+// PostApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiKeys(c *gin.Context) {
 
 	c.Set(BearerAuthScopes, []string{})
 
+	c.Set(ApiKeyAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -261,51 +942,72 @@ func (siw *ServerInterfaceWrapper) PostProducts(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostProducts(c)
+	siw.Handler.PostApiKeys(c)
 }
 
-// GetPvz operation middleware
-func (siw *ServerInterfaceWrapper) GetPvz(c *gin.Context) {
+// DeleteApiKeysKeyId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiKeysKeyId(c *gin.Context) {
 
 	var err error
 
-	c.Set(BearerAuthScopes, []string{})
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPvzParams
-
-	// ------------- Optional query parameter "startDate" -------------
+	// ------------- Path parameter "keyId" -------------
+	var keyId openapi_types.UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", c.Param("keyId"), &keyId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter keyId: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "endDate" -------------
+	c.Set(BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
-		return
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	siw.Handler.DeleteApiKeysKeyId(c, keyId)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+// PostApiKeysKeyIdRotate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiKeysKeyIdRotate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", c.Param("keyId"), &keyId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter keyId: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	c.Set(BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
-		return
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
+	siw.Handler.PostApiKeysKeyIdRotate(c, keyId)
+}
+
+// GetCities operation middleware
+func (siw *ServerInterfaceWrapper) GetCities(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -313,14 +1015,16 @@ func (siw *ServerInterfaceWrapper) GetPvz(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetPvz(c, params)
+	siw.Handler.GetCities(c)
 }
 
-// PostPvz operation middleware
-func (siw *ServerInterfaceWrapper) PostPvz(c *gin.Context) {
+// PostCities operation middleware
+func (siw *ServerInterfaceWrapper) PostCities(c *gin.Context) {
 
 	c.Set(BearerAuthScopes, []string{})
 
+	c.Set(ApiKeyAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -328,25 +1032,27 @@ func (siw *ServerInterfaceWrapper) PostPvz(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostPvz(c)
+	siw.Handler.PostCities(c)
 }
 
-// PostPvzPvzIdCloseLastReception operation middleware
-func (siw *ServerInterfaceWrapper) PostPvzPvzIdCloseLastReception(c *gin.Context) {
+// DeleteCitiesName operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCitiesName(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "pvzId" -------------
-	var pvzId openapi_types.UUID
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(BearerAuthScopes, []string{})
 
+	c.Set(ApiKeyAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -354,25 +1060,27 @@ func (siw *ServerInterfaceWrapper) PostPvzPvzIdCloseLastReception(c *gin.Context
 		}
 	}
 
-	siw.Handler.PostPvzPvzIdCloseLastReception(c, pvzId)
+	siw.Handler.DeleteCitiesName(c, name)
 }
 
-// PostPvzPvzIdDeleteLastProduct operation middleware
-func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteLastProduct(c *gin.Context) {
+// PatchCitiesName operation middleware
+func (siw *ServerInterfaceWrapper) PatchCitiesName(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "pvzId" -------------
-	var pvzId openapi_types.UUID
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(BearerAuthScopes, []string{})
 
+	c.Set(ApiKeyAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -380,13 +1088,11 @@ func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteLastProduct(c *gin.Context)
 		}
 	}
 
-	siw.Handler.PostPvzPvzIdDeleteLastProduct(c, pvzId)
+	siw.Handler.PatchCitiesName(c, name)
 }
 
-// PostReceptions operation middleware
-func (siw *ServerInterfaceWrapper) PostReceptions(c *gin.Context) {
-
-	c.Set(BearerAuthScopes, []string{})
+// PostDummyLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostDummyLogin(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -395,11 +1101,15 @@ func (siw *ServerInterfaceWrapper) PostReceptions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostReceptions(c)
+	siw.Handler.PostDummyLogin(c)
 }
 
-// PostRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+// PostInvites operation middleware
+func (siw *ServerInterfaceWrapper) PostInvites(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -408,7 +1118,1063 @@ func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostRegister(c)
+	siw.Handler.PostInvites(c)
+}
+
+// PostInvitesAccept operation middleware
+func (siw *ServerInterfaceWrapper) PostInvitesAccept(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostInvitesAccept(c)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogin(c)
+}
+
+// PostLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogout(c)
+}
+
+// PostLogoutAll operation middleware
+func (siw *ServerInterfaceWrapper) PostLogoutAll(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogoutAll(c)
+}
+
+// GetOidcCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcCallback(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOidcCallbackParams
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := c.Query("state"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument state is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", c.Request.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", c.Request.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter error: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "error_description" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error_description", c.Request.URL.Query(), &params.ErrorDescription)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter error_description: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcCallback(c, params)
+}
+
+// GetOidcLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetOidcLogin(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOidcLogin(c)
+}
+
+// GetProductTypes operation middleware
+func (siw *ServerInterfaceWrapper) GetProductTypes(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProductTypes(c)
+}
+
+// PostProductTypes operation middleware
+func (siw *ServerInterfaceWrapper) PostProductTypes(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProductTypes(c)
+}
+
+// DeleteProductTypesName operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductTypesName(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteProductTypesName(c, name)
+}
+
+// PatchProductTypesName operation middleware
+func (siw *ServerInterfaceWrapper) PatchProductTypesName(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchProductTypesName(c, name)
+}
+
+// PostProducts operation middleware
+func (siw *ServerInterfaceWrapper) PostProducts(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProducts(c)
+}
+
+// PostProductsBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostProductsBatch(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProductsBatch(c)
+}
+
+// GetProductsByBarcodeCode operation middleware
+func (siw *ServerInterfaceWrapper) GetProductsByBarcodeCode(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProductsByBarcodeCode(c, code)
+}
+
+// GetPvz operation middleware
+func (siw *ServerInterfaceWrapper) GetPvz(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzParams
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", c.Request.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter after: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvz(c, params)
+}
+
+// PostPvz operation middleware
+func (siw *ServerInterfaceWrapper) PostPvz(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvz(c)
+}
+
+// GetPvzNearby operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzNearby(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzNearbyParams
+
+	// ------------- Required query parameter "lat" -------------
+
+	if paramValue := c.Query("lat"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument lat is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lat", c.Request.URL.Query(), &params.Lat)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lat: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "lon" -------------
+
+	if paramValue := c.Query("lon"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument lon is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lon", c.Request.URL.Query(), &params.Lon)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lon: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "radius" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius", c.Request.URL.Query(), &params.Radius)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter radius: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "open" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "open", c.Request.URL.Query(), &params.Open)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter open: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzNearby(c, params)
+}
+
+// PatchPvzPvzId operation middleware
+func (siw *ServerInterfaceWrapper) PatchPvzPvzId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchPvzPvzId(c, pvzId)
+}
+
+// PostPvzPvzIdCloseLastReception operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdCloseLastReception(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdCloseLastReception(c, pvzId)
+}
+
+// PostPvzPvzIdDeactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeactivate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdDeactivate(c, pvzId)
+}
+
+// PostPvzPvzIdDeleteLastProduct operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteLastProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdDeleteLastProduct(c, pvzId)
+}
+
+// PostPvzPvzIdDeleteProduct operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdDeleteProduct(c, pvzId)
+}
+
+// GetPvzPvzIdEmployees operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdEmployees(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzPvzIdEmployees(c, pvzId)
+}
+
+// PostPvzPvzIdEmployees operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdEmployees(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdEmployees(c, pvzId)
+}
+
+// DeletePvzPvzIdEmployeesUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeletePvzPvzIdEmployeesUserId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeletePvzPvzIdEmployeesUserId(c, pvzId, userId)
+}
+
+// GetPvzPvzIdProductOperations operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdProductOperations(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzPvzIdProductOperations(c, pvzId)
+}
+
+// PatchPvzPvzIdProductsProductId operation middleware
+func (siw *ServerInterfaceWrapper) PatchPvzPvzIdProductsProductId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Param("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter productId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchPvzPvzIdProductsProductId(c, pvzId, productId)
+}
+
+// PostPvzPvzIdRedo operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdRedo(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdRedo(c, pvzId)
+}
+
+// PostPvzPvzIdUndo operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdUndo(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdUndo(c, pvzId)
+}
+
+// PostReceptions operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptions(c)
+}
+
+// GetReceptionsReceptionIdReport operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionIdReport(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReceptionsReceptionIdReport(c, receptionId)
+}
+
+// PostReceptionsReceptionIdReportAccept operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptionsReceptionIdReportAccept(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptionsReceptionIdReportAccept(c, receptionId)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRegister(c)
+}
+
+// PostTokenRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostTokenRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTokenRefresh(c)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", c.Request.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter role: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsers(c, params)
+}
+
+// PostUsersUserIdDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdDisable(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUserIdDisable(c, userId)
+}
+
+// PostUsersUserIdEnable operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdEnable(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUserIdEnable(c, userId)
+}
+
+// PostUsersUserIdResetPassword operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdResetPassword(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUserIdResetPassword(c, userId)
+}
+
+// PostUsersUserIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdRole(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	c.Set(ApiKeyAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUserIdRole(c, userId)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -438,13 +2204,53 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/.well-known/jwks.json", wrapper.GetWellKnownJwksJson)
+	router.GET(options.BaseURL+"/api_keys", wrapper.GetApiKeys)
+	router.POST(options.BaseURL+"/api_keys", wrapper.PostApiKeys)
+	router.DELETE(options.BaseURL+"/api_keys/:keyId", wrapper.DeleteApiKeysKeyId)
+	router.POST(options.BaseURL+"/api_keys/:keyId/rotate", wrapper.PostApiKeysKeyIdRotate)
+	router.GET(options.BaseURL+"/cities", wrapper.GetCities)
+	router.POST(options.BaseURL+"/cities", wrapper.PostCities)
+	router.DELETE(options.BaseURL+"/cities/:name", wrapper.DeleteCitiesName)
+	router.PATCH(options.BaseURL+"/cities/:name", wrapper.PatchCitiesName)
 	router.POST(options.BaseURL+"/dummyLogin", wrapper.PostDummyLogin)
+	router.POST(options.BaseURL+"/invites", wrapper.PostInvites)
+	router.POST(options.BaseURL+"/invites/accept", wrapper.PostInvitesAccept)
 	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
+	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.POST(options.BaseURL+"/logout/all", wrapper.PostLogoutAll)
+	router.GET(options.BaseURL+"/oidc/callback", wrapper.GetOidcCallback)
+	router.GET(options.BaseURL+"/oidc/login", wrapper.GetOidcLogin)
+	router.GET(options.BaseURL+"/product_types", wrapper.GetProductTypes)
+	router.POST(options.BaseURL+"/product_types", wrapper.PostProductTypes)
+	router.DELETE(options.BaseURL+"/product_types/:name", wrapper.DeleteProductTypesName)
+	router.PATCH(options.BaseURL+"/product_types/:name", wrapper.PatchProductTypesName)
 	router.POST(options.BaseURL+"/products", wrapper.PostProducts)
+	router.POST(options.BaseURL+"/products/batch", wrapper.PostProductsBatch)
+	router.GET(options.BaseURL+"/products/by-barcode/:code", wrapper.GetProductsByBarcodeCode)
 	router.GET(options.BaseURL+"/pvz", wrapper.GetPvz)
 	router.POST(options.BaseURL+"/pvz", wrapper.PostPvz)
+	router.GET(options.BaseURL+"/pvz/nearby", wrapper.GetPvzNearby)
+	router.PATCH(options.BaseURL+"/pvz/:pvzId", wrapper.PatchPvzPvzId)
 	router.POST(options.BaseURL+"/pvz/:pvzId/close_last_reception", wrapper.PostPvzPvzIdCloseLastReception)
+	router.POST(options.BaseURL+"/pvz/:pvzId/deactivate", wrapper.PostPvzPvzIdDeactivate)
 	router.POST(options.BaseURL+"/pvz/:pvzId/delete_last_product", wrapper.PostPvzPvzIdDeleteLastProduct)
+	router.POST(options.BaseURL+"/pvz/:pvzId/delete_product", wrapper.PostPvzPvzIdDeleteProduct)
+	router.GET(options.BaseURL+"/pvz/:pvzId/employees", wrapper.GetPvzPvzIdEmployees)
+	router.POST(options.BaseURL+"/pvz/:pvzId/employees", wrapper.PostPvzPvzIdEmployees)
+	router.DELETE(options.BaseURL+"/pvz/:pvzId/employees/:userId", wrapper.DeletePvzPvzIdEmployeesUserId)
+	router.GET(options.BaseURL+"/pvz/:pvzId/product_operations", wrapper.GetPvzPvzIdProductOperations)
+	router.PATCH(options.BaseURL+"/pvz/:pvzId/products/:productId", wrapper.PatchPvzPvzIdProductsProductId)
+	router.POST(options.BaseURL+"/pvz/:pvzId/redo", wrapper.PostPvzPvzIdRedo)
+	router.POST(options.BaseURL+"/pvz/:pvzId/undo", wrapper.PostPvzPvzIdUndo)
 	router.POST(options.BaseURL+"/receptions", wrapper.PostReceptions)
+	router.GET(options.BaseURL+"/receptions/:receptionId/report", wrapper.GetReceptionsReceptionIdReport)
+	router.POST(options.BaseURL+"/receptions/:receptionId/report/accept", wrapper.PostReceptionsReceptionIdReportAccept)
 	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
+	router.POST(options.BaseURL+"/token/refresh", wrapper.PostTokenRefresh)
+	router.GET(options.BaseURL+"/users", wrapper.GetUsers)
+	router.POST(options.BaseURL+"/users/:userId/disable", wrapper.PostUsersUserIdDisable)
+	router.POST(options.BaseURL+"/users/:userId/enable", wrapper.PostUsersUserIdEnable)
+	router.POST(options.BaseURL+"/users/:userId/reset_password", wrapper.PostUsersUserIdResetPassword)
+	router.POST(options.BaseURL+"/users/:userId/role", wrapper.PostUsersUserIdRole)
 }
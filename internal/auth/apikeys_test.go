@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+type stubKeyStore struct {
+	keys    map[string]model.APIKey
+	touched []string
+}
+
+func (s *stubKeyStore) GetAPIKeyByPrefix(_ context.Context, prefix string) (model.APIKey, error) {
+	k, ok := s.keys[prefix]
+	if !ok {
+		return model.APIKey{}, e.NotFound("not found")
+	}
+	return k, nil
+}
+
+func (s *stubKeyStore) TouchAPIKey(_ context.Context, id string) error {
+	s.touched = append(s.touched, id)
+	return nil
+}
+
+func newTestKey(t *testing.T, store *stubKeyStore, scopes ...string) (string, string) {
+	key, prefix, hash, err := NewAPIKey()
+	require.NoError(t, err)
+	store.keys[prefix] = model.APIKey{ID: "k-" + prefix, Prefix: prefix, KeyHash: hash, Scopes: scopes}
+	return key, prefix
+}
+
+func TestAPIKeys_Verify(t *testing.T) {
+	store := &stubKeyStore{keys: map[string]model.APIKey{}}
+	keys := NewAPIKeys(store)
+	key, prefix := newTestKey(t, store, string(PermProductAdd))
+
+	actor, err := keys.Verify(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "k-"+prefix, actor.APIKeyID)
+	assert.Equal(t, []string{string(PermProductAdd)}, actor.Scopes)
+	assert.Empty(t, actor.UserID)
+	assert.Equal(t, []string{"k-" + prefix}, store.touched)
+
+	for _, bad := range []string{"", "garbage", "pvz_" + prefix, "pvz_deadbeef_secret", key + "x"} {
+		_, err := keys.Verify(context.Background(), bad)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, bad)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	k := store.keys[prefix]
+	k.ExpiresAt = &past
+	store.keys[prefix] = k
+	_, err = keys.Verify(context.Background(), key)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	k.ExpiresAt, k.RevokedAt = nil, &past
+	store.keys[prefix] = k
+	_, err = keys.Verify(context.Background(), key)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, err = (*APIKeys)(nil).Verify(context.Background(), key)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &stubKeyStore{keys: map[string]model.APIKey{}}
+	key, prefix := newTestKey(t, store, string(PermPVZRead))
+	router := gin.New()
+	router.Use(Middleware(newTestManager(nil), NewAPIKeys(store), nil))
+	router.Use(RequirePermissions(DefaultPermissions(), map[string]Permission{
+		"GET /pvz":  PermPVZRead,
+		"POST /pvz": PermPVZCreate,
+	}, nil))
+	router.GET("/pvz", func(c *gin.Context) {
+		assert.Equal(t, "k-"+prefix, ActorFromContext(c.Request.Context()).APIKeyID)
+		c.Status(http.StatusOK)
+	})
+	router.POST("/pvz", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	do := func(method, key string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/pvz", nil)
+		req.Header.Set("X-API-Key", key)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, do("GET", key))
+	assert.Equal(t, http.StatusForbidden, do("POST", key))
+	assert.Equal(t, http.StatusUnauthorized, do("GET", "pvz_00000000_nope"))
+}
+
+func TestUnaryInterceptor_APIKey(t *testing.T) {
+	store := &stubKeyStore{keys: map[string]model.APIKey{}}
+	key, prefix := newTestKey(t, store, string(PermPVZCreate))
+	call := func(key string) (model.Actor, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", key))
+		var got model.Actor
+		_, err := UnaryServerInterceptor(newTestManager(nil), NewAPIKeys(store), DefaultPermissions(), testRules)(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pvz.v1.PVZService/CreatePVZ"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = ActorFromContext(ctx)
+				return nil, nil
+			})
+		return got, err
+	}
+
+	actor, err := call(key)
+	require.NoError(t, err)
+	assert.Equal(t, "k-"+prefix, actor.APIKeyID)
+
+	_, err = call("pvz_00000000_nope")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	other, _ := newTestKey(t, store, string(PermPVZRead))
+	_, err = call(other)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
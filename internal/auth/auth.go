@@ -1,25 +1,171 @@
 package auth
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"pvz-backend-service/internal/model"
 	"pvz-backend-service/lib/e"
 )
 
 type Claims struct {
 	jwt.RegisteredClaims
 	Role string `json:"role"`
+	// IssuedAtMicros is iat to the microsecond, the precision of the
+	// revocation cut-off, which whole-second iat cannot be compared with.
+	IssuedAtMicros int64 `json:"iat_us,omitempty"`
 }
 
-func Middleware(secret string) gin.HandlerFunc {
+// IssuedAtTime returns the exact issue time, falling back to iat for tokens
+// without iat_us.
+func (c *Claims) IssuedAtTime() time.Time {
+	if c.IssuedAtMicros != 0 {
+		return time.UnixMicro(c.IssuedAtMicros)
+	}
+	if c.IssuedAt != nil {
+		return c.IssuedAt.Time
+	}
+	return time.Time{}
+}
+
+// RevocationChecker reports whether an otherwise valid access token has been
+// revoked, either individually by jti or by a "log out everywhere" cut-off.
+type RevocationChecker interface {
+	IsAccessTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+}
+
+type TokenManager struct {
+	keys       *KeySet
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	revocation RevocationChecker
+}
+
+func NewTokenManager(keys *KeySet, accessTTL, refreshTTL time.Duration, rc RevocationChecker) *TokenManager {
+	return &TokenManager{keys: keys, accessTTL: accessTTL, refreshTTL: refreshTTL, revocation: rc}
+}
+
+func (m *TokenManager) IssueAccessToken(userID, role string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTTL)),
+		},
+		Role:           role,
+		IssuedAtMicros: now.UnixMicro(),
+	}
+	k := m.keys.signing
+	t := jwt.NewWithClaims(k.Method, claims)
+	if k.ID != "" {
+		t.Header["kid"] = k.ID
+	}
+	s, err := t.SignedString(k.sign)
+	return s, e.WrapIfErr("token generation failed", err)
+}
+
+func (m *TokenManager) ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, m.keys.keyFunc, jwt.WithValidMethods(m.keys.algorithms()))
+	if err != nil {
+		return nil, e.Wrap("invalid token", err)
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+func (m *TokenManager) JWKS() JWKS {
+	return m.keys.JWKS()
+}
+
+// Verify parses the token and rejects it if it has been revoked.
+func (m *TokenManager) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := m.ParseToken(tokenString)
+	if err != nil || m.revocation == nil {
+		return claims, err
+	}
+	revoked, err := m.revocation.IsAccessTokenRevoked(ctx, claims.ID, claims.Subject, claims.IssuedAtTime())
+	if err != nil {
+		return nil, e.Wrap("revocation check failed", err)
+	}
+	if revoked {
+		return nil, errors.New("token revoked")
+	}
+	return claims, nil
+}
+
+func (m *TokenManager) NewRefreshToken() (token, hash string, expiresAt time.Time, err error) {
+	token, hash, err = NewOpaqueToken()
+	return token, hash, time.Now().Add(m.refreshTTL), err
+}
+
+// NewOpaqueToken returns a random token for the client and the hash that is
+// stored server-side.
+func NewOpaqueToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", e.Wrap("token generation failed", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func ActorFromClaims(c *Claims) model.Actor {
+	a := model.Actor{UserID: c.Subject, Role: c.Role, TokenID: c.ID}
+	if c.ExpiresAt != nil {
+		a.TokenExpiresAt = c.ExpiresAt.Time
+	}
+	return a
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, a model.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+func ActorFromContext(ctx context.Context) model.Actor {
+	a, _ := ctx.Value(actorKey{}).(model.Actor)
+	return a
+}
+
+// Middleware authenticates requests by bearer token or, for service
+// integrations, by an X-API-Key header.
+func Middleware(tm *TokenManager, keys *APIKeys, public map[string]bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		switch c.FullPath() {
-		case "/dummyLogin", "/register", "/login":
+		if public[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		if key := c.GetHeader("X-API-Key"); key != "" {
+			a, err := keys.Verify(c.Request.Context(), key)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+				return
+			}
+			c.Request = c.Request.WithContext(WithActor(c.Request.Context(), a))
 			c.Next()
 			return
 		}
@@ -29,28 +175,20 @@ func Middleware(secret string) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
 			return
 		}
-		tokenString := strings.TrimPrefix(auth, "Bearer ")
-
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
+		claims, err := tm.Verify(c.Request.Context(), strings.TrimPrefix(auth, "Bearer "))
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
 			return
 		}
 
-		c.Set("user_id", claims.Subject)
-		c.Set("role", claims.Role)
+		c.Request = c.Request.WithContext(WithActor(c.Request.Context(), ActorFromClaims(claims)))
 		c.Next()
 	}
 }
 
-func GenerateToken(userID, role, secret string) (string, error) {
-	claims := Claims{jwt.RegisteredClaims{Subject: userID, ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour))}, role}
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	s, err := t.SignedString([]byte(secret))
-	return s, e.WrapIfErr("token generation failed", err)
+// GinActor returns the actor the middleware stored in the request context.
+func GinActor(c *gin.Context) model.Actor {
+	return ActorFromContext(c.Request.Context())
 }
 
 func HashPassword(p string) (string, error) {
@@ -62,3 +200,14 @@ func CheckPassword(hash, p string) error {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(p))
 	return e.WrapIfErr("password mismatch", err)
 }
+
+var dummyHash = sync.OnceValue(func() []byte {
+	h, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	return h
+})
+
+// SimulatePasswordCheck costs as much as CheckPassword, so unknown accounts
+// cannot be told apart from wrong passwords by response time.
+func SimulatePasswordCheck(p string) {
+	_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(p))
+}
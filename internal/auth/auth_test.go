@@ -1,17 +1,33 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
+type stubRevocation struct {
+	revoked map[string]bool
+	err     error
+}
+
+func (s *stubRevocation) IsAccessTokenRevoked(_ context.Context, jti, _ string, _ time.Time) (bool, error) {
+	return s.revoked[jti], s.err
+}
+
+func newTestManager(rc RevocationChecker) *TokenManager {
+	return NewTokenManager(NewHMACKeySet("secret"), time.Minute, time.Hour, rc)
+}
+
 func TestGenerateTokenAndClaims(t *testing.T) {
-	token, err := GenerateToken("user1", "employee", "secret")
+	token, err := newTestManager(nil).IssueAccessToken("user1", "employee")
 	assert.NoError(t, err)
 
 	parsed, err := jwt.ParseWithClaims(token, &Claims{}, func(token *jwt.Token) (interface{}, error) {
@@ -23,6 +39,38 @@ func TestGenerateTokenAndClaims(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "user1", claims.Subject)
 	assert.Equal(t, "employee", claims.Role)
+	assert.NotEmpty(t, claims.ID)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), claims.ExpiresAt.Time, 2*time.Second)
+	assert.Equal(t, claims.IssuedAt.Unix(), claims.IssuedAtTime().Unix())
+	assert.Equal(t, claims.IssuedAtMicros, claims.IssuedAtTime().UnixMicro())
+
+	claims.IssuedAtMicros = 0
+	assert.Equal(t, claims.IssuedAt.Time, claims.IssuedAtTime(), "tokens without iat_us fall back to iat")
+}
+
+func TestVerify_Revoked(t *testing.T) {
+	rc := &stubRevocation{revoked: map[string]bool{}}
+	tm := newTestManager(rc)
+	token, _ := tm.IssueAccessToken("user1", "employee")
+	claims, err := tm.Verify(context.Background(), token)
+	assert.NoError(t, err)
+
+	rc.revoked[claims.ID] = true
+	_, err = tm.Verify(context.Background(), token)
+	assert.EqualError(t, err, "token revoked")
+
+	rc.err = errors.New("db down")
+	_, err = tm.Verify(context.Background(), token)
+	assert.ErrorContains(t, err, "revocation check failed")
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	tok, hash, exp, err := newTestManager(nil).NewRefreshToken()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tok)
+	assert.Equal(t, HashToken(tok), hash)
+	assert.NotEqual(t, tok, hash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), exp, 2*time.Second)
 }
 
 func TestHashAndCheckPassword(t *testing.T) {
@@ -38,14 +86,23 @@ func TestHashAndCheckPassword(t *testing.T) {
 
 func TestAuthMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
+	rc := &stubRevocation{revoked: map[string]bool{}}
+	tm := newTestManager(rc)
 	router := gin.New()
-	router.Use(Middleware("secret"))
+	router.Use(Middleware(tm, nil, map[string]bool{"/public": true}))
 	router.GET("/protected", func(c *gin.Context) {
+		assert.Equal(t, "u2", ActorFromContext(c.Request.Context()).UserID)
 		c.Status(http.StatusOK)
 	})
+	router.GET("/public", func(c *gin.Context) { c.Status(http.StatusOK) })
 
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/protected", nil)
+	req := httptest.NewRequest("GET", "/public", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/protected", nil)
 	router.ServeHTTP(w, req)
 	assert.Equal(t, 401, w.Code)
 
@@ -55,7 +112,7 @@ func TestAuthMiddleware(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, 401, w.Code)
 
-	validToken, err := GenerateToken("u2", "moderator", "secret")
+	validToken, err := tm.IssueAccessToken("u2", "moderator")
 	assert.NoError(t, err)
 
 	w = httptest.NewRecorder()
@@ -63,4 +120,12 @@ func TestAuthMiddleware(t *testing.T) {
 	req.Header.Set("Authorization", "Bearer "+validToken)
 	router.ServeHTTP(w, req)
 	assert.Equal(t, 200, w.Code)
+
+	claims, _ := tm.ParseToken(validToken)
+	rc.revoked[claims.ID] = true
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+validToken)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, 401, w.Code)
 }
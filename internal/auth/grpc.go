@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"pvz-backend-service/internal/model"
+)
+
+type GRPCRules struct {
+	Public      map[string]bool
+	Permissions map[string]Permission
+}
+
+func UnaryServerInterceptor(tm *TokenManager, keys *APIKeys, perms *Permissions, rules GRPCRules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorizeGRPC(ctx, info.FullMethod, tm, keys, perms, rules)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func StreamServerInterceptor(tm *TokenManager, keys *APIKeys, perms *Permissions, rules GRPCRules) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorizeGRPC(ss.Context(), info.FullMethod, tm, keys, perms, rules)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &actorStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
+
+func authorizeGRPC(ctx context.Context, method string, tm *TokenManager, keys *APIKeys, perms *Permissions, rules GRPCRules) (context.Context, error) {
+	if rules.Public[method] {
+		return ctx, nil
+	}
+	a, err := authenticateGRPC(ctx, tm, keys)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if perm, ok := rules.Permissions[method]; ok && !perms.ActorAllowed(a, perm) {
+		return nil, status.Errorf(codes.PermissionDenied, "access forbidden: %s permission required", perm)
+	}
+	return WithActor(ctx, a), nil
+}
+
+func authenticateGRPC(ctx context.Context, tm *TokenManager, keys *APIKeys) (model.Actor, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if key := md.Get("x-api-key"); len(key) > 0 {
+		return keys.Verify(ctx, key[0])
+	}
+	var tokenString string
+	for _, v := range md.Get("authorization") {
+		if strings.HasPrefix(v, "Bearer ") {
+			tokenString = strings.TrimPrefix(v, "Bearer ")
+			break
+		}
+	}
+	if tokenString == "" {
+		return model.Actor{}, errors.New("missing token")
+	}
+	claims, err := tm.Verify(ctx, tokenString)
+	if err != nil {
+		return model.Actor{}, err
+	}
+	return ActorFromClaims(claims), nil
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"pvz-backend-service/internal/model"
+)
+
+var testRules = GRPCRules{
+	Public:      map[string]bool{"/pvz.v1.PVZService/Login": true},
+	Permissions: map[string]Permission{"/pvz.v1.PVZService/CreatePVZ": PermPVZCreate},
+}
+
+func callUnary(t *testing.T, method, token string) (model.Actor, error) {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	var got model.Actor
+	_, err := UnaryServerInterceptor(newTestManager(nil), nil, DefaultPermissions(), testRules)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = ActorFromContext(ctx)
+			return nil, nil
+		})
+	return got, err
+}
+
+func TestUnaryInterceptor_Public(t *testing.T) {
+	_, err := callUnary(t, "/pvz.v1.PVZService/Login", "")
+	assert.NoError(t, err)
+}
+
+func TestUnaryInterceptor_Unauthenticated(t *testing.T) {
+	_, err := callUnary(t, "/pvz.v1.PVZService/ListPVZ", "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = callUnary(t, "/pvz.v1.PVZService/ListPVZ", "garbage")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUnaryInterceptor_Permissions(t *testing.T) {
+	emp, _ := newTestManager(nil).IssueAccessToken("u1", "employee")
+	aud, _ := newTestManager(nil).IssueAccessToken("u3", "auditor")
+	mod, _ := newTestManager(nil).IssueAccessToken("u2", "moderator")
+	adm, _ := newTestManager(nil).IssueAccessToken("u4", "admin")
+
+	_, err := callUnary(t, "/pvz.v1.PVZService/CreatePVZ", emp)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callUnary(t, "/pvz.v1.PVZService/CreatePVZ", aud)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callUnary(t, "/pvz.v1.PVZService/CreatePVZ", adm)
+	assert.NoError(t, err)
+
+	id, err := callUnary(t, "/pvz.v1.PVZService/CreatePVZ", mod)
+	assert.NoError(t, err)
+	assert.Equal(t, "u2", id.UserID)
+	assert.Equal(t, "moderator", id.Role)
+
+	id, err = callUnary(t, "/pvz.v1.PVZService/ListPVZ", emp)
+	assert.NoError(t, err)
+	assert.Equal(t, "employee", id.Role)
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeStream) Context() context.Context { return f.ctx }
+
+func TestStreamInterceptor(t *testing.T) {
+	tok, _ := newTestManager(nil).IssueAccessToken("u1", "employee")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tok))
+	var got model.Actor
+	err := StreamServerInterceptor(newTestManager(nil), nil, DefaultPermissions(), testRules)(nil, &fakeStream{ctx: ctx},
+		&grpc.StreamServerInfo{FullMethod: "/pvz.v1.PVZService/ListPVZ"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			got = ActorFromContext(ss.Context())
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", got.UserID)
+
+	err = StreamServerInterceptor(newTestManager(nil), nil, DefaultPermissions(), testRules)(nil, &fakeStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/pvz.v1.PVZService/ListPVZ"},
+		func(srv interface{}, ss grpc.ServerStream) error { return nil })
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+	"pvz-backend-service/lib/e"
+)
+
+const minRSABits = 2048
+
+// Key is a single JWT key. Asymmetric keys are identified by their RFC 7638
+// thumbprint, which is sent as the kid header and published in the JWKS.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+	sign   interface{}
+	verify interface{}
+}
+
+// KeySet holds the key used to sign new tokens and every key tokens are still
+// accepted from. Old keys stay in the set for rotation until their tokens expire.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+func NewKeySet(signing *Key, verify ...*Key) (*KeySet, error) {
+	if signing == nil || signing.sign == nil {
+		return nil, errors.New("signing key must be a private key")
+	}
+	ks := &KeySet{signing: signing, keys: map[string]*Key{signing.ID: signing}}
+	for _, k := range verify {
+		if _, ok := ks.keys[k.ID]; ok {
+			continue
+		}
+		if (k.ID == "") != (signing.ID == "") {
+			return nil, errors.New("cannot mix HMAC and asymmetric keys")
+		}
+		ks.keys[k.ID] = k
+	}
+	return ks, nil
+}
+
+// NewHMACKeySet is the legacy shared-secret setup. Tokens carry no kid and the
+// set publishes no JWKS.
+func NewHMACKeySet(secret string) *KeySet {
+	k := &Key{Method: jwt.SigningMethodHS256, sign: []byte(secret), verify: []byte(secret)}
+	return &KeySet{signing: k, keys: map[string]*Key{"": k}}
+}
+
+// LoadKeySet reads a PEM private signing key and any number of PEM keys
+// (public or private) that remain valid for verification.
+func LoadKeySet(signingFile string, verifyFiles []string) (*KeySet, error) {
+	signing, err := loadKey(signingFile)
+	if err != nil {
+		return nil, err
+	}
+	var verify []*Key
+	for _, f := range verifyFiles {
+		k, err := loadKey(f)
+		if err != nil {
+			return nil, err
+		}
+		verify = append(verify, k)
+	}
+	return NewKeySet(signing, verify...)
+}
+
+func loadKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, e.Wrap("read key "+path, err)
+	}
+	k, err := ParseKey(data)
+	return k, e.WrapIfErr("parse key "+path, err)
+}
+
+// ParseKey accepts PKCS#8/PKCS#1 private keys and PKIX public keys holding RSA
+// (RS256) or Ed25519 (EdDSA) material.
+func ParseKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	var (
+		raw interface{}
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		raw, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		raw, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Key{}
+	switch key := raw.(type) {
+	case *rsa.PrivateKey:
+		k.Method, k.sign, k.verify = jwt.SigningMethodRS256, key, &key.PublicKey
+	case *rsa.PublicKey:
+		k.Method, k.verify = jwt.SigningMethodRS256, key
+	case ed25519.PrivateKey:
+		k.Method, k.sign, k.verify = jwt.SigningMethodEdDSA, key, key.Public()
+	case ed25519.PublicKey:
+		k.Method, k.verify = jwt.SigningMethodEdDSA, key
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", raw)
+	}
+	if pub, ok := k.verify.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSABits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+	}
+	k.ID = thumbprint(k.verify)
+	return k, nil
+}
+
+// keyFunc resolves the verification key by kid and pins the algorithm to the
+// one the key was loaded with, so a token cannot pick its own verification
+// method (e.g. HS256 signed with an RSA public key).
+func (ks *KeySet) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	k, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if t.Method.Alg() != k.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q", t.Method.Alg())
+	}
+	return k.verify, nil
+}
+
+func (ks *KeySet) algorithms() []string {
+	seen := map[string]bool{}
+	var algs []string
+	for _, k := range ks.keys {
+		if alg := k.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	sort.Strings(algs)
+	return algs
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public halves of all asymmetric keys, signing key first.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		if id != "" && id != ks.signing.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if ks.signing.ID != "" {
+		ids = append([]string{ks.signing.ID}, ids...)
+	}
+	for _, id := range ids {
+		k := ks.keys[id]
+		jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Method.Alg()}
+		switch pub := k.verify.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty, jwk.N, jwk.E = "RSA", b64(pub.N.Bytes()), b64(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty, jwk.Crv, jwk.X = "OKP", "Ed25519", b64(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint: sha256 over the required
+// members in lexicographic order.
+func thumbprint(pub crypto.PublicKey) string {
+	var canonical string
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, b64(big.NewInt(int64(p.E)).Bytes()), b64(p.N.Bytes()))
+	case ed25519.PublicKey:
+		canonical = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, b64(p))
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:])
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pemPrivate(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func pemPublic(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newEdKey(t *testing.T) (*Key, ed25519.PublicKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k, err := ParseKey(pemPrivate(t, priv))
+	require.NoError(t, err)
+	return k, pub
+}
+
+func newRSAKey(t *testing.T) (*Key, *rsa.PublicKey) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	k, err := ParseKey(pemPrivate(t, priv))
+	require.NoError(t, err)
+	return k, &priv.PublicKey
+}
+
+func managerFor(t *testing.T, signing *Key, verify ...*Key) *TokenManager {
+	ks, err := NewKeySet(signing, verify...)
+	require.NoError(t, err)
+	return NewTokenManager(ks, time.Minute, time.Hour, nil)
+}
+
+func TestAsymmetricSignAndVerify(t *testing.T) {
+	for name, newKey := range map[string]func(*testing.T) *Key{
+		"EdDSA": func(t *testing.T) *Key { k, _ := newEdKey(t); return k },
+		"RS256": func(t *testing.T) *Key { k, _ := newRSAKey(t); return k },
+	} {
+		t.Run(name, func(t *testing.T) {
+			k := newKey(t)
+			tm := managerFor(t, k)
+			tok, err := tm.IssueAccessToken("u1", "employee")
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(tok, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, k.ID, parsed.Header["kid"])
+			assert.Equal(t, name, parsed.Header["alg"])
+
+			claims, err := tm.ParseToken(tok)
+			require.NoError(t, err)
+			assert.Equal(t, "u1", claims.Subject)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, oldPub := newEdKey(t)
+	newKey, _ := newRSAKey(t)
+	oldTok, _ := managerFor(t, oldKey).IssueAccessToken("u1", "employee")
+
+	verifyOnly, err := ParseKey(pemPublic(t, oldPub))
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID, verifyOnly.ID)
+
+	rotated := managerFor(t, newKey, verifyOnly)
+	_, err = rotated.ParseToken(oldTok)
+	assert.NoError(t, err)
+
+	_, err = managerFor(t, newKey).ParseToken(oldTok)
+	assert.Error(t, err, "token from a retired key must be rejected")
+}
+
+func TestAlgorithmConfusion(t *testing.T) {
+	k, pub := newRSAKey(t)
+	tm := managerFor(t, k)
+
+	claims := Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "u1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}, Role: "moderator"}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	forged.Header["kid"] = k.ID
+	tok, err := forged.SignedString(pemPublic(t, pub))
+	require.NoError(t, err)
+	_, err = tm.ParseToken(tok)
+	assert.Error(t, err)
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	none.Header["kid"] = k.ID
+	tok, _ = none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	_, err = tm.ParseToken(tok)
+	assert.Error(t, err)
+
+	hmacTok, _ := newTestManager(nil).IssueAccessToken("u1", "moderator")
+	_, err = tm.ParseToken(hmacTok)
+	assert.Error(t, err, "token without kid must be rejected by an asymmetric key set")
+}
+
+func TestNewKeySet_Errors(t *testing.T) {
+	_, pub := newEdKey(t)
+	public, _ := ParseKey(pemPublic(t, pub))
+	_, err := NewKeySet(public)
+	assert.Error(t, err)
+
+	k, _ := newEdKey(t)
+	_, err = NewKeySet(k, NewHMACKeySet("secret").signing)
+	assert.Error(t, err)
+
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	_, err = ParseKey(pemPrivate(t, small))
+	assert.Error(t, err)
+
+	_, err = ParseKey([]byte("not a pem"))
+	assert.Error(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	ed, edPub := newEdKey(t)
+	rs, _ := newRSAKey(t)
+	ks, err := NewKeySet(ed, rs)
+	require.NoError(t, err)
+
+	set := ks.JWKS()
+	require.Len(t, set.Keys, 2)
+	assert.Equal(t, JWK{Kty: "OKP", Kid: ed.ID, Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: b64(edPub)}, set.Keys[0])
+	assert.Equal(t, "RSA", set.Keys[1].Kty)
+	assert.Equal(t, "AQAB", set.Keys[1].E)
+
+	assert.Empty(t, NewHMACKeySet("secret").JWKS().Keys)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	oldPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	signing := filepath.Join(dir, "signing.pem")
+	old := filepath.Join(dir, "old.pem")
+	require.NoError(t, os.WriteFile(signing, pemPrivate(t, priv), 0o600))
+	require.NoError(t, os.WriteFile(old, pemPublic(t, oldPub), 0o600))
+
+	ks, err := LoadKeySet(signing, []string{old})
+	require.NoError(t, err)
+	assert.Len(t, ks.JWKS().Keys, 2)
+
+	_, err = LoadKeySet(filepath.Join(dir, "missing.pem"), nil)
+	assert.Error(t, err)
+}
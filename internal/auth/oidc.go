@@ -0,0 +1,353 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"pvz-backend-service/lib/e"
+)
+
+// jwksMinRefresh limits how often a token signed with an unknown kid can make
+// us refetch the provider's keys.
+const jwksMinRefresh = 10 * time.Second
+
+var oidcAlgorithms = []string{"RS256", "RS384", "RS512", "EdDSA"}
+
+type RoleMapping struct {
+	Value string
+	Role  string
+}
+
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// RoleClaim is the ID token claim holding group or role names; a dotted
+	// path such as realm_access.roles reaches into nested objects. The first
+	// RoleMapping whose Value the claim contains decides the local role.
+	RoleClaim    string
+	RoleMapping  []RoleMapping
+	DefaultRole  string
+	JWKSCacheTTL time.Duration
+	HTTPClient   *http.Client
+}
+
+// OIDCIdentity is the verified result of an SSO login. Role is empty when no
+// mapping matched and there is no default role.
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Role          string
+}
+
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCProvider runs the authorization code flow with PKCE against an OpenID
+// Connect provider. Discovery happens on first use; signing keys are cached
+// for JWKSCacheTTL and refetched early when a token names an unknown kid.
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	meta        *oidcMetadata
+	keys        map[string]*Key
+	keysFetched time.Time
+}
+
+func NewOIDCProvider(cfg OIDCConfig) *OIDCProvider {
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.JWKSCacheTTL == 0 {
+		cfg.JWKSCacheTTL = time.Hour
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCProvider{cfg: cfg, client: client}
+}
+
+// NewPKCE returns a code verifier and its S256 challenge.
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, _, err = NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, b64(sum[:]), nil
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, challenge string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange redeems an authorization code and verifies the returned ID token.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, verifier, nonce string) (OIDCIdentity, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return OIDCIdentity{}, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return OIDCIdentity{}, e.Wrap("oidc token request", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	var tok struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &tok)
+	if err != nil {
+		return OIDCIdentity{}, e.Wrap("oidc token request", err)
+	}
+	if status != http.StatusOK {
+		if tok.Error != "" {
+			return OIDCIdentity{}, e.Unauthorized("sso login failed: %s %s", tok.Error, tok.ErrorDescription)
+		}
+		return OIDCIdentity{}, e.Wrap("oidc token request", fmt.Errorf("unexpected status %d", status))
+	}
+	if tok.IDToken == "" {
+		return OIDCIdentity{}, e.Unauthorized("sso login failed: no id_token in token response")
+	}
+	return p.verifyIDToken(ctx, meta.Issuer, tok.IDToken, nonce)
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, issuer, raw, nonce string) (OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims,
+		func(t *jwt.Token) (interface{}, error) { return p.key(ctx, t) },
+		jwt.WithValidMethods(oidcAlgorithms),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return OIDCIdentity{}, e.Unauthorized("sso login failed: invalid id_token: %v", err)
+	}
+	if got, _ := claims["nonce"].(string); got == "" || got != nonce {
+		return OIDCIdentity{}, e.Unauthorized("sso login failed: id_token nonce mismatch")
+	}
+	sub, _ := claims.GetSubject()
+	if sub == "" {
+		return OIDCIdentity{}, e.Unauthorized("sso login failed: id_token has no subject")
+	}
+	id := OIDCIdentity{Issuer: p.cfg.Issuer, Subject: sub}
+	id.Email, _ = claims["email"].(string)
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		id.EmailVerified = v
+	case string:
+		id.EmailVerified = v == "true"
+	}
+	id.Role = p.mapRole(claims)
+	return id, nil
+}
+
+func (p *OIDCProvider) mapRole(claims jwt.MapClaims) string {
+	var v any = map[string]any(claims)
+	for _, part := range strings.Split(p.cfg.RoleClaim, ".") {
+		m, _ := v.(map[string]any)
+		v = m[part]
+	}
+	var values []string
+	switch v := v.(type) {
+	case string:
+		values = strings.Fields(v)
+	case []any:
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	for _, m := range p.cfg.RoleMapping {
+		if slices.Contains(values, m.Value) {
+			return m.Role
+		}
+	}
+	return p.cfg.DefaultRole
+}
+
+func (p *OIDCProvider) metadata(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, e.Wrap("oidc discovery", err)
+	}
+	var meta oidcMetadata
+	status, err := p.doJSON(req, &meta)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("unexpected status %d", status)
+	}
+	if err != nil {
+		return nil, e.Wrap("oidc discovery", err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != p.cfg.Issuer {
+		return nil, e.Wrap("oidc discovery", fmt.Errorf("issuer %q does not match %q", meta.Issuer, p.cfg.Issuer))
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, e.Wrap("oidc discovery", errors.New("incomplete provider metadata"))
+	}
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// key resolves the ID token signing key and, like KeySet.keyFunc, pins the
+// algorithm to the key type.
+func (p *OIDCProvider) key(ctx context.Context, t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k, ok := p.keys[kid]
+	stale := time.Since(p.keysFetched) > p.cfg.JWKSCacheTTL
+	if stale || (!ok && time.Since(p.keysFetched) > jwksMinRefresh) {
+		if err := p.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		k, ok = p.keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if t.Method.Alg() != k.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q", t.Method.Alg())
+	}
+	return k.verify, nil
+}
+
+// fetchKeys must be called with p.mu held and metadata loaded.
+func (p *OIDCProvider) fetchKeys(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.meta.JWKSURI, nil)
+	if err != nil {
+		return err
+	}
+	var set JWKS
+	status, err := p.doJSON(req, &set)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("unexpected status %d", status)
+	}
+	if err != nil {
+		return fmt.Errorf("fetch provider keys: %w", err)
+	}
+	keys := map[string]*Key{}
+	for _, jwk := range set.Keys {
+		if k, err := parseJWK(jwk); err == nil {
+			keys[k.ID] = k
+		}
+	}
+	p.keys, p.keysFetched = keys, time.Now()
+	return nil
+}
+
+func parseJWK(jwk JWK) (*Key, error) {
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return nil, errors.New("not a signing key")
+	}
+	k := &Key{ID: jwk.Kid}
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		exp, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(exp).Int64())}
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		k.Method, k.verify = jwt.SigningMethodRS256, pub
+		if m := jwt.GetSigningMethod(jwk.Alg); m != nil && strings.HasPrefix(jwk.Alg, "RS") {
+			k.Method = m
+		}
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("unsupported OKP key")
+		}
+		k.Method, k.verify = jwt.SigningMethodEdDSA, ed25519.PublicKey(x)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+	return k, nil
+}
+
+func (p *OIDCProvider) doJSON(req *http.Request, v any) (int, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, err
+	}
+	return resp.StatusCode, nil
+}
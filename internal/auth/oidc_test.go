@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/auth/oidctest"
+	"pvz-backend-service/lib/e"
+)
+
+func newTestOIDC(t *testing.T) (*oidctest.Provider, *OIDCProvider) {
+	idp := oidctest.New(t, "pvz", "client-secret")
+	p := NewOIDCProvider(OIDCConfig{
+		Issuer:       idp.URL,
+		ClientID:     "pvz",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://pvz.example/oidc/callback",
+		RoleClaim:    "groups",
+		RoleMapping:  []RoleMapping{{"pvz-moderators", RoleModerator}, {"pvz-staff", RoleEmployee}},
+	})
+	return idp, p
+}
+
+func oidcLogin(t *testing.T, idp *oidctest.Provider, p *OIDCProvider, claims map[string]any) (OIDCIdentity, error) {
+	verifier, challenge, err := NewPKCE()
+	require.NoError(t, err)
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", challenge)
+	require.NoError(t, err)
+	code, state, err := idp.Authorize(authURL, claims)
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", state)
+	return p.Exchange(context.Background(), code, verifier, "nonce-1")
+}
+
+func TestOIDC_Login(t *testing.T) {
+	idp, p := newTestOIDC(t)
+	id, err := oidcLogin(t, idp, p, map[string]any{
+		"sub":            "alice",
+		"email":          "Alice@corp.ru",
+		"email_verified": true,
+		"groups":         []string{"everyone", "pvz-staff", "pvz-moderators"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, OIDCIdentity{Issuer: idp.URL, Subject: "alice", Email: "Alice@corp.ru", EmailVerified: true, Role: RoleModerator}, id)
+
+	id, err = oidcLogin(t, idp, p, map[string]any{"groups": []string{"everyone"}})
+	require.NoError(t, err)
+	assert.Empty(t, id.Role)
+	assert.Equal(t, 1, idp.JWKSRequests(), "keys are cached between logins")
+}
+
+func TestOIDC_Rejects(t *testing.T) {
+	idp, p := newTestOIDC(t)
+	ctx := context.Background()
+	_, challenge, _ := NewPKCE()
+
+	authURL, err := p.AuthCodeURL(ctx, "s", "nonce-1", challenge)
+	require.NoError(t, err)
+	code, _, err := idp.Authorize(authURL, nil)
+	require.NoError(t, err)
+	_, err = p.Exchange(ctx, code, "wrong-verifier", "nonce-1")
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err), "PKCE verifier must match")
+
+	verifier, challenge, _ := NewPKCE()
+	authURL, _ = p.AuthCodeURL(ctx, "s", "nonce-1", challenge)
+	code, _, _ = idp.Authorize(authURL, nil)
+	_, err = p.Exchange(ctx, code, verifier, "other-nonce")
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err), "nonce must match")
+
+	_, err = p.Exchange(ctx, code, verifier, "nonce-1")
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err), "codes are single use")
+
+	_, err = oidcLogin(t, idp, p, map[string]any{"aud": "someone-else"})
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err))
+	_, err = oidcLogin(t, idp, p, map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err))
+}
+
+func TestOIDC_KeyRotation(t *testing.T) {
+	idp, p := newTestOIDC(t)
+	_, err := oidcLogin(t, idp, p, nil)
+	require.NoError(t, err)
+
+	idp.RotateKey(t)
+	_, err = oidcLogin(t, idp, p, nil)
+	assert.Error(t, err, "unknown kid right after a fetch is not refetched")
+
+	p.keysFetched = time.Now().Add(-time.Minute)
+	_, err = oidcLogin(t, idp, p, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, idp.JWKSRequests())
+}
+
+func TestOIDC_MapRole(t *testing.T) {
+	p := NewOIDCProvider(OIDCConfig{
+		RoleClaim:   "realm_access.roles",
+		RoleMapping: []RoleMapping{{"admins", RoleAdmin}},
+		DefaultRole: RoleEmployee,
+	})
+	assert.Equal(t, RoleAdmin, p.mapRole(jwt.MapClaims{"realm_access": map[string]any{"roles": []any{"admins"}}}))
+	assert.Equal(t, RoleEmployee, p.mapRole(jwt.MapClaims{"realm_access": map[string]any{"roles": []any{"users"}}}))
+	assert.Equal(t, RoleEmployee, p.mapRole(jwt.MapClaims{}))
+
+	p.cfg.RoleClaim = "role"
+	assert.Equal(t, RoleAdmin, p.mapRole(jwt.MapClaims{"role": "admins"}))
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"pvz-backend-service/lib/e"
+)
+
+// maxPasswordBytes is the bcrypt input limit; longer passwords are rejected
+// instead of being truncated.
+const maxPasswordBytes = 72
+
+type PasswordPolicy struct {
+	MinLength  int
+	MinClasses int
+	Breached   map[string]struct{}
+}
+
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8, MinClasses: 2}
+
+// LoadBreachedPasswords reads a newline-separated list of known leaked
+// passwords. Entries are matched case-insensitively.
+func LoadBreachedPasswords(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	defer f.Close()
+
+	list := map[string]struct{}{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if p := strings.TrimSpace(sc.Text()); p != "" {
+			list[strings.ToLower(p)] = struct{}{}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read breached password list: %w", err)
+	}
+	return list, nil
+}
+
+// Validate returns a validation error naming every rule the password breaks.
+func (p PasswordPolicy) Validate(password string) error {
+	var failed []string
+	if len([]rune(password)) < p.MinLength {
+		failed = append(failed, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		failed = append(failed, fmt.Sprintf("must not exceed %d bytes", maxPasswordBytes))
+	}
+	if p.MinClasses > 0 && charClasses(password) < p.MinClasses {
+		failed = append(failed, fmt.Sprintf("must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", p.MinClasses))
+	}
+	if _, ok := p.Breached[strings.ToLower(password)]; ok {
+		failed = append(failed, "must not be a commonly used or breached password")
+	}
+	if len(failed) > 0 {
+		return e.Validation("password %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func charClasses(s string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/lib/e"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8, MinClasses: 3, Breached: map[string]struct{}{"password1!": {}}}
+	cases := []struct {
+		password string
+		rule     string
+	}{
+		{"Sh0rt!", "at least 8 characters"},
+		{"lowercaseonly", "at least 3 of"},
+		{"Password1!", "breached"},
+		{strings.Repeat("Aa1!", 19), "72 bytes"},
+		{"Correct-horse-1", ""},
+		{"Пароль-надёжный", ""},
+	}
+	for _, tc := range cases {
+		err := policy.Validate(tc.password)
+		if tc.rule == "" {
+			assert.NoError(t, err, tc.password)
+			continue
+		}
+		assert.Equal(t, e.KindValidation, e.KindOf(err), tc.password)
+		assert.ErrorContains(t, err, tc.rule, tc.password)
+	}
+
+	err := policy.Validate("abc")
+	assert.ErrorContains(t, err, "at least 8 characters")
+	assert.ErrorContains(t, err, "at least 3 of")
+}
+
+func TestLoadBreachedPasswords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte("123456\n\n  Qwerty123 \n"), 0o600))
+	list, err := LoadBreachedPasswords(path)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Error(t, PasswordPolicy{Breached: list}.Validate("QWERTY123"))
+
+	_, err = LoadBreachedPasswords(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+type Permission string
+
+// PermPVZAll lifts the restriction to PVZs the user is assigned to;
+// PermUserAdmin marks roles that only their peers may grant or manage.
+const (
+	PermPVZRead         Permission = "pvz:read"
+	PermPVZCreate       Permission = "pvz:create"
+	PermPVZManage       Permission = "pvz:manage"
+	PermPVZAssign       Permission = "pvz:assign"
+	PermPVZAll          Permission = "pvz:all"
+	PermCityManage      Permission = "city:manage"
+	PermCatalogManage   Permission = "catalog:manage"
+	PermReceptionOpen   Permission = "reception:open"
+	PermReceptionClose  Permission = "reception:close"
+	PermReceptionAccept Permission = "reception:accept"
+	PermProductAdd      Permission = "product:add"
+	PermProductDelete   Permission = "product:delete"
+	PermUserRead        Permission = "user:read"
+	PermUserManage      Permission = "user:manage"
+	PermUserInvite      Permission = "user:invite"
+	PermUserAdmin       Permission = "user:admin"
+	PermAPIKeyManage    Permission = "apikey:manage"
+)
+
+// PermAuthenticated marks routes that need nothing beyond a valid token.
+const PermAuthenticated Permission = ""
+
+var AllPermissions = []Permission{
+	PermPVZRead, PermPVZCreate, PermPVZManage, PermPVZAssign, PermPVZAll, PermCityManage, PermCatalogManage,
+	PermReceptionOpen, PermReceptionClose, PermReceptionAccept,
+	PermProductAdd, PermProductDelete,
+	PermUserRead, PermUserManage, PermUserInvite, PermUserAdmin,
+	PermAPIKeyManage,
+}
+
+// Roles are fixed by the users.role CHECK constraint; configuration only
+// changes which permissions each of them holds.
+const (
+	RoleEmployee  = "employee"
+	RoleModerator = "moderator"
+	RoleAuditor   = "auditor"
+	RoleAdmin     = "admin"
+)
+
+var Roles = []string{RoleEmployee, RoleModerator, RoleAuditor, RoleAdmin}
+
+var DefaultRolePermissions = map[string][]Permission{
+	RoleEmployee:  {PermPVZRead, PermReceptionOpen, PermReceptionClose, PermProductAdd, PermProductDelete},
+	RoleModerator: {PermPVZRead, PermPVZCreate, PermPVZManage, PermPVZAssign, PermCityManage, PermCatalogManage, PermReceptionAccept, PermUserRead, PermUserManage, PermUserInvite, PermAPIKeyManage},
+	RoleAuditor:   {PermPVZRead, PermUserRead},
+	RoleAdmin:     AllPermissions,
+}
+
+type Permissions struct {
+	roles map[string]map[Permission]bool
+}
+
+func NewPermissions(roles map[string][]Permission) (*Permissions, error) {
+	p := &Permissions{roles: map[string]map[Permission]bool{}}
+	for _, role := range Roles {
+		p.roles[role] = map[Permission]bool{}
+	}
+	for role, perms := range roles {
+		set, ok := p.roles[role]
+		if !ok {
+			return nil, fmt.Errorf("unknown role %q (want one of %s)", role, strings.Join(Roles, ", "))
+		}
+		for _, perm := range perms {
+			if !slices.Contains(AllPermissions, perm) {
+				return nil, fmt.Errorf("role %q: unknown permission %q", role, perm)
+			}
+			set[perm] = true
+		}
+	}
+	return p, nil
+}
+
+func DefaultPermissions() *Permissions {
+	p, err := NewPermissions(DefaultRolePermissions)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// LoadPermissions reads a JSON object mapping roles to permission lists.
+// Roles missing from the file keep their default permissions.
+func LoadPermissions(path string) (*Permissions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read role permissions: %w", err)
+	}
+	var roles map[string][]Permission
+	if err := json.Unmarshal(data, &roles); err != nil {
+		return nil, fmt.Errorf("parse role permissions: %w", err)
+	}
+	merged := map[string][]Permission{}
+	for role, perms := range DefaultRolePermissions {
+		merged[role] = perms
+	}
+	for role, perms := range roles {
+		merged[role] = perms
+	}
+	return NewPermissions(merged)
+}
+
+func (p *Permissions) IsRole(role string) bool {
+	_, ok := p.roles[role]
+	return ok
+}
+
+func (p *Permissions) Allowed(role string, perm Permission) bool {
+	return p.roles[role][perm]
+}
+
+// ActorAllowed checks an API key against its scopes and a user against the
+// permissions of their role.
+func (p *Permissions) ActorAllowed(a model.Actor, perm Permission) bool {
+	if a.APIKeyID != "" {
+		return slices.Contains(a.Scopes, string(perm))
+	}
+	return p.Allowed(a.Role, perm)
+}
+
+func (p *Permissions) Require(a model.Actor, perm Permission) error {
+	if !p.ActorAllowed(a, perm) {
+		return e.Forbidden("access forbidden: %s permission required", perm)
+	}
+	return nil
+}
+
+// CanGrant reports whether role holds every user management permission of
+// target, so that nobody can hand out or manage accounts more privileged than
+// their own.
+func (p *Permissions) CanGrant(role, target string) bool {
+	for perm := range p.roles[target] {
+		if strings.HasPrefix(string(perm), "user:") && !p.Allowed(role, perm) {
+			return false
+		}
+	}
+	return true
+}
+
+// RequirePermissions enforces per-route permissions keyed by "METHOD /path",
+// where path is the gin route pattern. Authenticated routes without a rule are
+// denied, so token-only routes must be listed with PermAuthenticated. It must
+// run after Middleware with the same public paths.
+func RequirePermissions(p *Permissions, rules map[string]Permission, public map[string]bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if path == "" || public[path] {
+			c.Next()
+			return
+		}
+		perm, ok := rules[c.Request.Method+" "+path]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "access forbidden"})
+			return
+		}
+		if perm != PermAuthenticated {
+			if err := p.Require(GinActor(c), perm); err != nil {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": e.Message(err)})
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func TestDefaultPermissions(t *testing.T) {
+	p := DefaultPermissions()
+	assert.True(t, p.Allowed(RoleModerator, PermPVZCreate))
+	assert.False(t, p.Allowed(RoleEmployee, PermPVZCreate))
+	assert.True(t, p.Allowed(RoleEmployee, PermProductDelete))
+	assert.True(t, p.Allowed(RoleAuditor, PermPVZRead))
+	assert.False(t, p.Allowed(RoleAuditor, PermReceptionOpen))
+	assert.False(t, p.Allowed("unknown", PermPVZRead))
+	for _, perm := range AllPermissions {
+		assert.True(t, p.Allowed(RoleAdmin, perm), perm)
+	}
+	assert.Equal(t, e.KindForbidden, e.KindOf(p.Require(model.Actor{Role: RoleAuditor}, PermUserManage)))
+	assert.NoError(t, p.Require(model.Actor{Role: RoleAuditor}, PermUserRead))
+}
+
+func TestPermissions_APIKeyScopes(t *testing.T) {
+	p := DefaultPermissions()
+	key := model.Actor{APIKeyID: "k1", Role: RoleAdmin, Scopes: []string{string(PermProductAdd)}}
+	assert.True(t, p.ActorAllowed(key, PermProductAdd))
+	assert.False(t, p.ActorAllowed(key, PermPVZRead), "scopes replace role permissions")
+}
+
+func TestPermissions_CanGrant(t *testing.T) {
+	p := DefaultPermissions()
+	assert.True(t, p.CanGrant(RoleModerator, RoleEmployee))
+	assert.True(t, p.CanGrant(RoleModerator, RoleModerator))
+	assert.True(t, p.CanGrant(RoleModerator, RoleAuditor))
+	assert.False(t, p.CanGrant(RoleModerator, RoleAdmin))
+	assert.True(t, p.CanGrant(RoleAdmin, RoleAdmin))
+}
+
+func TestNewPermissions_Errors(t *testing.T) {
+	_, err := NewPermissions(map[string][]Permission{"root": {PermPVZRead}})
+	assert.ErrorContains(t, err, "unknown role")
+	_, err = NewPermissions(map[string][]Permission{RoleAuditor: {"pvz:delete"}})
+	assert.ErrorContains(t, err, "unknown permission")
+}
+
+func TestLoadPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"auditor": ["pvz:read"]}`), 0o600))
+	p, err := LoadPermissions(path)
+	require.NoError(t, err)
+	assert.False(t, p.Allowed(RoleAuditor, PermUserRead))
+	assert.True(t, p.Allowed(RoleModerator, PermUserRead))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"auditor": "pvz:read"}`), 0o600))
+	_, err = LoadPermissions(path)
+	assert.Error(t, err)
+}
+
+func TestRequirePermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithActor(c.Request.Context(), model.Actor{Role: c.GetHeader("X-Role")}))
+	})
+	router.Use(RequirePermissions(DefaultPermissions(), map[string]Permission{
+		"POST /pvz":              PermPVZCreate,
+		"POST /pvz/:pvzId/close": PermReceptionClose,
+		"POST /logout":           PermAuthenticated,
+	}, map[string]bool{"/login": true}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.POST("/pvz", ok)
+	router.GET("/pvz", ok)
+	router.POST("/pvz/:pvzId/close", ok)
+	router.POST("/logout", ok)
+	router.POST("/login", ok)
+
+	cases := []struct {
+		method, path, role string
+		want               int
+	}{
+		{"POST", "/pvz", RoleModerator, http.StatusOK},
+		{"POST", "/pvz", RoleAuditor, http.StatusForbidden},
+		{"GET", "/pvz", RoleAdmin, http.StatusForbidden},
+		{"POST", "/logout", RoleAuditor, http.StatusOK},
+		{"POST", "/login", "", http.StatusOK},
+		{"GET", "/missing", RoleAdmin, http.StatusNotFound},
+		{"POST", "/pvz/p1/close", RoleEmployee, http.StatusOK},
+		{"POST", "/pvz/p1/close", RoleModerator, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("X-Role", tc.role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tc.want, w.Code, "%s %s as %s", tc.method, tc.path, tc.role)
+	}
+}
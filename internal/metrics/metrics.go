@@ -29,10 +29,15 @@ var (
 	PvzCreated = prometheus.NewCounter(
 		prometheus.CounterOpts{Name: "pvz_created_total"},
 	)
+
+	LoginFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "login_failures_total"},
+		[]string{"reason"},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(httpRequests, httpDuration, PvzCreated, ProductsAdded, ReceptionCreated)
+	prometheus.MustRegister(httpRequests, httpDuration, PvzCreated, ProductsAdded, ReceptionCreated, LoginFailures)
 }
 
 func Middleware() gin.HandlerFunc {
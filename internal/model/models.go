@@ -2,17 +2,141 @@ package model
 
 import "time"
 
+// Actor is the caller of an operation: a user with a role, or an API key
+// limited to its Scopes.
+type Actor struct {
+	UserID         string
+	Role           string
+	TokenID        string
+	TokenExpiresAt time.Time
+	APIKeyID       string
+	Scopes         []string
+}
+
+type TokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	TokenHash  string
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *string
+}
+
+// OIDCLogin is a pending SSO login, found by the hash of its state parameter.
+type OIDCLogin struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
 type User struct {
-	ID           string
-	Email        string
-	PasswordHash string
-	Role         string
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	Role         string     `json:"role"`
+	CreatedAt    time.Time  `json:"createdAt,omitempty"`
+	DisabledAt   *time.Time `json:"disabledAt,omitempty"`
+}
+
+type UserFilter struct {
+	Query  string
+	Role   string
+	Limit  int
+	Offset int
+}
+
+type Invite struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	TokenHash  string     `json:"-"`
+	InvitedBy  string     `json:"invitedBy"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+}
+
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"createdBy,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+type City struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ProductType struct {
+	Name            string     `json:"name"`
+	Fragile         bool       `json:"fragile"`
+	RequiresIDCheck bool       `json:"requiresIdCheck"`
+	MaxWeightGrams  *int       `json:"maxWeightGrams,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	DeprecatedAt    *time.Time `json:"deprecatedAt,omitempty"`
+}
+
+// ProductTypeUpdate holds the attributes to change; a zero MaxWeightGrams
+// removes the limit.
+type ProductTypeUpdate struct {
+	Fragile         *bool `json:"fragile"`
+	RequiresIDCheck *bool `json:"requiresIdCheck"`
+	MaxWeightGrams  *int  `json:"maxWeightGrams"`
+	Deprecated      *bool `json:"deprecated"`
 }
 
 type PVZ struct {
-	ID               string    `json:"id"`
-	City             string    `json:"city"`
-	RegistrationDate time.Time `json:"registrationDate,omitempty"`
+	ID               string         `json:"id"`
+	City             string         `json:"city"`
+	RegistrationDate time.Time      `json:"registrationDate,omitempty"`
+	Address          string         `json:"address,omitempty"`
+	Latitude         *float64       `json:"latitude,omitempty"`
+	Longitude        *float64       `json:"longitude,omitempty"`
+	Timezone         string         `json:"timezone,omitempty"`
+	WorkingHours     []WorkingHours `json:"workingHours,omitempty"`
+	Capacity         *int           `json:"capacity,omitempty"`
+	Status           string         `json:"status,omitempty"`
+}
+
+// NearbyPVZ is a PVZ found by a geo search, with its great-circle distance
+// from the search point and whether it is open at the time of the search.
+type NearbyPVZ struct {
+	PVZ
+	DistanceKm float64 `json:"distanceKm"`
+	OpenNow    bool    `json:"openNow"`
+}
+
+// WorkingHours is the local "HH:MM" schedule for one ISO weekday, 1 being
+// Monday. Weekdays without an entry are days off.
+type WorkingHours struct {
+	Weekday int    `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+// PVZUpdate holds the fields to change on a PVZ; nil fields are kept.
+type PVZUpdate struct {
+	City         *string         `json:"city"`
+	Address      *string         `json:"address"`
+	Latitude     *float64        `json:"latitude"`
+	Longitude    *float64        `json:"longitude"`
+	Timezone     *string         `json:"timezone"`
+	WorkingHours *[]WorkingHours `json:"workingHours"`
+	Capacity     *int            `json:"capacity"`
+	Status       *string         `json:"status"`
 }
 
 type Reception struct {
@@ -20,11 +144,118 @@ type Reception struct {
 	DateTime time.Time `json:"dateTime,omitempty"`
 	PVZID    string    `json:"pvzId"`
 	Status   string    `json:"status"`
+	Manifest *Manifest `json:"manifest,omitempty"`
+}
+
+// Manifest lists what a reception is expected to receive: counts per
+// product type, specific barcodes, or both.
+type Manifest struct {
+	Supplier string         `json:"supplier,omitempty"`
+	Items    []ManifestItem `json:"items,omitempty"`
+	Barcodes []string       `json:"barcodes,omitempty"`
+}
+
+type ManifestItem struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+type DiscrepancyLine struct {
+	Type     string `json:"type"`
+	Expected int    `json:"expected"`
+	Received int    `json:"received"`
+}
+
+// ReceptionReport compares what a closed reception received with its
+// manifest. Unexpected lines are product types the manifest did not list;
+// barcodes are only compared when the manifest lists them.
+type ReceptionReport struct {
+	ReceptionID        string            `json:"receptionId"`
+	PVZID              string            `json:"pvzId"`
+	Supplier           string            `json:"supplier,omitempty"`
+	Status             string            `json:"status"`
+	Missing            []DiscrepancyLine `json:"missing"`
+	Surplus            []DiscrepancyLine `json:"surplus"`
+	Unexpected         []DiscrepancyLine `json:"unexpected"`
+	MissingBarcodes    []string          `json:"missingBarcodes"`
+	UnexpectedBarcodes []string          `json:"unexpectedBarcodes"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	AcceptedBy         *string           `json:"acceptedBy,omitempty"`
+	AcceptedAt         *time.Time        `json:"acceptedAt,omitempty"`
+	Comment            string            `json:"comment,omitempty"`
 }
 
 type Product struct {
-	ID          string    `json:"id"`
-	DateTime    time.Time `json:"dateTime,omitempty"`
-	Type        string    `json:"type"`
-	ReceptionID string    `json:"receptionId"`
+	ID          string      `json:"id"`
+	DateTime    time.Time   `json:"dateTime,omitempty"`
+	Type        string      `json:"type"`
+	ReceptionID string      `json:"receptionId"`
+	Barcode     string      `json:"barcode,omitempty"`
+	SKU         string      `json:"sku,omitempty"`
+	WeightGrams *int        `json:"weightGrams,omitempty"`
+	Dimensions  *Dimensions `json:"dimensions,omitempty"`
+	// Seq orders products by scan; it is not part of the API.
+	Seq int64 `json:"-"`
+}
+
+// BatchItem is the outcome of one product of a batch, by its position in
+// the request.
+type BatchItem struct {
+	Index   int      `json:"index"`
+	Status  string   `json:"status"`
+	Product *Product `json:"product,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type BatchResult struct {
+	Added  int         `json:"added"`
+	Failed int         `json:"failed"`
+	Items  []BatchItem `json:"items"`
+}
+
+// ProductOperation is an entry of the change log of a reception's products.
+// Product is the product after an add or retype and the removed one for a
+// delete; undo and redo entries refer to the operation they act on.
+type ProductOperation struct {
+	ID           string    `json:"id"`
+	ReceptionID  string    `json:"receptionId"`
+	Kind         string    `json:"kind"`
+	Product      Product   `json:"product"`
+	PreviousType string    `json:"previousType,omitempty"`
+	TargetID     string    `json:"targetId,omitempty"`
+	State        string    `json:"state"`
+	UserID       string    `json:"userId,omitempty"`
+	APIKeyID     string    `json:"apiKeyId,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type Dimensions struct {
+	LengthMm int `json:"lengthMm"`
+	WidthMm  int `json:"widthMm"`
+	HeightMm int `json:"heightMm"`
+}
+
+type ReceptionWithProducts struct {
+	Reception Reception `json:"reception"`
+	Products  []Product `json:"products"`
+}
+
+type PVZWithReceptions struct {
+	PVZ        PVZ                     `json:"pvz"`
+	Receptions []ReceptionWithProducts `json:"receptions"`
+}
+
+// PVZCursor is the position of the last PVZ of a page in the
+// (registration_date DESC, id DESC) order; listing resumes right after it.
+type PVZCursor struct {
+	RegistrationDate time.Time
+	ID               string
+}
+
+type PVZFilter struct {
+	Start  string
+	End    string
+	Limit  int
+	Offset int
+	After  *PVZCursor
 }
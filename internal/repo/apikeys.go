@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+const apiKeyColumns = "id,name,prefix,key_hash,scopes,created_by,created_at,expires_at,last_used_at,revoked_at"
+
+func scanAPIKey(row interface{ Scan(...any) error }) (model.APIKey, error) {
+	var k model.APIKey
+	var createdBy *string
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes, &createdBy, &k.CreatedAt, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt)
+	if createdBy != nil {
+		k.CreatedBy = *createdBy
+	}
+	return k, err
+}
+
+func (r *repo) CreateAPIKey(ctx context.Context, k model.APIKey) (model.APIKey, error) {
+	k, err := scanAPIKey(r.db.QueryRow(ctx,
+		"INSERT INTO api_keys (id,name,prefix,key_hash,scopes,created_by,expires_at) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "+apiKeyColumns,
+		k.ID, k.Name, k.Prefix, k.KeyHash, k.Scopes, nullIfEmpty(k.CreatedBy), k.ExpiresAt,
+	))
+	return k, mapErr("create api key", err)
+}
+
+func (r *repo) GetAPIKey(ctx context.Context, id string) (model.APIKey, error) {
+	k, err := scanAPIKey(r.db.QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id=$1 FOR UPDATE", id))
+	return k, mapErr("get api key", err)
+}
+
+func (r *repo) GetAPIKeyByPrefix(ctx context.Context, prefix string) (model.APIKey, error) {
+	k, err := scanAPIKey(r.db.QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE prefix=$1", prefix))
+	return k, mapErr("get api key", err)
+}
+
+func (r *repo) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY created_at DESC, id")
+	if err != nil {
+		return nil, mapErr("list api keys", err)
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, mapErr("scan api key", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, mapErr("list api keys", rows.Err())
+}
+
+func (r *repo) RevokeAPIKey(ctx context.Context, id, replacedBy string) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE api_keys SET revoked_at=now(), replaced_by=$2 WHERE id=$1 AND revoked_at IS NULL",
+		id, nullIfEmpty(replacedBy),
+	)
+	if err != nil {
+		return mapErr("revoke api key", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("revoke api key: not found")
+	}
+	return nil
+}
+
+// TouchAPIKey records use of a key, at most once a minute to keep hot keys
+// from turning every request into a write.
+func (r *repo) TouchAPIKey(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE api_keys SET last_used_at=now() WHERE id=$1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')",
+		id,
+	)
+	return mapErr("touch api key", err)
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+var apiKeyRowColumns = []string{"id", "name", "prefix", "key_hash", "scopes", "created_by", "created_at", "expires_at", "last_used_at", "revoked_at"}
+
+func TestCreateAPIKey(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	now := time.Now()
+	by := "m1"
+	scopes := []string{"reception:open", "product:add"}
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"INSERT INTO api_keys (id,name,prefix,key_hash,scopes,created_by,expires_at) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "+apiKeyColumns,
+	)).
+		WithArgs("k1", "erp", "abcd1234", "hash", scopes, &by, (*time.Time)(nil)).
+		WillReturnRows(pgxmock.NewRows(apiKeyRowColumns).
+			AddRow("k1", "erp", "abcd1234", "hash", scopes, &by, now, nil, nil, nil))
+
+	k, err := r.CreateAPIKey(context.Background(), model.APIKey{
+		ID: "k1", Name: "erp", Prefix: "abcd1234", KeyHash: "hash", Scopes: scopes, CreatedBy: "m1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "m1", k.CreatedBy)
+	assert.Equal(t, scopes, k.Scopes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeyByPrefix_NotFound(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + apiKeyColumns + " FROM api_keys WHERE prefix=$1")).
+		WithArgs("abcd1234").
+		WillReturnRows(pgxmock.NewRows(apiKeyRowColumns))
+
+	_, err := r.GetAPIKeyByPrefix(context.Background(), "abcd1234")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	replacement := "k2"
+	query := regexp.QuoteMeta("UPDATE api_keys SET revoked_at=now(), replaced_by=$2 WHERE id=$1 AND revoked_at IS NULL")
+	mock.ExpectExec(query).
+		WithArgs("k1", &replacement).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(query).
+		WithArgs("k1", (*string)(nil)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	assert.NoError(t, r.RevokeAPIKey(context.Background(), "k1", "k2"))
+	assert.True(t, e.IsKind(r.RevokeAPIKey(context.Background(), "k1", ""), e.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
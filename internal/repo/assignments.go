@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func (r *repo) AssignEmployee(ctx context.Context, pvzID, userID, assignedBy string) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO pvz_assignments (pvz_id,user_id,assigned_by) VALUES ($1,$2,$3) ON CONFLICT (pvz_id,user_id) DO NOTHING",
+		pvzID, userID, nullIfEmpty(assignedBy),
+	)
+	return mapErr("assign employee", err)
+}
+
+func (r *repo) UnassignEmployee(ctx context.Context, pvzID, userID string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM pvz_assignments WHERE pvz_id=$1 AND user_id=$2", pvzID, userID)
+	if err != nil {
+		return mapErr("unassign employee", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("unassign employee: not found")
+	}
+	return nil
+}
+
+func (r *repo) ListPVZEmployees(ctx context.Context, pvzID string) ([]model.User, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT u.id,u.email,u.password_hash,u.role,u.created_at,u.disabled_at
+        FROM pvz_assignments a JOIN users u ON u.id = a.user_id
+        WHERE a.pvz_id=$1
+        ORDER BY u.email`,
+		pvzID,
+	)
+	if err != nil {
+		return nil, mapErr("list pvz employees", err)
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, mapErr("scan user", err)
+		}
+		users = append(users, u)
+	}
+	return users, mapErr("list pvz employees", rows.Err())
+}
+
+func (r *repo) IsAssigned(ctx context.Context, userID, pvzID string) (bool, error) {
+	var assigned bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM pvz_assignments WHERE user_id=$1 AND pvz_id=$2)",
+		userID, pvzID,
+	).Scan(&assigned)
+	return assigned, mapErr("check pvz assignment", err)
+}
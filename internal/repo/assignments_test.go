@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/lib/e"
+)
+
+func TestAssignEmployee(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	by := "m1"
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO pvz_assignments (pvz_id,user_id,assigned_by) VALUES ($1,$2,$3) ON CONFLICT (pvz_id,user_id) DO NOTHING",
+	)).
+		WithArgs("p1", "u1", &by).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	assert.NoError(t, r.AssignEmployee(context.Background(), "p1", "u1", "m1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnassignEmployee_NotFound(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM pvz_assignments WHERE pvz_id=$1 AND user_id=$2")).
+		WithArgs("p1", "u1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	assert.True(t, e.IsKind(r.UnassignEmployee(context.Background(), "p1", "u1"), e.KindNotFound))
+}
+
+func TestIsAssigned(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM pvz_assignments WHERE user_id=$1 AND pvz_id=$2)")).
+		WithArgs("u1", "p1").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	ok, err := r.IsAssigned(context.Background(), "u1", "p1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
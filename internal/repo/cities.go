@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func (r *repo) ListCities(ctx context.Context) ([]model.City, error) {
+	rows, err := r.db.Query(ctx, "SELECT name,created_at FROM cities ORDER BY name")
+	if err != nil {
+		return nil, mapErr("list cities", err)
+	}
+	defer rows.Close()
+
+	cities := []model.City{}
+	for rows.Next() {
+		var c model.City
+		if err := rows.Scan(&c.Name, &c.CreatedAt); err != nil {
+			return nil, mapErr("scan city", err)
+		}
+		cities = append(cities, c)
+	}
+	return cities, mapErr("list cities", rows.Err())
+}
+
+func (r *repo) CreateCity(ctx context.Context, name string) (model.City, error) {
+	c := model.City{Name: name}
+	err := r.db.QueryRow(ctx, "INSERT INTO cities (name) VALUES ($1) RETURNING created_at", name).Scan(&c.CreatedAt)
+	return c, mapErr("create city", err)
+}
+
+// RenameCity relies on ON UPDATE CASCADE to carry existing PVZs along.
+func (r *repo) RenameCity(ctx context.Context, name, newName string) (model.City, error) {
+	c := model.City{Name: newName}
+	err := r.db.QueryRow(ctx, "UPDATE cities SET name=$2 WHERE name=$1 RETURNING created_at", name, newName).Scan(&c.CreatedAt)
+	return c, mapErr("rename city", err)
+}
+
+func (r *repo) DeleteCity(ctx context.Context, name string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM cities WHERE name=$1", name)
+	if err != nil {
+		return mapErr("delete city", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("delete city: not found")
+	}
+	return nil
+}
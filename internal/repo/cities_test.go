@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/lib/e"
+)
+
+func TestListCities(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name,created_at FROM cities ORDER BY name")).
+		WillReturnRows(pgxmock.NewRows([]string{"name", "created_at"}).
+			AddRow("Казань", time.Now()).
+			AddRow("Москва", time.Now()))
+
+	cities, err := r.ListCities(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, cities, 2)
+	assert.Equal(t, "Казань", cities[0].Name)
+}
+
+func TestCreateCity_Duplicate(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO cities (name) VALUES ($1) RETURNING created_at")).
+		WithArgs("Казань").
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	_, err := r.CreateCity(context.Background(), "Казань")
+	assert.True(t, e.IsKind(err, e.KindConflict))
+}
+
+func TestRenameCity(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE cities SET name=$2 WHERE name=$1 RETURNING created_at")).
+		WithArgs("Питер", "Санкт-Петербург").
+		WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+	c, err := r.RenameCity(context.Background(), "Питер", "Санкт-Петербург")
+	assert.NoError(t, err)
+	assert.Equal(t, "Санкт-Петербург", c.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCity(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cities WHERE name=$1")).
+		WithArgs("Казань").
+		WillReturnError(&pgconn.PgError{Code: "23503", Detail: `Key (name)=(Казань) is still referenced from table "pvz".`})
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cities WHERE name=$1")).
+		WithArgs("Тверь").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	assert.True(t, e.IsKind(r.DeleteCity(context.Background(), "Казань"), e.KindPrecondition))
+	assert.True(t, e.IsKind(r.DeleteCity(context.Background(), "Тверь"), e.KindNotFound))
+}
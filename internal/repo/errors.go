@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"pvz-backend-service/lib/e"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgInvalidTextRepr     = "22P02"
+)
+
+// mapErr translates driver errors into domain error kinds, keeping the
+// original error as the cause.
+func mapErr(msg string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e.New(e.KindNotFound, msg+": not found", err)
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return e.Wrap(msg, err)
+	}
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return e.New(e.KindConflict, msg+": already exists", err)
+	case pgForeignKeyViolation:
+		if strings.Contains(pgErr.Detail, "is not present") {
+			return e.New(e.KindNotFound, msg+": referenced record not found", err)
+		}
+		return e.New(e.KindPrecondition, msg+": record is still referenced", err)
+	case pgCheckViolation:
+		return e.New(e.KindValidation, msg+": invalid value", err)
+	case pgInvalidTextRepr:
+		return e.New(e.KindValidation, msg+": malformed value", err)
+	}
+	return e.Wrap(msg, err)
+}
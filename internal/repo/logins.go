@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"time"
+)
+
+// RecordLoginFailure bumps the failure counter for key and returns it. A
+// counter with no failure or lockout after since starts over.
+func (r *repo) RecordLoginFailure(ctx context.Context, key string, since time.Time) (int, error) {
+	if _, err := r.db.Exec(ctx,
+		"DELETE FROM login_failures WHERE GREATEST(last_failure_at, locked_until) < $1",
+		since,
+	); err != nil {
+		return 0, mapErr("purge login failures", err)
+	}
+	var failures int
+	err := r.db.QueryRow(ctx, `
+        INSERT INTO login_failures (key, failures, last_failure_at) VALUES ($1, 1, now())
+        ON CONFLICT (key) DO UPDATE SET
+            failures = CASE WHEN GREATEST(login_failures.last_failure_at, login_failures.locked_until) < $2
+                THEN 1 ELSE login_failures.failures + 1 END,
+            last_failure_at = now()
+        RETURNING failures`,
+		key, since,
+	).Scan(&failures)
+	return failures, mapErr("record login failure", err)
+}
+
+func (r *repo) LockLogin(ctx context.Context, key string, until time.Time) error {
+	_, err := r.db.Exec(ctx, "UPDATE login_failures SET locked_until=$2 WHERE key=$1", key, until)
+	return mapErr("lock login", err)
+}
+
+// LoginLockedUntil returns the latest lockout among keys, or the zero time.
+func (r *repo) LoginLockedUntil(ctx context.Context, keys []string) (time.Time, error) {
+	var until *time.Time
+	err := r.db.QueryRow(ctx,
+		"SELECT MAX(locked_until) FROM login_failures WHERE key = ANY($1) AND locked_until > now()",
+		keys,
+	).Scan(&until)
+	if err != nil || until == nil {
+		return time.Time{}, mapErr("check login lock", err)
+	}
+	return *until, nil
+}
+
+func (r *repo) ClearLoginFailures(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM login_failures WHERE key=$1", key)
+	return mapErr("clear login failures", err)
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordLoginFailure(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	since := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM login_failures WHERE GREATEST(last_failure_at, locked_until) < $1")).
+		WithArgs(since).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO login_failures")).
+		WithArgs("email:a@b", since).
+		WillReturnRows(pgxmock.NewRows([]string{"failures"}).AddRow(3))
+
+	n, err := r.RecordLoginFailure(context.Background(), "email:a@b", since)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginLockedUntil(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	keys := []string{"email:a@b", "ip:10.0.0.1"}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(locked_until) FROM login_failures WHERE key = ANY($1)")).
+		WithArgs(keys).
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+
+	until, err := r.LoginLockedUntil(context.Background(), keys)
+	assert.NoError(t, err)
+	assert.True(t, until.IsZero())
+
+	lock := time.Now().Add(time.Minute)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(locked_until)")).
+		WithArgs(keys).
+		WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(&lock))
+	until, err = r.LoginLockedUntil(context.Background(), keys)
+	assert.NoError(t, err)
+	assert.Equal(t, lock, until)
+}
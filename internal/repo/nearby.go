@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"math"
+
+	sq "github.com/Masterminds/squirrel"
+	"pvz-backend-service/internal/model"
+)
+
+const earthRadiusKm = 6371.0
+
+// haversineKm is the great-circle distance in km from the point bound to its
+// placeholders (lat, lat, lon) to the PVZ's coordinates. The asin argument is
+// clamped because rounding can push it just past 1 for antipodal points.
+const haversineKm = `6371 * 2 * asin(least(1.0, sqrt(
+    power(sin(radians(latitude - ?) / 2), 2) +
+    cos(radians(?)) * cos(radians(latitude)) * power(sin(radians(longitude - ?) / 2), 2))))`
+
+// boundingBox returns the latitude and longitude ranges that contain every
+// point within radiusKm of (lat, lon). The longitude range is nil near the
+// poles and across the antimeridian, where it cannot narrow the search.
+func boundingBox(lat, lon, radiusKm float64) (sq.Sqlizer, sq.Sqlizer) {
+	dLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	latRange := sq.Expr("latitude BETWEEN ? AND ?", lat-dLat, lat+dLat)
+	if math.Abs(lat)+dLat >= 90 {
+		return latRange, nil
+	}
+	dLon := dLat / math.Cos(lat*math.Pi/180)
+	if lon-dLon < -180 || lon+dLon > 180 {
+		return latRange, nil
+	}
+	return latRange, sq.Expr("longitude BETWEEN ? AND ?", lon-dLon, lon+dLon)
+}
+
+// NearbyPVZ lists active PVZs within radiusKm of (lat, lon), nearest first.
+// The bounding box lets the pvz_location index discard far rows before the
+// exact distance is computed.
+func (r *repo) NearbyPVZ(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]model.NearbyPVZ, error) {
+	latRange, lonRange := boundingBox(lat, lon, radiusKm)
+	inner := sq.
+		Select(pvzColumns).
+		Column(sq.Alias(sq.Expr(haversineKm, lat, lat, lon), "distance_km")).
+		From("pvz").
+		Where(sq.Eq{"status": "active"}).
+		Where(latRange)
+	if lonRange != nil {
+		inner = inner.Where(lonRange)
+	}
+	sql, args, err := r.sb.
+		Select(pvzColumns, "distance_km").
+		FromSelect(inner, "p").
+		Where(sq.LtOrEq{"distance_km": radiusKm}).
+		OrderBy("distance_km", "id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, mapErr("nearby pvz", err)
+	}
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapErr("nearby pvz", err)
+	}
+	defer rows.Close()
+
+	res := []model.NearbyPVZ{}
+	for rows.Next() {
+		var n model.NearbyPVZ
+		if n.PVZ, err = scanPVZ(rows, &n.DistanceKm); err != nil {
+			return nil, mapErr("scan pvz", err)
+		}
+		res = append(res, n)
+	}
+	return res, mapErr("nearby pvz", rows.Err())
+}
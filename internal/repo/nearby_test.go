@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearbyPVZ(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM pvz WHERE status = $4 AND latitude BETWEEN $5 AND $6 AND longitude BETWEEN $7 AND $8) AS p WHERE distance_km <= $9 ORDER BY distance_km, id LIMIT 10",
+	)).
+		WithArgs(55.75, 55.75, 37.62, "active", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), 5.0).
+		WillReturnRows(pvzRows("distance_km").
+			AddRow("p1", "Москва", time.Now(), "", nil, nil, "Europe/Moscow", []byte("[]"), nil, "active", 1.2).
+			AddRow("p2", "Москва", time.Now(), "", nil, nil, "Europe/Moscow", []byte("[]"), nil, "active", 4.8))
+
+	res, err := r.NearbyPVZ(context.Background(), 55.75, 37.62, 5, 10)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "p1", res[0].ID)
+	assert.Equal(t, 4.8, res[1].DistanceKm)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBoundingBox(t *testing.T) {
+	latRange, lonRange := boundingBox(55.75, 37.62, 10)
+	_, args, _ := latRange.ToSql()
+	assert.InDelta(t, 55.66, args[0], 0.01)
+	assert.InDelta(t, 55.84, args[1], 0.01)
+	_, args, _ = lonRange.ToSql()
+	assert.InDelta(t, 37.46, args[0], 0.01)
+	assert.InDelta(t, 37.78, args[1], 0.01)
+
+	_, lonRange = boundingBox(89.95, 0, 10)
+	assert.Nil(t, lonRange)
+	_, lonRange = boundingBox(64.7, 179.99, 10)
+	assert.Nil(t, lonRange)
+}
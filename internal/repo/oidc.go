@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"context"
+
+	"pvz-backend-service/internal/model"
+)
+
+// CreateOIDCLogin stores a pending login and purges abandoned ones.
+func (r *repo) CreateOIDCLogin(ctx context.Context, l model.OIDCLogin) error {
+	if _, err := r.db.Exec(ctx, "DELETE FROM oidc_logins WHERE expires_at < now()"); err != nil {
+		return mapErr("purge oidc logins", err)
+	}
+	sql, args, _ := r.sb.
+		Insert("oidc_logins").
+		Columns("state_hash", "nonce", "code_verifier", "expires_at").
+		Values(l.StateHash, l.Nonce, l.CodeVerifier, l.ExpiresAt).
+		ToSql()
+	_, err := r.db.Exec(ctx, sql, args...)
+	return mapErr("create oidc login", err)
+}
+
+// ConsumeOIDCLogin deletes and returns a pending login so that each state
+// can be redeemed only once.
+func (r *repo) ConsumeOIDCLogin(ctx context.Context, stateHash string) (model.OIDCLogin, error) {
+	l := model.OIDCLogin{StateHash: stateHash}
+	err := r.db.QueryRow(ctx,
+		"DELETE FROM oidc_logins WHERE state_hash=$1 RETURNING nonce,code_verifier,expires_at",
+		stateHash,
+	).Scan(&l.Nonce, &l.CodeVerifier, &l.ExpiresAt)
+	return l, mapErr("consume oidc login", err)
+}
+
+func (r *repo) GetUserByIdentity(ctx context.Context, issuer, subject string) (model.User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"SELECT "+userColumns+" FROM users WHERE id=(SELECT user_id FROM user_identities WHERE issuer=$1 AND subject=$2)",
+		issuer, subject,
+	))
+	return u, mapErr("get user by identity", err)
+}
+
+func (r *repo) LinkUserIdentity(ctx context.Context, issuer, subject, userID string) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO user_identities (issuer,subject,user_id) VALUES ($1,$2,$3)",
+		issuer, subject, userID,
+	)
+	return mapErr("link user identity", err)
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func TestCreateOIDCLogin(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	exp := time.Now().Add(10 * time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM oidc_logins WHERE expires_at < now()")).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO oidc_logins (state_hash,nonce,code_verifier,expires_at) VALUES ($1,$2,$3,$4)")).
+		WithArgs("sh", "n", "v", exp).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := r.CreateOIDCLogin(context.Background(), model.OIDCLogin{StateHash: "sh", Nonce: "n", CodeVerifier: "v", ExpiresAt: exp})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsumeOIDCLogin(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	exp := time.Now()
+	query := regexp.QuoteMeta("DELETE FROM oidc_logins WHERE state_hash=$1 RETURNING nonce,code_verifier,expires_at")
+	mock.ExpectQuery(query).WithArgs("sh").
+		WillReturnRows(pgxmock.NewRows([]string{"nonce", "code_verifier", "expires_at"}).AddRow("n", "v", exp))
+	mock.ExpectQuery(query).WithArgs("sh").
+		WillReturnRows(pgxmock.NewRows([]string{"nonce", "code_verifier", "expires_at"}))
+
+	l, err := r.ConsumeOIDCLogin(context.Background(), "sh")
+	require.NoError(t, err)
+	assert.Equal(t, model.OIDCLogin{StateHash: "sh", Nonce: "n", CodeVerifier: "v", ExpiresAt: exp}, l)
+	_, err = r.ConsumeOIDCLogin(context.Background(), "sh")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+}
+
+func TestGetUserByIdentity(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id,email,password_hash,role,created_at,disabled_at FROM users WHERE id=(SELECT user_id FROM user_identities WHERE issuer=$1 AND subject=$2)",
+	)).
+		WithArgs("https://sso", "alice").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at", "disabled_at"}).
+			AddRow("u1", "alice@corp.ru", "", "employee", time.Now(), nil))
+
+	u, err := r.GetUserByIdentity(context.Background(), "https://sso", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", u.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+// loggedProduct is how a product is stored in the log. It keeps the seq the
+// API hides, so that undoing a delete restores the product to its place.
+type loggedProduct struct {
+	model.Product
+	Seq int64 `json:"seq,omitempty"`
+}
+
+const operationColumns = "id,reception_id,kind,product,previous_type,target_id,state,user_id,api_key_id,created_at"
+
+func scanOperation(row interface{ Scan(...any) error }) (model.ProductOperation, error) {
+	var op model.ProductOperation
+	var product []byte
+	var previousType, targetID, userID, apiKeyID *string
+	if err := row.Scan(&op.ID, &op.ReceptionID, &op.Kind, &product, &previousType, &targetID, &op.State, &userID, &apiKeyID, &op.CreatedAt); err != nil {
+		return op, err
+	}
+	if previousType != nil {
+		op.PreviousType = *previousType
+	}
+	if targetID != nil {
+		op.TargetID = *targetID
+	}
+	if userID != nil {
+		op.UserID = *userID
+	}
+	if apiKeyID != nil {
+		op.APIKeyID = *apiKeyID
+	}
+	var logged loggedProduct
+	if err := json.Unmarshal(product, &logged); err != nil {
+		return op, err
+	}
+	op.Product = logged.Product
+	op.Product.Seq = logged.Seq
+	return op, nil
+}
+
+// RecordProductOperations appends operations to the log in order and returns
+// them with their ids and time, which now() makes the same for all.
+func (r *repo) RecordProductOperations(ctx context.Context, ops []model.ProductOperation) ([]model.ProductOperation, error) {
+	b := r.sb.
+		Insert("product_operation").
+		Columns("id", "reception_id", "kind", "product", "previous_type", "target_id", "user_id", "api_key_id").
+		Suffix("RETURNING created_at")
+	recorded := make([]model.ProductOperation, len(ops))
+	for i, op := range ops {
+		op.ID, op.State = uuid.NewString(), "done"
+		product, err := json.Marshal(loggedProduct{Product: op.Product, Seq: op.Product.Seq})
+		if err != nil {
+			return nil, e.Internal("encode product operation", err)
+		}
+		b = b.Values(op.ID, op.ReceptionID, op.Kind, product, nullIfEmpty(op.PreviousType), nullIfEmpty(op.TargetID),
+			nullIfEmpty(op.UserID), nullIfEmpty(op.APIKeyID))
+		recorded[i] = op
+	}
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return nil, e.Validation("record product operations: nothing to record")
+	}
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapErr("record product operations", err)
+	}
+	defer rows.Close()
+
+	var at time.Time
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(&at); err != nil {
+			return nil, mapErr("scan product operation", err)
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, mapErr("record product operations", err)
+	}
+	if n != len(recorded) {
+		return nil, e.Internal("record product operations: unexpected number of rows returned", nil)
+	}
+	for i := range recorded {
+		recorded[i].CreatedAt = at
+	}
+	return recorded, nil
+}
+
+func (r *repo) listOperations(ctx context.Context, msg, sql string, args ...any) ([]model.ProductOperation, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapErr(msg, err)
+	}
+	defer rows.Close()
+
+	ops := []model.ProductOperation{}
+	for rows.Next() {
+		op, err := scanOperation(rows)
+		if err != nil {
+			return nil, mapErr("scan product operation", err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, mapErr(msg, rows.Err())
+}
+
+// ListProductOperations returns the whole log of a reception, oldest first.
+func (r *repo) ListProductOperations(ctx context.Context, receptionID string) ([]model.ProductOperation, error) {
+	return r.listOperations(ctx, "list product operations",
+		"SELECT "+operationColumns+" FROM product_operation WHERE reception_id=$1 ORDER BY seq", receptionID)
+}
+
+// LockUndoStack locks up to limit operations that can be undone, most recent
+// first.
+func (r *repo) LockUndoStack(ctx context.Context, receptionID string, limit int) ([]model.ProductOperation, error) {
+	return r.listOperations(ctx, "lock undo stack", `
+        SELECT `+operationColumns+` FROM product_operation
+        WHERE reception_id=$1 AND state='done' AND kind IN ('add','delete','retype')
+        ORDER BY seq DESC
+        LIMIT $2
+        FOR UPDATE`, receptionID, limit)
+}
+
+// LockRedoStack locks up to limit undone operations in the order they are to
+// be redone: since undo goes backwards, the earliest undone comes first.
+func (r *repo) LockRedoStack(ctx context.Context, receptionID string, limit int) ([]model.ProductOperation, error) {
+	return r.listOperations(ctx, "lock redo stack", `
+        SELECT `+operationColumns+` FROM product_operation
+        WHERE reception_id=$1 AND state='undone'
+        ORDER BY seq
+        LIMIT $2
+        FOR UPDATE`, receptionID, limit)
+}
+
+func (r *repo) SetOperationState(ctx context.Context, id, state string) error {
+	tag, err := r.db.Exec(ctx, "UPDATE product_operation SET state=$2 WHERE id=$1", id, state)
+	if err != nil {
+		return mapErr("set operation state", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("set operation state: not found")
+	}
+	return nil
+}
+
+// DiscardUndoneOperations drops the redo stack of a reception once a new
+// operation is recorded.
+func (r *repo) DiscardUndoneOperations(ctx context.Context, receptionID string) error {
+	_, err := r.db.Exec(ctx, "UPDATE product_operation SET state='discarded' WHERE reception_id=$1 AND state='undone'", receptionID)
+	return mapErr("discard undone operations", err)
+}
@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func TestRecordProductOperations(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	at := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(
+		"INSERT INTO product_operation (id,reception_id,kind,product,previous_type,target_id,user_id,api_key_id) " +
+			"VALUES ($1,$2,$3,$4,$5,$6,$7,$8),($9,$10,$11,$12,$13,$14,$15,$16) RETURNING created_at",
+	)
+	mock.ExpectQuery(query).
+		WithArgs(
+			pgxmock.AnyArg(), "r1", "add", []byte(`{"id":"pr1","dateTime":"0001-01-01T00:00:00Z","type":"обувь","receptionId":"r1","seq":3}`),
+			(*string)(nil), (*string)(nil), nullIfEmpty("u1"), (*string)(nil),
+			pgxmock.AnyArg(), "r1", "retype", pgxmock.AnyArg(), nullIfEmpty("обувь"), (*string)(nil), (*string)(nil), nullIfEmpty("k1"),
+		).
+		WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(at).AddRow(at))
+
+	ops, err := r.RecordProductOperations(context.Background(), []model.ProductOperation{
+		{ReceptionID: "r1", Kind: "add", Product: model.Product{ID: "pr1", ReceptionID: "r1", Type: "обувь", Seq: 3}, UserID: "u1"},
+		{ReceptionID: "r1", Kind: "retype", Product: model.Product{ID: "pr1", ReceptionID: "r1", Type: "одежда"}, PreviousType: "обувь", APIKeyID: "k1"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ops, 2)
+	assert.NotEqual(t, ops[0].ID, ops[1].ID)
+	assert.Equal(t, "done", ops[1].State)
+	assert.Equal(t, at, ops[1].CreatedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO product_operation")).
+		WithArgs(pgxmock.AnyArg(), "r1", "delete", pgxmock.AnyArg(), (*string)(nil), (*string)(nil), (*string)(nil), (*string)(nil)).
+		WillReturnRows(pgxmock.NewRows([]string{"created_at"}))
+
+	_, err = r.RecordProductOperations(context.Background(), []model.ProductOperation{{ReceptionID: "r1", Kind: "delete"}})
+	assert.True(t, e.IsKind(err, e.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func operationRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{"id", "reception_id", "kind", "product", "previous_type", "target_id", "state", "user_id", "api_key_id", "created_at"})
+}
+
+func TestLockUndoStack(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT "+operationColumns+" FROM product_operation WHERE reception_id=$1 AND state='done' AND kind IN ('add','delete','retype') ORDER BY seq DESC LIMIT $2 FOR UPDATE",
+	)).
+		WithArgs("r1", 2).
+		WillReturnRows(operationRows().
+			AddRow("op2", "r1", "retype", []byte(`{"id":"pr1","type":"одежда","receptionId":"r1","seq":3}`), nullIfEmpty("обувь"), (*string)(nil), "done", (*string)(nil), nullIfEmpty("k1"), time.Now()).
+			AddRow("op1", "r1", "add", []byte(`{"id":"pr1","type":"обувь","receptionId":"r1"}`), (*string)(nil), (*string)(nil), "done", nullIfEmpty("u1"), (*string)(nil), time.Now()))
+
+	ops, err := r.LockUndoStack(context.Background(), "r1", 2)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, "обувь", ops[0].PreviousType)
+	assert.Equal(t, "одежда", ops[0].Product.Type)
+	assert.Equal(t, int64(3), ops[0].Product.Seq)
+	assert.Zero(t, ops[1].Product.Seq)
+	assert.Equal(t, "k1", ops[0].APIKeyID)
+	assert.Equal(t, "u1", ops[1].UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetOperationState_NotFound(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product_operation SET state=$2 WHERE id=$1")).
+		WithArgs("op1", "undone").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	assert.True(t, e.IsKind(r.SetOperationState(context.Background(), "op1", "undone"), e.KindNotFound))
+}
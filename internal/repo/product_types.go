@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+const productTypeColumns = "name,fragile,requires_id_check,max_weight_grams,created_at,deprecated_at"
+
+func scanProductType(row interface{ Scan(...any) error }) (model.ProductType, error) {
+	var t model.ProductType
+	err := row.Scan(&t.Name, &t.Fragile, &t.RequiresIDCheck, &t.MaxWeightGrams, &t.CreatedAt, &t.DeprecatedAt)
+	return t, err
+}
+
+func (r *repo) ListProductTypes(ctx context.Context) ([]model.ProductType, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+productTypeColumns+" FROM product_types ORDER BY name")
+	if err != nil {
+		return nil, mapErr("list product types", err)
+	}
+	defer rows.Close()
+
+	types := []model.ProductType{}
+	for rows.Next() {
+		t, err := scanProductType(rows)
+		if err != nil {
+			return nil, mapErr("scan product type", err)
+		}
+		types = append(types, t)
+	}
+	return types, mapErr("list product types", rows.Err())
+}
+
+func (r *repo) GetProductType(ctx context.Context, name string) (model.ProductType, error) {
+	t, err := scanProductType(r.db.QueryRow(ctx, "SELECT "+productTypeColumns+" FROM product_types WHERE name=$1", name))
+	return t, mapErr("get product type", err)
+}
+
+func (r *repo) CreateProductType(ctx context.Context, t model.ProductType) (model.ProductType, error) {
+	t, err := scanProductType(r.db.QueryRow(ctx,
+		"INSERT INTO product_types (name,fragile,requires_id_check,max_weight_grams) VALUES ($1,$2,$3,$4) RETURNING "+productTypeColumns,
+		t.Name, t.Fragile, t.RequiresIDCheck, t.MaxWeightGrams))
+	return t, mapErr("create product type", err)
+}
+
+func (r *repo) UpdateProductType(ctx context.Context, name string, u model.ProductTypeUpdate) (model.ProductType, error) {
+	b := r.sb.Update("product_types").Where(sq.Eq{"name": name}).Suffix("RETURNING " + productTypeColumns)
+	if u.Fragile != nil {
+		b = b.Set("fragile", *u.Fragile)
+	}
+	if u.RequiresIDCheck != nil {
+		b = b.Set("requires_id_check", *u.RequiresIDCheck)
+	}
+	if u.MaxWeightGrams != nil {
+		var limit *int
+		if *u.MaxWeightGrams != 0 {
+			limit = u.MaxWeightGrams
+		}
+		b = b.Set("max_weight_grams", limit)
+	}
+	if u.Deprecated != nil {
+		if *u.Deprecated {
+			b = b.Set("deprecated_at", sq.Expr("COALESCE(deprecated_at, now())"))
+		} else {
+			b = b.Set("deprecated_at", nil)
+		}
+	}
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return model.ProductType{}, e.Validation("update product type: nothing to update")
+	}
+	t, err := scanProductType(r.db.QueryRow(ctx, sql, args...))
+	return t, mapErr("update product type", err)
+}
+
+// DeleteProductType fails with a conflict while products of the type exist.
+func (r *repo) DeleteProductType(ctx context.Context, name string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM product_types WHERE name=$1", name)
+	if err != nil {
+		return mapErr("delete product type", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("delete product type: not found")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func productTypeRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{"name", "fragile", "requires_id_check", "max_weight_grams", "created_at", "deprecated_at"})
+}
+
+func TestListProductTypes(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	limit, deprecated := 5000, time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + productTypeColumns + " FROM product_types ORDER BY name")).
+		WillReturnRows(productTypeRows().
+			AddRow("обувь", false, false, nil, time.Now(), nil).
+			AddRow("электроника", true, true, &limit, time.Now(), &deprecated))
+
+	types, err := r.ListProductTypes(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, types, 2)
+	assert.Nil(t, types[0].MaxWeightGrams)
+	assert.True(t, types[1].Fragile)
+	assert.Equal(t, 5000, *types[1].MaxWeightGrams)
+	assert.NotNil(t, types[1].DeprecatedAt)
+}
+
+func TestCreateProductType(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	limit := 2000
+	query := regexp.QuoteMeta("INSERT INTO product_types (name,fragile,requires_id_check,max_weight_grams) VALUES ($1,$2,$3,$4) RETURNING " + productTypeColumns)
+	mock.ExpectQuery(query).
+		WithArgs("косметика", true, false, &limit).
+		WillReturnRows(productTypeRows().AddRow("косметика", true, false, &limit, time.Now(), nil))
+	mock.ExpectQuery(query).
+		WithArgs("обувь", false, false, (*int)(nil)).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	pt, err := r.CreateProductType(context.Background(), model.ProductType{Name: "косметика", Fragile: true, MaxWeightGrams: &limit})
+	assert.NoError(t, err)
+	assert.Equal(t, "косметика", pt.Name)
+	assert.False(t, pt.CreatedAt.IsZero())
+
+	_, err = r.CreateProductType(context.Background(), model.ProductType{Name: "обувь"})
+	assert.True(t, e.IsKind(err, e.KindConflict))
+}
+
+func TestUpdateProductType(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	yes, noLimit, now := true, 0, time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"UPDATE product_types SET fragile = $1, max_weight_grams = $2, deprecated_at = COALESCE(deprecated_at, now()) WHERE name = $3 RETURNING "+productTypeColumns,
+	)).
+		WithArgs(true, (*int)(nil), "обувь").
+		WillReturnRows(productTypeRows().AddRow("обувь", true, false, nil, now, &now))
+
+	pt, err := r.UpdateProductType(context.Background(), "обувь", model.ProductTypeUpdate{Fragile: &yes, MaxWeightGrams: &noLimit, Deprecated: &yes})
+	assert.NoError(t, err)
+	assert.NotNil(t, pt.DeprecatedAt)
+
+	_, err = r.UpdateProductType(context.Background(), "обувь", model.ProductTypeUpdate{})
+	assert.True(t, e.IsKind(err, e.KindValidation))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteProductType(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_types WHERE name=$1")).
+		WithArgs("обувь").
+		WillReturnError(&pgconn.PgError{Code: "23503", Detail: `Key (name)=(обувь) is still referenced from table "product".`})
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_types WHERE name=$1")).
+		WithArgs("мебель").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	assert.True(t, e.IsKind(r.DeleteProductType(context.Background(), "обувь"), e.KindPrecondition))
+	assert.True(t, e.IsKind(r.DeleteProductType(context.Background(), "мебель"), e.KindNotFound))
+}
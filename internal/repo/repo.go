@@ -1,14 +1,17 @@
 package repo
 
 import (
+	"cmp"
 	"context"
-	"errors"
+	"encoding/json"
 	"pvz-backend-service/internal/model"
 	"pvz-backend-service/lib/e"
+	"slices"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 var _ Repository = (*repo)(nil)
@@ -16,25 +19,99 @@ var _ Repository = (*repo)(nil)
 type Repository interface {
 	CreateUser(ctx context.Context, email, hash, role string) (model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (model.User, error)
-	CreatePVZ(ctx context.Context, city string) (model.PVZ, error)
-	ListPVZ(ctx context.Context, start, end string, limit, offset int) ([]model.PVZ, error)
-	OpenReception(ctx context.Context, pvzID string) (model.Reception, error)
+	GetUserByID(ctx context.Context, id string) (model.User, error)
+	ListUsers(ctx context.Context, f model.UserFilter) ([]model.User, error)
+	UpdateUserRole(ctx context.Context, id, role string) error
+	SetUserDisabled(ctx context.Context, id string, disabled bool) error
+	UpdateUserPassword(ctx context.Context, id, hash string) error
+	GetUserByIdentity(ctx context.Context, issuer, subject string) (model.User, error)
+	LinkUserIdentity(ctx context.Context, issuer, subject, userID string) error
+	CreateOIDCLogin(ctx context.Context, l model.OIDCLogin) error
+	ConsumeOIDCLogin(ctx context.Context, stateHash string) (model.OIDCLogin, error)
+	CreateInvite(ctx context.Context, inv model.Invite) error
+	GetInvite(ctx context.Context, tokenHash string) (model.Invite, error)
+	AcceptInvite(ctx context.Context, id string) error
+	ListCities(ctx context.Context) ([]model.City, error)
+	CreateCity(ctx context.Context, name string) (model.City, error)
+	RenameCity(ctx context.Context, name, newName string) (model.City, error)
+	DeleteCity(ctx context.Context, name string) error
+	ListProductTypes(ctx context.Context) ([]model.ProductType, error)
+	GetProductType(ctx context.Context, name string) (model.ProductType, error)
+	CreateProductType(ctx context.Context, t model.ProductType) (model.ProductType, error)
+	UpdateProductType(ctx context.Context, name string, u model.ProductTypeUpdate) (model.ProductType, error)
+	DeleteProductType(ctx context.Context, name string) error
+	CreatePVZ(ctx context.Context, p model.PVZ) (model.PVZ, error)
+	UpdatePVZ(ctx context.Context, id string, u model.PVZUpdate) (model.PVZ, error)
+	ListPVZ(ctx context.Context, f model.PVZFilter) ([]model.PVZ, error)
+	ListPVZWithReceptions(ctx context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error)
+	OpenReception(ctx context.Context, pvzID string, m *model.Manifest) (model.Reception, error)
 	GetOpenReception(ctx context.Context, pvzID string) (model.Reception, error)
-	AddProduct(ctx context.Context, receptionID, typ string) (model.Product, error)
-	DeleteLastProduct(ctx context.Context, receptionID string) error
+	FindOpenReception(ctx context.Context, pvzID string) (model.Reception, error)
+	AddProduct(ctx context.Context, receptionID string, p model.Product) (model.Product, error)
+	AddProducts(ctx context.Context, receptionID string, ps []model.Product) ([]model.Product, error)
+	ProductsByBarcode(ctx context.Context, barcode string, limit int) ([]model.Product, error)
+	DeleteLastProduct(ctx context.Context, receptionID string) (model.Product, error)
+	GetReceptionProduct(ctx context.Context, receptionID, id, barcode string) (model.Product, error)
+	DeleteProduct(ctx context.Context, id string) error
+	RestoreProduct(ctx context.Context, p model.Product) error
+	SetProductType(ctx context.Context, id, productType string) (model.Product, error)
+	RecordProductOperations(ctx context.Context, ops []model.ProductOperation) ([]model.ProductOperation, error)
+	ListProductOperations(ctx context.Context, receptionID string) ([]model.ProductOperation, error)
+	LockUndoStack(ctx context.Context, receptionID string, limit int) ([]model.ProductOperation, error)
+	LockRedoStack(ctx context.Context, receptionID string, limit int) ([]model.ProductOperation, error)
+	SetOperationState(ctx context.Context, id, state string) error
+	DiscardUndoneOperations(ctx context.Context, receptionID string) error
 	CloseReception(ctx context.Context, receptionID string) error
+	CountProductsByType(ctx context.Context, receptionID string) (map[string]int, error)
+	ListReceptionBarcodes(ctx context.Context, receptionID string) ([]string, error)
+	CreateReceptionReport(ctx context.Context, rep model.ReceptionReport) error
+	GetReceptionReport(ctx context.Context, receptionID string) (model.ReceptionReport, error)
+	AcceptReceptionReport(ctx context.Context, receptionID, userID, comment string) error
+	LockPVZ(ctx context.Context, pvzID string) (model.PVZ, error)
+	NearbyPVZ(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]model.NearbyPVZ, error)
+	AssignEmployee(ctx context.Context, pvzID, userID, assignedBy string) error
+	UnassignEmployee(ctx context.Context, pvzID, userID string) error
+	ListPVZEmployees(ctx context.Context, pvzID string) ([]model.User, error)
+	IsAssigned(ctx context.Context, userID, pvzID string) (bool, error)
+	CreateAPIKey(ctx context.Context, k model.APIKey) (model.APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (model.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (model.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id, replacedBy string) error
+	TouchAPIKey(ctx context.Context, id string) error
+	CreateRefreshToken(ctx context.Context, t model.RefreshToken) error
+	GetRefreshToken(ctx context.Context, hash string) (model.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id, replacedBy string) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserTokens(ctx context.Context, userID string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+	RecordLoginFailure(ctx context.Context, key string, since time.Time) (int, error)
+	LockLogin(ctx context.Context, key string, until time.Time) error
+	LoginLockedUntil(ctx context.Context, keys []string) (time.Time, error)
+	ClearLoginFailures(ctx context.Context, key string) error
+	WithTx(ctx context.Context, fn func(Repository) error) error
 }
 
 type repo struct {
-	db DB
-	sb sq.StatementBuilderType
+	db        DB
+	sb        sq.StatementBuilderType
+	isolation pgx.TxIsoLevel
+	txRetries int
+	inTx      bool
 }
 
-func New(db DB) Repository {
-	return &repo{
-		db: db,
-		sb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+func New(db DB, opts ...Option) Repository {
+	r := &repo{
+		db:        db,
+		sb:        sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		isolation: pgx.ReadCommitted,
+		txRetries: 3,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *repo) CreateUser(ctx context.Context, email, hash, role string) (model.User, error) {
@@ -45,126 +122,461 @@ func (r *repo) CreateUser(ctx context.Context, email, hash, role string) (model.
 		Values(id, email, hash, role).
 		ToSql()
 	_, err := r.db.Exec(ctx, sql, args...)
-	return model.User{ID: id, Email: email, PasswordHash: hash, Role: role}, e.WrapIfErr("create user", err)
+	return model.User{ID: id, Email: email, PasswordHash: hash, Role: role}, mapErr("create user", err)
 }
 
-func (r *repo) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
-	row := r.db.QueryRow(ctx, "SELECT id,email,password_hash,role FROM users WHERE email=$1", email)
-	var u model.User
-	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role); err != nil {
-		return u, err
+const pvzColumns = "id,city,registration_date,address,latitude,longitude,timezone,working_hours,capacity,status"
+
+const productColumns = "id,reception_id,date_time,type,barcode,sku,weight_grams,length_mm,width_mm,height_mm,seq"
+
+func scanProduct(row interface{ Scan(...any) error }) (model.Product, error) {
+	var p model.Product
+	var barcode *string
+	var length, width, height *int
+	if err := row.Scan(&p.ID, &p.ReceptionID, &p.DateTime, &p.Type, &barcode, &p.SKU, &p.WeightGrams, &length, &width, &height, &p.Seq); err != nil {
+		return p, err
+	}
+	if barcode != nil {
+		p.Barcode = *barcode
 	}
-	return u, nil
+	if length != nil && width != nil && height != nil {
+		p.Dimensions = &model.Dimensions{LengthMm: *length, WidthMm: *width, HeightMm: *height}
+	}
+	return p, nil
 }
 
-func (r *repo) CreatePVZ(ctx context.Context, city string) (model.PVZ, error) {
-	if city != "Москва" && city != "Санкт-Петербург" && city != "Казань" {
-		return model.PVZ{}, e.Wrap("invalid city", nil)
+// dimensionArgs returns the length, width and height columns, NULL when
+// dimensions are unknown.
+func dimensionArgs(d *model.Dimensions) (any, any, any) {
+	if d == nil {
+		return (*int)(nil), (*int)(nil), (*int)(nil)
 	}
-	id := uuid.NewString()
+	return d.LengthMm, d.WidthMm, d.HeightMm
+}
+
+// scanPVZ scans pvzColumns followed by any extra selected columns.
+func scanPVZ(row interface{ Scan(...any) error }, extra ...any) (model.PVZ, error) {
+	var p model.PVZ
+	var hours []byte
+	dest := append([]any{&p.ID, &p.City, &p.RegistrationDate, &p.Address, &p.Latitude, &p.Longitude, &p.Timezone, &hours, &p.Capacity, &p.Status}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return p, err
+	}
+	return p, json.Unmarshal(hours, &p.WorkingHours)
+}
+
+func workingHoursJSON(h []model.WorkingHours) []byte {
+	if h == nil {
+		h = []model.WorkingHours{}
+	}
+	b, _ := json.Marshal(h)
+	return b
+}
+
+// pvzCityErr reports a missing city as invalid input: the city is the only
+// reference a PVZ row holds.
+func pvzCityErr(err error, city string) error {
+	if e.IsKind(err, e.KindNotFound) {
+		return e.Validation("invalid city %q", city)
+	}
+	return err
+}
+
+func (r *repo) CreatePVZ(ctx context.Context, p model.PVZ) (model.PVZ, error) {
+	p.ID = uuid.NewString()
 	sql, args, _ := r.sb.
 		Insert("pvz").
-		Columns("id", "city").
-		Values(id, city).
+		Columns("id", "city", "address", "latitude", "longitude", "timezone", "working_hours", "capacity", "status").
+		Values(p.ID, p.City, p.Address, p.Latitude, p.Longitude, p.Timezone, workingHoursJSON(p.WorkingHours), p.Capacity, p.Status).
 		Suffix("RETURNING registration_date").
 		ToSql()
-	var reg time.Time
-	if err := r.db.QueryRow(ctx, sql, args...).Scan(&reg); err != nil {
-		return model.PVZ{}, e.WrapIfErr("create pvz", err)
+	err := mapErr("create pvz", r.db.QueryRow(ctx, sql, args...).Scan(&p.RegistrationDate))
+	if err != nil {
+		return model.PVZ{}, pvzCityErr(err, p.City)
 	}
-	return model.PVZ{ID: id, City: city, RegistrationDate: reg}, nil
+	return p, nil
 }
 
-func (r *repo) ListPVZ(ctx context.Context, start, end string, limit, offset int) ([]model.PVZ, error) {
-	b := r.sb.
-		Select("id", "city", "registration_date").
-		From("pvz").
-		OrderBy("registration_date DESC").
-		Limit(uint64(limit)).Offset(uint64(offset))
-	if start != "" && end != "" {
-		b = b.Where(sq.And{sq.GtOrEq{"registration_date": start}, sq.LtOrEq{"registration_date": end}})
+func (r *repo) UpdatePVZ(ctx context.Context, id string, u model.PVZUpdate) (model.PVZ, error) {
+	b := r.sb.Update("pvz").Where(sq.Eq{"id": id}).Suffix("RETURNING " + pvzColumns)
+	if u.City != nil {
+		b = b.Set("city", *u.City)
+	}
+	if u.Address != nil {
+		b = b.Set("address", *u.Address)
+	}
+	if u.Latitude != nil {
+		b = b.Set("latitude", *u.Latitude)
+	}
+	if u.Longitude != nil {
+		b = b.Set("longitude", *u.Longitude)
+	}
+	if u.Timezone != nil {
+		b = b.Set("timezone", *u.Timezone)
+	}
+	if u.WorkingHours != nil {
+		b = b.Set("working_hours", workingHoursJSON(*u.WorkingHours))
+	}
+	if u.Capacity != nil {
+		b = b.Set("capacity", *u.Capacity)
+	}
+	if u.Status != nil {
+		b = b.Set("status", *u.Status)
+	}
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return model.PVZ{}, e.Validation("update pvz: nothing to update")
+	}
+	p, err := scanPVZ(r.db.QueryRow(ctx, sql, args...))
+	err = mapErr("update pvz", err)
+	if u.City != nil {
+		err = pvzCityErr(err, *u.City)
+	}
+	return p, err
+}
+
+// pvzPage orders PVZs newest first, with id as a tiebreaker so that the
+// order is total and a cursor can resume it without skips or repeats.
+func pvzPage(b sq.SelectBuilder, f model.PVZFilter) sq.SelectBuilder {
+	b = b.OrderBy("registration_date DESC", "id DESC").
+		Limit(uint64(f.Limit)).Offset(uint64(f.Offset))
+	if f.After != nil {
+		b = b.Where("(registration_date, id) < (?, ?)", f.After.RegistrationDate, f.After.ID)
+	}
+	return b
+}
+
+func (r *repo) ListPVZ(ctx context.Context, f model.PVZFilter) ([]model.PVZ, error) {
+	b := pvzPage(r.sb.Select(pvzColumns).From("pvz"), f)
+	if f.Start != "" && f.End != "" {
+		b = b.Where(sq.And{sq.GtOrEq{"registration_date": f.Start}, sq.LtOrEq{"registration_date": f.End}})
 	}
 	sql, args, _ := b.ToSql()
 	rows, err := r.db.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, e.WrapIfErr("list pvz", err)
+		return nil, e.Wrap("list pvz", err)
 	}
 	defer rows.Close()
-	var res []model.PVZ
+	res := []model.PVZ{}
 	for rows.Next() {
-		var p model.PVZ
-		rows.Scan(&p.ID, &p.City, &p.RegistrationDate)
+		p, err := scanPVZ(rows)
+		if err != nil {
+			return nil, e.Wrap("scan pvz", err)
+		}
 		res = append(res, p)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap("list pvz", err)
+	}
 	return res, nil
 }
 
-func (r *repo) OpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
-	var cnt int
-	if err := r.db.QueryRow(ctx,
-		"SELECT COUNT(*) FROM reception WHERE pvz_id=$1 AND status='in_progress'", pvzID,
-	).Scan(&cnt); err != nil {
-		return model.Reception{}, err
+func (r *repo) ListPVZWithReceptions(ctx context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error) {
+	recFilter := sq.And{}
+	if f.Start != "" {
+		recFilter = append(recFilter, sq.GtOrEq{"date_time": f.Start})
+	}
+	if f.End != "" {
+		recFilter = append(recFilter, sq.LtOrEq{"date_time": f.End})
+	}
+
+	b := pvzPage(r.sb.Select(pvzColumns).From("pvz"), f)
+	if len(recFilter) > 0 {
+		sub, subArgs, _ := sq.Select("1").From("reception").
+			Where("reception.pvz_id = pvz.id").Where(recFilter).ToSql()
+		b = b.Where("EXISTS ("+sub+")", subArgs...)
+	}
+	sql, args, _ := b.ToSql()
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, e.Wrap("list pvz", err)
+	}
+	res := []model.PVZWithReceptions{}
+	pvzIdx := map[string]int{}
+	var pvzIDs []string
+	for rows.Next() {
+		p, err := scanPVZ(rows)
+		if err != nil {
+			rows.Close()
+			return nil, e.Wrap("scan pvz", err)
+		}
+		pvzIdx[p.ID] = len(res)
+		pvzIDs = append(pvzIDs, p.ID)
+		res = append(res, model.PVZWithReceptions{PVZ: p, Receptions: []model.ReceptionWithProducts{}})
 	}
-	if cnt > 0 {
-		return model.Reception{}, errors.New("open reception exists")
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap("list pvz", err)
+	}
+	if len(pvzIDs) == 0 {
+		return res, nil
+	}
+
+	recWhere := sq.And{sq.Eq{"pvz_id": pvzIDs}}
+	recWhere = append(recWhere, recFilter...)
+	sql, args, _ = r.sb.
+		Select("id", "pvz_id", "date_time", "status").
+		From("reception").
+		Where(recWhere).
+		OrderBy("date_time", "id").
+		ToSql()
+	rows, err = r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, e.Wrap("list receptions", err)
+	}
+	type recPos struct{ pvz, rec int }
+	recIdx := map[string]recPos{}
+	var recIDs []string
+	for rows.Next() {
+		var rec model.Reception
+		if err := rows.Scan(&rec.ID, &rec.PVZID, &rec.DateTime, &rec.Status); err != nil {
+			rows.Close()
+			return nil, e.Wrap("scan reception", err)
+		}
+		pi := pvzIdx[rec.PVZID]
+		recIdx[rec.ID] = recPos{pi, len(res[pi].Receptions)}
+		recIDs = append(recIDs, rec.ID)
+		res[pi].Receptions = append(res[pi].Receptions, model.ReceptionWithProducts{Reception: rec, Products: []model.Product{}})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, e.Wrap("list receptions", err)
+	}
+	if len(recIDs) == 0 {
+		return res, nil
+	}
+
+	sql, args, _ = r.sb.
+		Select(productColumns).
+		From("product").
+		Where(sq.Eq{"reception_id": recIDs}).
+		OrderBy("seq").
+		ToSql()
+	rows, err = r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, e.Wrap("list products", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, e.Wrap("scan product", err)
+		}
+		pos := recIdx[p.ReceptionID]
+		rec := &res[pos.pvz].Receptions[pos.rec]
+		rec.Products = append(rec.Products, p)
 	}
+	return res, e.WrapIfErr("list products", rows.Err())
+}
 
+func (r *repo) OpenReception(ctx context.Context, pvzID string, m *model.Manifest) (model.Reception, error) {
 	id := uuid.NewString()
 	var dt time.Time
 	if err := r.db.QueryRow(ctx,
-		"INSERT INTO reception (id,pvz_id,status) VALUES ($1,$2,'in_progress') RETURNING date_time",
-		id, pvzID,
+		"INSERT INTO reception (id,pvz_id,status,manifest) VALUES ($1,$2,'in_progress',$3) RETURNING date_time",
+		id, pvzID, manifestJSON(m),
 	).Scan(&dt); err != nil {
-		return model.Reception{}, err
+		return model.Reception{}, mapErr("open reception", err)
 	}
-	return model.Reception{ID: id, PVZID: pvzID, DateTime: dt, Status: "in_progress"}, nil
+	return model.Reception{ID: id, PVZID: pvzID, DateTime: dt, Status: "in_progress", Manifest: m}, nil
 }
 
+const openReceptionQuery = "SELECT id,pvz_id,date_time,status,manifest FROM reception WHERE pvz_id=$1 AND status='in_progress'"
+
+// GetOpenReception locks the open reception of a PVZ for the changes of the
+// current transaction.
 func (r *repo) GetOpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
-	row := r.db.QueryRow(ctx, "SELECT id,pvz_id,date_time,status FROM reception WHERE pvz_id=$1 AND status='in_progress'", pvzID)
+	return scanOpenReception("get open reception", r.db.QueryRow(ctx, openReceptionQuery+" FOR UPDATE", pvzID))
+}
+
+// FindOpenReception reads the open reception of a PVZ without locking it.
+func (r *repo) FindOpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
+	return scanOpenReception("find open reception", r.db.QueryRow(ctx, openReceptionQuery, pvzID))
+}
+
+func scanOpenReception(msg string, row pgx.Row) (model.Reception, error) {
 	var rec model.Reception
-	if err := row.Scan(&rec.ID, &rec.PVZID, &rec.DateTime, &rec.Status); err != nil {
-		return rec, err
+	var manifest []byte
+	if err := row.Scan(&rec.ID, &rec.PVZID, &rec.DateTime, &rec.Status, &manifest); err != nil {
+		return rec, mapErr(msg, err)
+	}
+	if manifest != nil {
+		rec.Manifest = &model.Manifest{}
+		if err := json.Unmarshal(manifest, rec.Manifest); err != nil {
+			return rec, e.Internal("decode reception manifest", err)
+		}
 	}
 	return rec, nil
 }
 
-func (r *repo) AddProduct(ctx context.Context, receptionID, typ string) (model.Product, error) {
-	id := uuid.NewString()
+func (r *repo) LockPVZ(ctx context.Context, pvzID string) (model.PVZ, error) {
+	p, err := scanPVZ(r.db.QueryRow(ctx, "SELECT "+pvzColumns+" FROM pvz WHERE id=$1 FOR UPDATE", pvzID))
+	return p, mapErr("lock pvz", err)
+}
+
+func (r *repo) AddProduct(ctx context.Context, receptionID string, p model.Product) (model.Product, error) {
+	p.ID, p.ReceptionID = uuid.NewString(), receptionID
+	length, width, height := dimensionArgs(p.Dimensions)
 	sql, args, _ := r.sb.
 		Insert("product").
-		Columns("id", "reception_id", "type").
-		Values(id, receptionID, typ).
-		Suffix("RETURNING date_time").
+		Columns("id", "reception_id", "type", "barcode", "sku", "weight_grams", "length_mm", "width_mm", "height_mm").
+		Values(p.ID, receptionID, p.Type, nullIfEmpty(p.Barcode), p.SKU, p.WeightGrams, length, width, height).
+		Suffix("RETURNING date_time,seq").
 		ToSql()
-	var dt time.Time
-	if err := r.db.QueryRow(ctx, sql, args...).Scan(&dt); err != nil {
-		return model.Product{}, e.WrapIfErr("add product", err)
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&p.DateTime, &p.Seq); err != nil {
+		return model.Product{}, mapErr("add product", err)
+	}
+	return p, nil
+}
+
+// AddProducts inserts a batch in one statement. The rows take their seq in
+// batch order, so DeleteLastProduct still removes the last scanned item, and
+// the returned rows map back onto the batch once sorted by seq.
+func (r *repo) AddProducts(ctx context.Context, receptionID string, ps []model.Product) ([]model.Product, error) {
+	b := r.sb.
+		Insert("product").
+		Columns("id", "reception_id", "type", "barcode", "sku", "weight_grams", "length_mm", "width_mm", "height_mm").
+		Suffix("RETURNING date_time,seq")
+	added := make([]model.Product, len(ps))
+	for i, p := range ps {
+		p.ID, p.ReceptionID = uuid.NewString(), receptionID
+		length, width, height := dimensionArgs(p.Dimensions)
+		b = b.Values(p.ID, receptionID, p.Type, nullIfEmpty(p.Barcode), p.SKU, p.WeightGrams, length, width, height)
+		added[i] = p
+	}
+	sql, args, err := b.ToSql()
+	if err != nil {
+		return nil, e.Validation("add products: nothing to add")
+	}
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapErr("add products", err)
+	}
+	defer rows.Close()
+
+	type stamp struct {
+		at  time.Time
+		seq int64
+	}
+	stamps := make([]stamp, 0, len(ps))
+	for rows.Next() {
+		var st stamp
+		if err := rows.Scan(&st.at, &st.seq); err != nil {
+			return nil, mapErr("scan product", err)
+		}
+		stamps = append(stamps, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, mapErr("add products", err)
+	}
+	if len(stamps) != len(added) {
+		return nil, e.Internal("add products: unexpected number of rows returned", nil)
+	}
+	slices.SortFunc(stamps, func(a, b stamp) int { return cmp.Compare(a.seq, b.seq) })
+	for i := range added {
+		added[i].DateTime, added[i].Seq = stamps[i].at, stamps[i].seq
+	}
+	return added, nil
+}
+
+// ProductsByBarcode returns products with the barcode, most recently
+// received first.
+func (r *repo) ProductsByBarcode(ctx context.Context, barcode string, limit int) ([]model.Product, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+productColumns+" FROM product WHERE barcode=$1 ORDER BY seq DESC LIMIT $2",
+		barcode, limit,
+	)
+	if err != nil {
+		return nil, mapErr("products by barcode", err)
+	}
+	defer rows.Close()
+
+	products := []model.Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, mapErr("scan product", err)
+		}
+		products = append(products, p)
 	}
-	return model.Product{ID: id, ReceptionID: receptionID, DateTime: dt, Type: typ}, nil
+	return products, mapErr("products by barcode", rows.Err())
 }
 
-func (r *repo) DeleteLastProduct(ctx context.Context, receptionID string) error {
-	_, err := r.db.Exec(ctx, `
+// DeleteLastProduct removes the most recently scanned product of a
+// reception and returns it.
+func (r *repo) DeleteLastProduct(ctx context.Context, receptionID string) (model.Product, error) {
+	p, err := scanProduct(r.db.QueryRow(ctx, `
         DELETE FROM product
         WHERE id IN (
           SELECT id FROM product
           WHERE reception_id=$1
-          ORDER BY date_time DESC, id DESC
+          ORDER BY seq DESC
           LIMIT 1
-        )`, receptionID,
-	)
-	return err
+        )
+        RETURNING `+productColumns, receptionID,
+	))
+	return p, mapErr("delete last product", err)
+}
+
+// GetReceptionProduct locks a product of the reception by id or, when id is
+// empty, by barcode.
+func (r *repo) GetReceptionProduct(ctx context.Context, receptionID, id, barcode string) (model.Product, error) {
+	where := sq.Eq{"reception_id": receptionID, "barcode": barcode}
+	if id != "" {
+		where = sq.Eq{"reception_id": receptionID, "id": id}
+	}
+	sql, args, _ := r.sb.
+		Select(productColumns).
+		From("product").
+		Where(where).
+		Suffix("FOR UPDATE").
+		ToSql()
+	p, err := scanProduct(r.db.QueryRow(ctx, sql, args...))
+	return p, mapErr("get product", err)
+}
+
+func (r *repo) DeleteProduct(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM product WHERE id=$1", id)
+	if err != nil {
+		return mapErr("delete product", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("delete product: not found")
+	}
+	return nil
+}
+
+// RestoreProduct puts a deleted product back as it was, keeping its id, scan
+// time and seq so that it returns to its place in the reception. Products
+// logged before seq existed get a new one.
+func (r *repo) RestoreProduct(ctx context.Context, p model.Product) error {
+	length, width, height := dimensionArgs(p.Dimensions)
+	cols := []string{"id", "reception_id", "date_time", "type", "barcode", "sku", "weight_grams", "length_mm", "width_mm", "height_mm"}
+	vals := []any{p.ID, p.ReceptionID, p.DateTime, p.Type, nullIfEmpty(p.Barcode), p.SKU, p.WeightGrams, length, width, height}
+	if p.Seq != 0 {
+		cols, vals = append(cols, "seq"), append(vals, p.Seq)
+	}
+	sql, args, _ := r.sb.
+		Insert("product").
+		Columns(cols...).
+		Values(vals...).
+		ToSql()
+	_, err := r.db.Exec(ctx, sql, args...)
+	return mapErr("restore product", err)
+}
+
+func (r *repo) SetProductType(ctx context.Context, id, productType string) (model.Product, error) {
+	p, err := scanProduct(r.db.QueryRow(ctx, "UPDATE product SET type=$2 WHERE id=$1 RETURNING "+productColumns, id, productType))
+	return p, mapErr("set product type", err)
 }
 
 func (r *repo) CloseReception(ctx context.Context, receptionID string) error {
 	tag, err := r.db.Exec(ctx, "UPDATE reception SET status='close' WHERE id=$1 AND status='in_progress'", receptionID)
 	if err != nil {
-		return e.Wrap("close reception", err)
+		return mapErr("close reception", err)
 	}
 	if tag.RowsAffected() == 0 {
-		return errors.New("reception already closed")
+		return e.Precondition("reception already closed")
 	}
 	return nil
 }
@@ -2,12 +2,17 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
 )
 
 func setupMockRepo(t *testing.T) (Repository, pgxmock.PgxPoolIface) {
@@ -16,37 +21,102 @@ func setupMockRepo(t *testing.T) (Repository, pgxmock.PgxPoolIface) {
 	return New(mock), mock
 }
 
+func pvzRows(extra ...string) *pgxmock.Rows {
+	return pgxmock.NewRows(append([]string{"id", "city", "registration_date", "address", "latitude", "longitude", "timezone", "working_hours", "capacity", "status"}, extra...))
+}
+
+func addPVZ(rows *pgxmock.Rows, id, city string, reg time.Time) *pgxmock.Rows {
+	return rows.AddRow(id, city, reg, "", nil, nil, "Europe/Moscow", []byte("[]"), nil, "active")
+}
+
 func TestCreatePVZ_Success(t *testing.T) {
 	r, mock := setupMockRepo(t)
 	cols := []string{"registration_date"}
+	lat, lon, capacity := 55.75, 37.62, 300
+	hours := []model.WorkingHours{{Weekday: 1, Opens: "09:00", Closes: "21:00"}}
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"INSERT INTO pvz (id,city) VALUES ($1,$2) RETURNING registration_date",
+		"INSERT INTO pvz (id,city,address,latitude,longitude,timezone,working_hours,capacity,status) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING registration_date",
 	)).
-		WithArgs(pgxmock.AnyArg(), "Москва").
+		WithArgs(pgxmock.AnyArg(), "Москва", "Тверская, 1", &lat, &lon, "Europe/Moscow", []byte(`[{"weekday":1,"opens":"09:00","closes":"21:00"}]`), &capacity, "active").
 		WillReturnRows(pgxmock.NewRows(cols).AddRow(time.Date(2025, 4, 19, 0, 0, 0, 0, time.UTC)))
 
-	pvz, err := r.CreatePVZ(context.Background(), "Москва")
+	pvz, err := r.CreatePVZ(context.Background(), model.PVZ{
+		City: "Москва", Address: "Тверская, 1", Latitude: &lat, Longitude: &lon,
+		Timezone: "Europe/Moscow", WorkingHours: hours, Capacity: &capacity, Status: "active",
+	})
 	assert.NoError(t, err)
 	assert.Equal(t, "Москва", pvz.City)
+	assert.NotEmpty(t, pvz.ID)
+	assert.False(t, pvz.RegistrationDate.IsZero())
+}
+
+func TestUpdatePVZ(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	addr, status := "Невский, 10", "suspended"
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"UPDATE pvz SET address = $1, working_hours = $2, status = $3 WHERE id = $4 RETURNING "+pvzColumns,
+	)).
+		WithArgs(addr, []byte("[]"), status, "p1").
+		WillReturnRows(pvzRows().AddRow("p1", "Санкт-Петербург", time.Now(), addr, nil, nil, "Europe/Moscow", []byte("[]"), nil, status))
+
+	p, err := r.UpdatePVZ(context.Background(), "p1", model.PVZUpdate{Address: &addr, WorkingHours: &[]model.WorkingHours{}, Status: &status})
+	assert.NoError(t, err)
+	assert.Equal(t, addr, p.Address)
+	assert.Equal(t, status, p.Status)
+	assert.Empty(t, p.WorkingHours)
+
+	_, err = r.UpdatePVZ(context.Background(), "p1", model.PVZUpdate{})
+	assert.True(t, e.IsKind(err, e.KindValidation))
+}
+
+func TestUpdatePVZ_InvalidCity(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	city := "London"
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE pvz SET city = $1 WHERE id = $2")).
+		WithArgs(city, "p1").
+		WillReturnError(&pgconn.PgError{Code: "23503", Detail: `Key (city)=(London) is not present in table "cities".`})
+
+	_, err := r.UpdatePVZ(context.Background(), "p1", model.PVZUpdate{City: &city})
+	assert.EqualError(t, err, `invalid city "London"`)
+}
+
+func TestLockPVZ(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	lat, lon, capacity := 55.79, 49.12, 120
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + pvzColumns + " FROM pvz WHERE id=$1 FOR UPDATE")).
+		WithArgs("p1").
+		WillReturnRows(pvzRows().AddRow("p1", "Казань", time.Now(), "Баумана, 5", &lat, &lon, "Europe/Moscow",
+			[]byte(`[{"weekday":6,"opens":"10:00","closes":"18:00"}]`), &capacity, "closed"))
+
+	p, err := r.LockPVZ(context.Background(), "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, "closed", p.Status)
+	assert.Equal(t, 49.12, *p.Longitude)
+	assert.Equal(t, 120, *p.Capacity)
+	assert.Equal(t, []model.WorkingHours{{Weekday: 6, Opens: "10:00", Closes: "18:00"}}, p.WorkingHours)
 }
 
 func TestCreatePVZ_InvalidCity(t *testing.T) {
-	r, _ := setupMockRepo(t)
-	_, err := r.CreatePVZ(context.Background(), "London")
-	assert.Error(t, err)
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO pvz (id,city,")).
+		WithArgs(pgxmock.AnyArg(), "London", "", (*float64)(nil), (*float64)(nil), "", []byte("[]"), (*int)(nil), "").
+		WillReturnError(&pgconn.PgError{Code: "23503", Detail: `Key (city)=(London) is not present in table "cities".`})
+
+	_, err := r.CreatePVZ(context.Background(), model.PVZ{City: "London"})
+	assert.True(t, e.IsKind(err, e.KindValidation))
+	assert.EqualError(t, err, `invalid city "London"`)
 }
 
 func TestListPVZ_NoFilter(t *testing.T) {
 	r, mock := setupMockRepo(t)
-	rows := pgxmock.NewRows([]string{"id", "city", "registration_date"}).
-		AddRow("p1", "Москва", time.Date(2025, 4, 19, 0, 0, 0, 0, time.UTC)).
-		AddRow("p2", "Казань", time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC))
+	rows := addPVZ(addPVZ(pvzRows(), "p1", "Москва", time.Date(2025, 4, 19, 0, 0, 0, 0, time.UTC)),
+		"p2", "Казань", time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC))
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, city, registration_date FROM pvz ORDER BY registration_date DESC LIMIT 10 OFFSET 0",
+		"SELECT " + pvzColumns + " FROM pvz ORDER BY registration_date DESC, id DESC LIMIT 10 OFFSET 0",
 	)).
 		WillReturnRows(rows)
 
-	pvzs, err := r.ListPVZ(context.Background(), "", "", 10, 0)
+	pvzs, err := r.ListPVZ(context.Background(), model.PVZFilter{Limit: 10})
 	assert.NoError(t, err)
 	assert.Len(t, pvzs, 2)
 	assert.Equal(t, "p1", pvzs[0].ID)
@@ -54,78 +124,269 @@ func TestListPVZ_NoFilter(t *testing.T) {
 
 func TestListPVZ_WithFilter(t *testing.T) {
 	r, mock := setupMockRepo(t)
-	rows := pgxmock.NewRows([]string{"id", "city", "registration_date"}).
-		AddRow("p3", "СПб", time.Date(2025, 4, 21, 0, 0, 0, 0, time.UTC))
+	rows := addPVZ(pvzRows(), "p3", "СПб", time.Date(2025, 4, 21, 0, 0, 0, 0, time.UTC))
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, city, registration_date FROM pvz WHERE (registration_date >= $1 AND registration_date <= $2) ORDER BY registration_date DESC LIMIT 5 OFFSET 1",
+		"SELECT "+pvzColumns+" FROM pvz WHERE (registration_date >= $1 AND registration_date <= $2) ORDER BY registration_date DESC, id DESC LIMIT 5 OFFSET 1",
 	)).
 		WithArgs("2025-04-19T00:00:00Z", "2025-04-21T23:59:59Z").
 		WillReturnRows(rows)
 
-	pvzs, err := r.ListPVZ(
-		context.Background(),
-		"2025-04-19T00:00:00Z",
-		"2025-04-21T23:59:59Z",
-		5, 1,
-	)
+	pvzs, err := r.ListPVZ(context.Background(), model.PVZFilter{
+		Start: "2025-04-19T00:00:00Z",
+		End:   "2025-04-21T23:59:59Z",
+		Limit: 5, Offset: 1,
+	})
 	assert.NoError(t, err)
 	assert.Len(t, pvzs, 1)
 	assert.Equal(t, "p3", pvzs[0].ID)
 }
 
-func TestOpenReception_Conflict(t *testing.T) {
+func TestListPVZ_AfterCursor(t *testing.T) {
 	r, mock := setupMockRepo(t)
+	after := model.PVZCursor{RegistrationDate: time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC), ID: "p2"}
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT COUNT(*) FROM reception WHERE pvz_id=$1 AND status='in_progress'",
+		"SELECT "+pvzColumns+" FROM pvz WHERE (registration_date, id) < ($1, $2) ORDER BY registration_date DESC, id DESC LIMIT 2 OFFSET 0",
 	)).
-		WithArgs("p1").
-		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+		WithArgs(after.RegistrationDate, "p2").
+		WillReturnRows(addPVZ(pvzRows(), "p1", "Москва", after.RegistrationDate))
 
-	_, err := r.OpenReception(context.Background(), "p1")
-	assert.EqualError(t, err, "open reception exists")
+	pvzs, err := r.ListPVZ(context.Background(), model.PVZFilter{Limit: 2, After: &after})
+	assert.NoError(t, err)
+	assert.Len(t, pvzs, 1)
+	assert.Equal(t, "p1", pvzs[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListPVZ_ScanError(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + pvzColumns + " FROM pvz")).
+		WillReturnRows(pvzRows().AddRow("p1", "Москва", time.Now(), "", nil, nil, "Europe/Moscow", []byte("{"), nil, "active"))
+
+	_, err := r.ListPVZ(context.Background(), model.PVZFilter{Limit: 10})
+	assert.Error(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + pvzColumns + " FROM pvz")).
+		WillReturnRows(addPVZ(pvzRows(), "p1", "Москва", time.Now()).RowError(0, errors.New("connection reset")))
+
+	_, err = r.ListPVZ(context.Background(), model.PVZFilter{Limit: 10})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestOpenReception_Success(t *testing.T) {
 	r, mock := setupMockRepo(t)
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT COUNT(*) FROM reception WHERE pvz_id=$1 AND status='in_progress'",
+		"INSERT INTO reception (id,pvz_id,status,manifest) VALUES ($1,$2,'in_progress',$3) RETURNING date_time",
 	)).
-		WithArgs("p1").
-		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
-	mock.ExpectQuery(regexp.QuoteMeta(
-		"INSERT INTO reception (id,pvz_id,status) VALUES ($1,$2,'in_progress') RETURNING date_time",
-	)).
-		WithArgs(pgxmock.AnyArg(), "p1").
+		WithArgs(pgxmock.AnyArg(), "p1", []byte(nil)).
 		WillReturnRows(pgxmock.NewRows([]string{"date_time"}).AddRow(time.Now().UTC()))
 
-	rec, err := r.OpenReception(context.Background(), "p1")
+	rec, err := r.OpenReception(context.Background(), "p1", nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "p1", rec.PVZID)
+	assert.Nil(t, rec.Manifest)
 }
 
-func TestAddProduct_Success(t *testing.T) {
+func TestOpenReception_WithManifest(t *testing.T) {
 	r, mock := setupMockRepo(t)
+	m := &model.Manifest{Supplier: "ООО Поставка", Items: []model.ManifestItem{{Type: "обувь", Count: 3}}}
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"INSERT INTO product (id,reception_id,type) VALUES ($1,$2,$3) RETURNING date_time",
+		"INSERT INTO reception (id,pvz_id,status,manifest) VALUES ($1,$2,'in_progress',$3) RETURNING date_time",
 	)).
-		WithArgs(pgxmock.AnyArg(), "r1", "электроника").
+		WithArgs(pgxmock.AnyArg(), "p1", []byte(`{"supplier":"ООО Поставка","items":[{"type":"обувь","count":3}]}`)).
 		WillReturnRows(pgxmock.NewRows([]string{"date_time"}).AddRow(time.Now().UTC()))
 
-	prod, err := r.AddProduct(context.Background(), "r1", "электроника")
+	rec, err := r.OpenReception(context.Background(), "p1", m)
+	assert.NoError(t, err)
+	assert.Equal(t, m, rec.Manifest)
+}
+
+func TestAddProduct_Success(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	query := regexp.QuoteMeta(
+		"INSERT INTO product (id,reception_id,type,barcode,sku,weight_grams,length_mm,width_mm,height_mm) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING date_time,seq",
+	)
+	mock.ExpectQuery(query).
+		WithArgs(pgxmock.AnyArg(), "r1", "электроника", (*string)(nil), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil)).
+		WillReturnRows(pgxmock.NewRows([]string{"date_time", "seq"}).AddRow(time.Now().UTC(), int64(1)))
+
+	prod, err := r.AddProduct(context.Background(), "r1", model.Product{Type: "электроника"})
 	assert.NoError(t, err)
 	assert.Equal(t, "r1", prod.ReceptionID)
+	assert.Equal(t, int64(1), prod.Seq)
+
+	weight := 450
+	mock.ExpectQuery(query).
+		WithArgs(pgxmock.AnyArg(), "r1", "обувь", nullIfEmpty("4006381333931"), "SH-42", &weight, 300, 200, 120).
+		WillReturnRows(pgxmock.NewRows([]string{"date_time", "seq"}).AddRow(time.Now().UTC(), int64(2)))
+
+	prod, err = r.AddProduct(context.Background(), "r1", model.Product{
+		Type: "обувь", Barcode: "4006381333931", SKU: "SH-42", WeightGrams: &weight,
+		Dimensions: &model.Dimensions{LengthMm: 300, WidthMm: 200, HeightMm: 120},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "4006381333931", prod.Barcode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddProduct_DuplicateBarcode(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO product")).
+		WithArgs(pgxmock.AnyArg(), "r1", "обувь", nullIfEmpty("4006381333931"), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "product_reception_barcode"})
+
+	_, err := r.AddProduct(context.Background(), "r1", model.Product{Type: "обувь", Barcode: "4006381333931"})
+	assert.True(t, e.IsKind(err, e.KindConflict))
+}
+
+func TestAddProducts(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	at := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	weight := 450
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"INSERT INTO product (id,reception_id,type,barcode,sku,weight_grams,length_mm,width_mm,height_mm) "+
+			"VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9),($10,$11,$12,$13,$14,$15,$16,$17,$18) RETURNING date_time,seq",
+	)).
+		WithArgs(
+			pgxmock.AnyArg(), "r1", "обувь", nullIfEmpty("4006381333931"), "", &weight, (*int)(nil), (*int)(nil), (*int)(nil),
+			pgxmock.AnyArg(), "r1", "одежда", (*string)(nil), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil),
+		).
+		WillReturnRows(pgxmock.NewRows([]string{"date_time", "seq"}).AddRow(at, int64(8)).AddRow(at, int64(7)))
+
+	products, err := r.AddProducts(context.Background(), "r1", []model.Product{
+		{Type: "обувь", Barcode: "4006381333931", WeightGrams: &weight},
+		{Type: "одежда"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, int64(7), products[0].Seq)
+	assert.Equal(t, int64(8), products[1].Seq)
+	assert.Equal(t, at, products[1].DateTime)
+	assert.Equal(t, "r1", products[1].ReceptionID)
+	assert.NotEqual(t, products[0].ID, products[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddProducts_DuplicateBarcode(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO product")).
+		WithArgs(pgxmock.AnyArg(), "r1", "обувь", nullIfEmpty("4006381333931"), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "product_reception_barcode"})
+
+	_, err := r.AddProducts(context.Background(), "r1", []model.Product{{Type: "обувь", Barcode: "4006381333931"}})
+	assert.True(t, e.IsKind(err, e.KindConflict))
+
+	_, err = r.AddProducts(context.Background(), "r1", nil)
+	assert.True(t, e.IsKind(err, e.KindValidation))
+}
+
+func TestProductsByBarcode(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	day := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	weight, length, width, height := 450, 300, 200, 120
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT "+productColumns+" FROM product WHERE barcode=$1 ORDER BY seq DESC LIMIT $2",
+	)).
+		WithArgs("4006381333931", 50).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type", "barcode", "sku", "weight_grams", "length_mm", "width_mm", "height_mm", "seq"}).
+			AddRow("pr1", "r1", day, "обувь", nullIfEmpty("4006381333931"), "SH-42", &weight, &length, &width, &height, int64(7)))
+
+	products, err := r.ProductsByBarcode(context.Background(), "4006381333931", 50)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Equal(t, "4006381333931", products[0].Barcode)
+	assert.Equal(t, &model.Dimensions{LengthMm: 300, WidthMm: 200, HeightMm: 120}, products[0].Dimensions)
+	assert.Equal(t, 450, *products[0].WeightGrams)
+}
+
+func productRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type", "barcode", "sku", "weight_grams", "length_mm", "width_mm", "height_mm", "seq"})
 }
 
 func TestDeleteLastProduct_Success(t *testing.T) {
 	r, mock := setupMockRepo(t)
-	mock.ExpectExec(regexp.QuoteMeta(
-		"DELETE FROM product WHERE id IN ( SELECT id FROM product WHERE reception_id=$1 ORDER BY date_time DESC, id DESC LIMIT 1 )",
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"DELETE FROM product WHERE id IN ( SELECT id FROM product WHERE reception_id=$1 ORDER BY seq DESC LIMIT 1 ) RETURNING " + productColumns,
 	)).
 		WithArgs("r1").
-		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		WillReturnRows(productRows().AddRow("pr1", "r1", time.Now(), "обувь", (*string)(nil), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), int64(9)))
+
+	p, err := r.DeleteLastProduct(context.Background(), "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, "pr1", p.ID)
+	assert.Equal(t, int64(9), p.Seq)
+}
+
+func TestGetReceptionProduct(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+productColumns+" FROM product WHERE barcode = $1 AND reception_id = $2 FOR UPDATE")).
+		WithArgs("4006381333931", "r1").
+		WillReturnRows(productRows().AddRow("pr1", "r1", time.Now(), "обувь", nullIfEmpty("4006381333931"), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), int64(4)))
+
+	p, err := r.GetReceptionProduct(context.Background(), "r1", "", "4006381333931")
+	assert.NoError(t, err)
+	assert.Equal(t, "pr1", p.ID)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+productColumns+" FROM product WHERE id = $1 AND reception_id = $2 FOR UPDATE")).
+		WithArgs("pr2", "r1").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = r.GetReceptionProduct(context.Background(), "r1", "pr2", "")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteProduct_NotFound(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product WHERE id=$1")).
+		WithArgs("pr1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	assert.True(t, e.IsKind(r.DeleteProduct(context.Background(), "pr1"), e.KindNotFound))
+}
+
+func TestRestoreProduct(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	at := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO product (id,reception_id,date_time,type,barcode,sku,weight_grams,length_mm,width_mm,height_mm,seq) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)",
+	)).
+		WithArgs("pr1", "r1", at, "обувь", nullIfEmpty("4006381333931"), "", (*int)(nil), 300, 200, 120, int64(5)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := r.RestoreProduct(context.Background(), model.Product{
+		ID: "pr1", ReceptionID: "r1", DateTime: at, Type: "обувь", Barcode: "4006381333931",
+		Dimensions: &model.Dimensions{LengthMm: 300, WidthMm: 200, HeightMm: 120}, Seq: 5,
+	})
+	assert.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO product (id,reception_id,date_time,type,barcode,sku,weight_grams,length_mm,width_mm,height_mm) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)",
+	)).
+		WithArgs("pr2", "r1", at, "одежда", (*string)(nil), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	err := r.DeleteLastProduct(context.Background(), "r1")
+	err = r.RestoreProduct(context.Background(), model.Product{ID: "pr2", ReceptionID: "r1", DateTime: at, Type: "одежда"})
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetProductType(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE product SET type=$2 WHERE id=$1 RETURNING "+productColumns)).
+		WithArgs("pr1", "одежда").
+		WillReturnRows(productRows().AddRow("pr1", "r1", time.Now(), "одежда", (*string)(nil), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), int64(4)))
+
+	p, err := r.SetProductType(context.Background(), "pr1", "одежда")
+	assert.NoError(t, err)
+	assert.Equal(t, "одежда", p.Type)
+
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE product SET type=$2")).
+		WithArgs("pr1", "мебель").
+		WillReturnError(&pgconn.PgError{Code: "23503", Detail: `Key (type)=(мебель) is not present in table "product_types".`})
+
+	_, err = r.SetProductType(context.Background(), "pr1", "мебель")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
 }
 
 func TestCloseReception_AlreadyClosed(t *testing.T) {
@@ -138,6 +399,7 @@ func TestCloseReception_AlreadyClosed(t *testing.T) {
 
 	err := r.CloseReception(context.Background(), "r1")
 	assert.EqualError(t, err, "reception already closed")
+	assert.True(t, e.IsKind(err, e.KindPrecondition))
 }
 
 func TestCreateUser_Success(t *testing.T) {
@@ -155,13 +417,13 @@ func TestCreateUser_Success(t *testing.T) {
 
 func TestGetUserByEmail_Success(t *testing.T) {
 	r, mock := setupMockRepo(t)
-	cols := []string{"id", "email", "password_hash", "role"}
+	cols := []string{"id", "email", "password_hash", "role", "created_at", "disabled_at"}
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id,email,password_hash,role FROM users WHERE email=$1",
+		"SELECT id,email,password_hash,role,created_at,disabled_at FROM users WHERE email=$1",
 	)).
 		WithArgs("a@b").
 		WillReturnRows(pgxmock.NewRows(cols).
-			AddRow("u1", "a@b", "hsh", "employee"),
+			AddRow("u1", "a@b", "hsh", "employee", time.Now(), nil),
 		)
 
 	u, err := r.GetUserByEmail(context.Background(), "a@b")
@@ -173,27 +435,44 @@ func TestGetUserByEmail_Success(t *testing.T) {
 func TestGetOpenReception_NotFound(t *testing.T) {
 	r, mock := setupMockRepo(t)
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id,pvz_id,date_time,status FROM reception WHERE pvz_id=$1 AND status='in_progress'",
+		"SELECT id,pvz_id,date_time,status,manifest FROM reception WHERE pvz_id=$1 AND status='in_progress' FOR UPDATE",
 	)).
 		WithArgs("p1").
-		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "date_time", "status"}))
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "date_time", "status", "manifest"}))
 	_, err := r.GetOpenReception(context.Background(), "p1")
-	assert.Error(t, err)
+	assert.True(t, e.IsKind(err, e.KindNotFound))
 }
 
 func TestGetOpenReception_Success(t *testing.T) {
 	r, mock := setupMockRepo(t)
 	now := time.Now().UTC()
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id,pvz_id,date_time,status FROM reception WHERE pvz_id=$1 AND status='in_progress'",
+		"SELECT id,pvz_id,date_time,status,manifest FROM reception WHERE pvz_id=$1 AND status='in_progress' FOR UPDATE",
 	)).
 		WithArgs("p1").
-		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "date_time", "status"}).
-			AddRow("r1", "p1", now, "in_progress"),
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "date_time", "status", "manifest"}).
+			AddRow("r1", "p1", now, "in_progress", []byte(`{"items":[{"type":"обувь","count":3}]}`)),
 		)
 	rec, err := r.GetOpenReception(context.Background(), "p1")
 	assert.NoError(t, err)
 	assert.Equal(t, "r1", rec.ID)
+	assert.Equal(t, []model.ManifestItem{{Type: "обувь", Count: 3}}, rec.Manifest.Items)
+}
+
+func TestFindOpenReception_NoLock(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id,pvz_id,date_time,status,manifest FROM reception WHERE pvz_id=$1 AND status='in_progress'",
+	) + "$").
+		WithArgs("p1").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "date_time", "status", "manifest"}).
+			AddRow("r1", "p1", time.Now(), "in_progress", nil),
+		)
+	rec, err := r.FindOpenReception(context.Background(), "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, "r1", rec.ID)
+	assert.Nil(t, rec.Manifest)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestCloseReception_Success(t *testing.T) {
@@ -210,12 +489,89 @@ func TestCloseReception_Success(t *testing.T) {
 
 func TestDeleteLastProduct_None(t *testing.T) {
 	r, mock := setupMockRepo(t)
-	mock.ExpectExec(regexp.QuoteMeta(
-		"DELETE FROM product WHERE id IN ( SELECT id FROM product WHERE reception_id=$1 ORDER BY date_time DESC, id DESC LIMIT 1 )",
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"DELETE FROM product WHERE id IN ( SELECT id FROM product WHERE reception_id=$1 ORDER BY seq DESC LIMIT 1 ) RETURNING " + productColumns,
 	)).
 		WithArgs("r1").
-		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err := r.DeleteLastProduct(context.Background(), "r1")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+}
+
+func TestListPVZWithReceptions_WithFilter(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	day := time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT "+pvzColumns+" FROM pvz WHERE EXISTS (SELECT 1 FROM reception WHERE reception.pvz_id = pvz.id AND (date_time >= $1 AND date_time <= $2)) ORDER BY registration_date DESC, id DESC LIMIT 10 OFFSET 0",
+	)).
+		WithArgs("2025-04-19T00:00:00Z", "2025-04-21T23:59:59Z").
+		WillReturnRows(addPVZ(addPVZ(pvzRows(), "p1", "Москва", day), "p2", "Казань", day))
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id, pvz_id, date_time, status FROM reception WHERE (pvz_id IN ($1,$2) AND date_time >= $3 AND date_time <= $4) ORDER BY date_time, id",
+	)).
+		WithArgs("p1", "p2", "2025-04-19T00:00:00Z", "2025-04-21T23:59:59Z").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "pvz_id", "date_time", "status"}).
+			AddRow("r1", "p1", day, "close").
+			AddRow("r2", "p2", day, "in_progress"))
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT "+productColumns+" FROM product WHERE reception_id IN ($1,$2) ORDER BY seq",
+	)).
+		WithArgs("r1", "r2").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type", "barcode", "sku", "weight_grams", "length_mm", "width_mm", "height_mm", "seq"}).
+			AddRow("pr1", "r1", day, "обувь", (*string)(nil), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), int64(1)).
+			AddRow("pr2", "r1", day, "одежда", nullIfEmpty("CODE-128"), "", (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), int64(2)))
 
-	err := r.DeleteLastProduct(context.Background(), "r1")
+	res, err := r.ListPVZWithReceptions(context.Background(), model.PVZFilter{Start: "2025-04-19T00:00:00Z", End: "2025-04-21T23:59:59Z", Limit: 10})
 	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "p1", res[0].PVZ.ID)
+	assert.Len(t, res[0].Receptions, 1)
+	assert.Len(t, res[0].Receptions[0].Products, 2)
+	assert.Equal(t, "r2", res[1].Receptions[0].Reception.ID)
+	assert.Empty(t, res[1].Receptions[0].Products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListPVZWithReceptions_Empty(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT " + pvzColumns + " FROM pvz ORDER BY registration_date DESC, id DESC LIMIT 10 OFFSET 0",
+	)).
+		WillReturnRows(pvzRows())
+
+	res, err := r.ListPVZWithReceptions(context.Background(), model.PVZFilter{Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOpenReception_UniqueViolation(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"INSERT INTO reception (id,pvz_id,status,manifest) VALUES ($1,$2,'in_progress',$3) RETURNING date_time",
+	)).
+		WithArgs(pgxmock.AnyArg(), "p1", []byte(nil)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "one_open_reception"})
+
+	_, err := r.OpenReception(context.Background(), "p1", nil)
+	assert.True(t, e.IsKind(err, e.KindConflict))
+	assert.Equal(t, "open reception: already exists", e.Message(err))
+}
+
+func TestMapErr(t *testing.T) {
+	cases := []struct {
+		err  error
+		kind e.Kind
+	}{
+		{&pgconn.PgError{Code: "23503", Detail: `Key (pvz_id)=(x) is not present in table "pvz".`}, e.KindNotFound},
+		{&pgconn.PgError{Code: "23503", Detail: `Key (id)=(x) is still referenced from table "reception".`}, e.KindPrecondition},
+		{&pgconn.PgError{Code: "23514"}, e.KindValidation},
+		{&pgconn.PgError{Code: "22P02"}, e.KindValidation},
+		{&pgconn.PgError{Code: "40001"}, e.KindInternal},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.kind, e.KindOf(mapErr("op", tc.err)), tc.err.Error())
+	}
+	assert.Nil(t, mapErr("op", nil))
 }
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+// manifestJSON returns nil for a reception without a manifest so the column
+// stays NULL.
+func manifestJSON(m *model.Manifest) []byte {
+	if m == nil {
+		return nil
+	}
+	b, _ := json.Marshal(m)
+	return b
+}
+
+// jsonList encodes a report list, writing nil as an empty array.
+func jsonList[T any](list []T) []byte {
+	if list == nil {
+		list = []T{}
+	}
+	b, _ := json.Marshal(list)
+	return b
+}
+
+func (r *repo) CountProductsByType(ctx context.Context, receptionID string) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, "SELECT type,count(*) FROM product WHERE reception_id=$1 GROUP BY type", receptionID)
+	if err != nil {
+		return nil, mapErr("count products", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var typ string
+		var n int
+		if err := rows.Scan(&typ, &n); err != nil {
+			return nil, mapErr("scan product count", err)
+		}
+		counts[typ] = n
+	}
+	return counts, mapErr("count products", rows.Err())
+}
+
+func (r *repo) ListReceptionBarcodes(ctx context.Context, receptionID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT barcode FROM product WHERE reception_id=$1 AND barcode IS NOT NULL ORDER BY barcode", receptionID)
+	if err != nil {
+		return nil, mapErr("list reception barcodes", err)
+	}
+	defer rows.Close()
+
+	var barcodes []string
+	for rows.Next() {
+		var b string
+		if err := rows.Scan(&b); err != nil {
+			return nil, mapErr("scan barcode", err)
+		}
+		barcodes = append(barcodes, b)
+	}
+	return barcodes, mapErr("list reception barcodes", rows.Err())
+}
+
+func (r *repo) CreateReceptionReport(ctx context.Context, rep model.ReceptionReport) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO reception_report (reception_id,missing,surplus,unexpected,missing_barcodes,unexpected_barcodes) VALUES ($1,$2,$3,$4,$5,$6)",
+		rep.ReceptionID, jsonList(rep.Missing), jsonList(rep.Surplus), jsonList(rep.Unexpected),
+		jsonList(rep.MissingBarcodes), jsonList(rep.UnexpectedBarcodes),
+	)
+	return mapErr("create reception report", err)
+}
+
+func (r *repo) GetReceptionReport(ctx context.Context, receptionID string) (model.ReceptionReport, error) {
+	rep := model.ReceptionReport{ReceptionID: receptionID}
+	var missing, surplus, unexpected, missingBarcodes, unexpectedBarcodes []byte
+	err := r.db.QueryRow(ctx, `
+        SELECT rc.pvz_id, COALESCE(rc.manifest->>'supplier', ''), rr.missing, rr.surplus, rr.unexpected,
+               rr.missing_barcodes, rr.unexpected_barcodes, rr.created_at, rr.accepted_by, rr.accepted_at, rr.comment
+        FROM reception_report rr
+        JOIN reception rc ON rc.id = rr.reception_id
+        WHERE rr.reception_id=$1`, receptionID,
+	).Scan(&rep.PVZID, &rep.Supplier, &missing, &surplus, &unexpected, &missingBarcodes, &unexpectedBarcodes,
+		&rep.CreatedAt, &rep.AcceptedBy, &rep.AcceptedAt, &rep.Comment)
+	if err != nil {
+		return rep, mapErr("get reception report", err)
+	}
+	for _, f := range []struct {
+		raw []byte
+		dst any
+	}{
+		{missing, &rep.Missing}, {surplus, &rep.Surplus}, {unexpected, &rep.Unexpected},
+		{missingBarcodes, &rep.MissingBarcodes}, {unexpectedBarcodes, &rep.UnexpectedBarcodes},
+	} {
+		if err := json.Unmarshal(f.raw, f.dst); err != nil {
+			return rep, e.Internal("decode reception report", err)
+		}
+	}
+	return rep, nil
+}
+
+// AcceptReceptionReport records an override of the report's discrepancies.
+// A report is accepted once; userID is empty when an API key accepts it.
+func (r *repo) AcceptReceptionReport(ctx context.Context, receptionID, userID, comment string) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE reception_report SET accepted_by=$2, accepted_at=now(), comment=$3 WHERE reception_id=$1 AND accepted_at IS NULL",
+		receptionID, nullIfEmpty(userID), comment,
+	)
+	if err != nil {
+		return mapErr("accept reception report", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.Precondition("reception report already accepted")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func TestCountProductsByType(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT type,count(*) FROM product WHERE reception_id=$1 GROUP BY type")).
+		WithArgs("r1").
+		WillReturnRows(pgxmock.NewRows([]string{"type", "count"}).
+			AddRow("обувь", 2).
+			AddRow("одежда", 5))
+
+	counts, err := r.CountProductsByType(context.Background(), "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"обувь": 2, "одежда": 5}, counts)
+}
+
+func TestListReceptionBarcodes(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT barcode FROM product WHERE reception_id=$1 AND barcode IS NOT NULL ORDER BY barcode")).
+		WithArgs("r1").
+		WillReturnRows(pgxmock.NewRows([]string{"barcode"}).AddRow("4006381333931").AddRow("CODE-128"))
+
+	barcodes, err := r.ListReceptionBarcodes(context.Background(), "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4006381333931", "CODE-128"}, barcodes)
+}
+
+func TestCreateReceptionReport(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO reception_report (reception_id,missing,surplus,unexpected,missing_barcodes,unexpected_barcodes) VALUES ($1,$2,$3,$4,$5,$6)")).
+		WithArgs("r1", []byte(`[{"type":"обувь","expected":3,"received":1}]`), []byte("[]"), []byte("[]"), []byte(`["4006381333931"]`), []byte("[]")).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := r.CreateReceptionReport(context.Background(), model.ReceptionReport{
+		ReceptionID:     "r1",
+		Missing:         []model.DiscrepancyLine{{Type: "обувь", Expected: 3, Received: 1}},
+		MissingBarcodes: []string{"4006381333931"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReceptionReport(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	now := time.Now()
+	mock.ExpectQuery(`FROM reception_report rr\s+JOIN reception rc ON rc.id = rr.reception_id\s+WHERE rr.reception_id=\$1`).
+		WithArgs("r1").
+		WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "supplier", "missing", "surplus", "unexpected", "missing_barcodes", "unexpected_barcodes", "created_at", "accepted_by", "accepted_at", "comment"}).
+			AddRow("p1", "ООО Поставка", []byte("[]"), []byte(`[{"type":"обувь","expected":1,"received":2}]`), []byte("[]"), []byte("[]"), []byte(`["CODE-128"]`), now, (*string)(nil), (*time.Time)(nil), ""))
+
+	rep, err := r.GetReceptionReport(context.Background(), "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", rep.PVZID)
+	assert.Equal(t, "ООО Поставка", rep.Supplier)
+	assert.Empty(t, rep.Missing)
+	assert.Equal(t, []model.DiscrepancyLine{{Type: "обувь", Expected: 1, Received: 2}}, rep.Surplus)
+	assert.Equal(t, []string{"CODE-128"}, rep.UnexpectedBarcodes)
+	assert.Nil(t, rep.AcceptedAt)
+
+	mock.ExpectQuery(`FROM reception_report rr`).
+		WithArgs("r2").
+		WillReturnRows(pgxmock.NewRows([]string{"pvz_id"}))
+	_, err = r.GetReceptionReport(context.Background(), "r2")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+}
+
+func TestAcceptReceptionReport(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	query := regexp.QuoteMeta("UPDATE reception_report SET accepted_by=$2, accepted_at=now(), comment=$3 WHERE reception_id=$1 AND accepted_at IS NULL")
+	mock.ExpectExec(query).
+		WithArgs("r1", nullIfEmpty("u1"), "partial delivery").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	assert.NoError(t, r.AcceptReceptionReport(context.Background(), "r1", "u1", "partial delivery"))
+
+	mock.ExpectExec(query).
+		WithArgs("r1", (*string)(nil), "").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	err := r.AcceptReceptionReport(context.Background(), "r1", "", "")
+	assert.True(t, e.IsKind(err, e.KindPrecondition))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"pvz-backend-service/internal/model"
+)
+
+func (r *repo) CreateRefreshToken(ctx context.Context, t model.RefreshToken) error {
+	sql, args, _ := r.sb.
+		Insert("refresh_tokens").
+		Columns("id", "user_id", "family_id", "token_hash", "expires_at").
+		Values(t.ID, t.UserID, t.FamilyID, t.TokenHash, t.ExpiresAt).
+		ToSql()
+	_, err := r.db.Exec(ctx, sql, args...)
+	return mapErr("create refresh token", err)
+}
+
+func (r *repo) GetRefreshToken(ctx context.Context, hash string) (model.RefreshToken, error) {
+	row := r.db.QueryRow(ctx,
+		"SELECT id,user_id,family_id,token_hash,expires_at,revoked_at,replaced_by FROM refresh_tokens WHERE token_hash=$1 FOR UPDATE",
+		hash,
+	)
+	var t model.RefreshToken
+	if err := row.Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &t.RevokedAt, &t.ReplacedBy); err != nil {
+		return t, mapErr("get refresh token", err)
+	}
+	return t, nil
+}
+
+func (r *repo) RevokeRefreshToken(ctx context.Context, id, replacedBy string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at=now(), replaced_by=$2 WHERE id=$1 AND revoked_at IS NULL",
+		id, nullIfEmpty(replacedBy),
+	)
+	return mapErr("revoke refresh token", err)
+}
+
+func (r *repo) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at=now() WHERE family_id=$1 AND revoked_at IS NULL",
+		familyID,
+	)
+	return mapErr("revoke refresh token family", err)
+}
+
+func (r *repo) RevokeUserTokens(ctx context.Context, userID string) error {
+	if _, err := r.db.Exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL",
+		userID,
+	); err != nil {
+		return mapErr("revoke user refresh tokens", err)
+	}
+	// The cut-off is taken from the clock that stamps iat, so a token issued
+	// right after it is not caught by skew against the database clock.
+	_, err := r.db.Exec(ctx, "UPDATE users SET tokens_revoked_at=$2 WHERE id=$1", userID, time.Now())
+	return mapErr("revoke user access tokens", err)
+}
+
+func (r *repo) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if _, err := r.db.Exec(ctx, "DELETE FROM revoked_tokens WHERE expires_at < now()"); err != nil {
+		return mapErr("purge revoked tokens", err)
+	}
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO revoked_tokens (jti,expires_at) VALUES ($1,$2) ON CONFLICT (jti) DO NOTHING",
+		jti, expiresAt,
+	)
+	return mapErr("revoke access token", err)
+}
+
+// IsAccessTokenRevoked rejects a token revoked by jti or issued before the
+// user's last logout from all sessions, compared to the microsecond. A token
+// from the very microsecond of the cut-off counts as issued after it: the
+// tokens being cut off were issued by earlier requests.
+func (r *repo) IsAccessTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRow(ctx, `
+        SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti=$1)
+            OR EXISTS (SELECT 1 FROM users WHERE id=$2 AND tokens_revoked_at > $3)`,
+		nullIfEmpty(jti), userID, issuedAt.Truncate(time.Microsecond),
+	).Scan(&revoked)
+	return revoked, mapErr("check token revocation", err)
+}
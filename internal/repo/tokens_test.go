@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/lib/e"
+)
+
+func TestGetRefreshToken(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	exp := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := pgxmock.NewRows([]string{"id", "user_id", "family_id", "token_hash", "expires_at", "revoked_at", "replaced_by"}).
+		AddRow("t1", "u1", "f1", "h", exp, nil, nil)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refresh_tokens WHERE token_hash=$1 FOR UPDATE")).
+		WithArgs("h").
+		WillReturnRows(rows)
+
+	tok, err := r.GetRefreshToken(context.Background(), "h")
+	assert.NoError(t, err)
+	assert.Equal(t, "f1", tok.FamilyID)
+	assert.Nil(t, tok.RevokedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refresh_tokens")).WithArgs("x").WillReturnError(pgx.ErrNoRows)
+	_, err = r.GetRefreshToken(context.Background(), "x")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+}
+
+func TestIsAccessTokenRevoked(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	iat := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	jti := "j1"
+	mock.ExpectQuery(regexp.QuoteMeta("FROM revoked_tokens WHERE jti=$1")).
+		WithArgs(&jti, "u1", iat).
+		WillReturnRows(pgxmock.NewRows([]string{"revoked"}).AddRow(true))
+
+	revoked, err := r.IsAccessTokenRevoked(context.Background(), "j1", "u1", iat)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsAccessTokenRevoked_Microseconds(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta("tokens_revoked_at > $3")).
+		WithArgs((*string)(nil), "u1", time.Date(2025, 5, 1, 0, 0, 7, 250000, time.UTC)).
+		WillReturnRows(pgxmock.NewRows([]string{"revoked"}).AddRow(false))
+
+	revoked, err := r.IsAccessTokenRevoked(context.Background(), "", "u1", time.Date(2025, 5, 1, 0, 0, 7, 250999, time.UTC))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1")).
+		WithArgs("u1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET tokens_revoked_at=$2 WHERE id=$1")).
+		WithArgs("u1", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	assert.NoError(t, r.RevokeUserTokens(context.Background(), "u1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"pvz-backend-service/lib/e"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+type Option func(*repo)
+
+func WithIsolation(level pgx.TxIsoLevel) Option {
+	return func(r *repo) { r.isolation = level }
+}
+
+func WithTxRetries(n int) Option {
+	return func(r *repo) { r.txRetries = n }
+}
+
+// WithTx runs fn against a repository bound to a single transaction. The
+// transaction is retried on serialization failures and deadlocks; fn may
+// therefore be called more than once. Nested calls reuse the outer transaction.
+func (r *repo) WithTx(ctx context.Context, fn func(Repository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+	b, ok := r.db.(TxBeginner)
+	if !ok {
+		return e.Internal("transactions are not supported by the database handle", nil)
+	}
+	var err error
+	for attempt := 0; attempt <= r.txRetries; attempt++ {
+		if err = r.runTx(ctx, b, fn); !retryable(err) {
+			return err
+		}
+	}
+	return e.Wrap("transaction retries exhausted", err)
+}
+
+func (r *repo) runTx(ctx context.Context, b TxBeginner, fn func(Repository) error) error {
+	tx, err := b.BeginTx(ctx, pgx.TxOptions{IsoLevel: r.isolation})
+	if err != nil {
+		return e.Wrap("begin tx", err)
+	}
+	txRepo := *r
+	txRepo.db = tx
+	txRepo.inTx = true
+	if err := fn(&txRepo); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+	return mapErr("commit tx", tx.Commit(ctx))
+}
+
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected)
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTx_Commit(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	r := New(mock, WithIsolation(pgx.Serializable))
+
+	mock.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.Serializable})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + pvzColumns + " FROM pvz WHERE id=$1 FOR UPDATE")).
+		WithArgs("p1").
+		WillReturnRows(addPVZ(pvzRows(), "p1", "Москва", time.Now()))
+	mock.ExpectCommit()
+
+	err = r.WithTx(context.Background(), func(tx Repository) error {
+		_, err := tx.LockPVZ(context.Background(), "p1")
+		return err
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_RollbackOnError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	r := New(mock)
+
+	mock.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	mock.ExpectRollback()
+
+	boom := errors.New("boom")
+	err = r.WithTx(context.Background(), func(tx Repository) error { return boom })
+	assert.ErrorIs(t, err, boom)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_RetriesSerializationFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	r := New(mock, WithTxRetries(1))
+
+	mock.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	mock.ExpectCommit().WillReturnError(&pgconn.PgError{Code: "40001"})
+	mock.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	mock.ExpectCommit()
+
+	calls := 0
+	err = r.WithTx(context.Background(), func(tx Repository) error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_RetriesExhausted(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	r := New(mock, WithTxRetries(0))
+
+	mock.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	mock.ExpectRollback()
+
+	err = r.WithTx(context.Background(), func(tx Repository) error {
+		return &pgconn.PgError{Code: "40P01"}
+	})
+	assert.ErrorContains(t, err, "transaction retries exhausted")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithTx_Nested(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	r := New(mock)
+
+	mock.ExpectBeginTx(pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	mock.ExpectCommit()
+
+	err = r.WithTx(context.Background(), func(tx Repository) error {
+		return tx.WithTx(context.Background(), func(inner Repository) error {
+			assert.Same(t, tx, inner)
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
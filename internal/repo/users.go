@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+const userColumns = "id,email,password_hash,role,created_at,disabled_at"
+
+func scanUser(row interface{ Scan(...any) error }) (model.User, error) {
+	var u model.User
+	err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.CreatedAt, &u.DisabledAt)
+	return u, err
+}
+
+func (r *repo) GetUserByEmail(ctx context.Context, email string) (model.User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx, "SELECT "+userColumns+" FROM users WHERE email=$1", email))
+	return u, mapErr("get user", err)
+}
+
+func (r *repo) GetUserByID(ctx context.Context, id string) (model.User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx, "SELECT "+userColumns+" FROM users WHERE id=$1", id))
+	return u, mapErr("get user", err)
+}
+
+func (r *repo) ListUsers(ctx context.Context, f model.UserFilter) ([]model.User, error) {
+	b := r.sb.
+		Select(userColumns).
+		From("users").
+		OrderBy("created_at DESC", "id").
+		Limit(uint64(f.Limit)).Offset(uint64(f.Offset))
+	if f.Query != "" {
+		b = b.Where(sq.ILike{"email": "%" + escapeLike(f.Query) + "%"})
+	}
+	if f.Role != "" {
+		b = b.Where(sq.Eq{"role": f.Role})
+	}
+	sql, args, _ := b.ToSql()
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapErr("list users", err)
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, mapErr("scan user", err)
+		}
+		users = append(users, u)
+	}
+	return users, mapErr("list users", rows.Err())
+}
+
+func (r *repo) UpdateUserRole(ctx context.Context, id, role string) error {
+	return r.updateUser(ctx, "update user role", "UPDATE users SET role=$2 WHERE id=$1", id, role)
+}
+
+func (r *repo) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	if disabled {
+		return r.updateUser(ctx, "disable user", "UPDATE users SET disabled_at=COALESCE(disabled_at, now()) WHERE id=$1", id)
+	}
+	return r.updateUser(ctx, "enable user", "UPDATE users SET disabled_at=NULL WHERE id=$1", id)
+}
+
+func (r *repo) UpdateUserPassword(ctx context.Context, id, hash string) error {
+	return r.updateUser(ctx, "update user password", "UPDATE users SET password_hash=$2 WHERE id=$1", id, hash)
+}
+
+func (r *repo) updateUser(ctx context.Context, msg, sql string, args ...any) error {
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return mapErr(msg, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.NotFound("user not found")
+	}
+	return nil
+}
+
+func (r *repo) CreateInvite(ctx context.Context, inv model.Invite) error {
+	sql, args, _ := r.sb.
+		Insert("invites").
+		Columns("id", "email", "role", "token_hash", "invited_by", "expires_at").
+		Values(inv.ID, inv.Email, inv.Role, inv.TokenHash, nullIfEmpty(inv.InvitedBy), inv.ExpiresAt).
+		ToSql()
+	_, err := r.db.Exec(ctx, sql, args...)
+	return mapErr("create invite", err)
+}
+
+func (r *repo) GetInvite(ctx context.Context, tokenHash string) (model.Invite, error) {
+	row := r.db.QueryRow(ctx,
+		"SELECT id,email,role,token_hash,COALESCE(invited_by::text,''),expires_at,accepted_at FROM invites WHERE token_hash=$1 FOR UPDATE",
+		tokenHash,
+	)
+	var inv model.Invite
+	if err := row.Scan(&inv.ID, &inv.Email, &inv.Role, &inv.TokenHash, &inv.InvitedBy, &inv.ExpiresAt, &inv.AcceptedAt); err != nil {
+		return inv, mapErr("get invite", err)
+	}
+	return inv, nil
+}
+
+func (r *repo) AcceptInvite(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, "UPDATE invites SET accepted_at=now() WHERE id=$1 AND accepted_at IS NULL", id)
+	if err != nil {
+		return mapErr("accept invite", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return e.Precondition("invite already accepted")
+	}
+	return nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
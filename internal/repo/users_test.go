@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func TestListUsers_Filter(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	disabled := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := pgxmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at", "disabled_at"}).
+		AddRow("u1", "ann_1@pvz.ru", "h", "employee", disabled, &disabled)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id,email,password_hash,role,created_at,disabled_at FROM users WHERE email ILIKE $1 AND role = $2 ORDER BY created_at DESC, id LIMIT 10 OFFSET 20",
+	)).
+		WithArgs(`%ann\_1%`, "employee").
+		WillReturnRows(rows)
+
+	users, err := r.ListUsers(context.Background(), model.UserFilter{Query: "ann_1", Role: "employee", Limit: 10, Offset: 20})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NotNil(t, users[0].DisabledAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUserRole_NotFound(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET role=$2 WHERE id=$1")).
+		WithArgs("u1", "moderator").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := r.UpdateUserRole(context.Background(), "u1", "moderator")
+	assert.True(t, e.IsKind(err, e.KindNotFound))
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET disabled_at=COALESCE(disabled_at, now()) WHERE id=$1")).
+		WithArgs("u1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET disabled_at=NULL WHERE id=$1")).
+		WithArgs("u1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	assert.NoError(t, r.SetUserDisabled(context.Background(), "u1", true))
+	assert.NoError(t, r.SetUserDisabled(context.Background(), "u1", false))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptInvite_AlreadyAccepted(t *testing.T) {
+	r, mock := setupMockRepo(t)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE invites SET accepted_at=now() WHERE id=$1 AND accepted_at IS NULL")).
+		WithArgs("i1").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := r.AcceptInvite(context.Background(), "i1")
+	assert.True(t, e.IsKind(err, e.KindPrecondition))
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+// validScopes rejects unknown permissions and any scope the actor does not
+// hold itself, so that keys cannot escalate privileges.
+func (s *service) validScopes(actor model.Actor, scopes []string) error {
+	if len(scopes) == 0 {
+		return e.Validation("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		perm := auth.Permission(scope)
+		if !slices.Contains(auth.AllPermissions, perm) {
+			return e.Validation("unknown scope %q", scope)
+		}
+		if !s.perms.ActorAllowed(actor, perm) {
+			return e.Forbidden("access forbidden: cannot grant scope %s", scope)
+		}
+	}
+	return nil
+}
+
+// newAPIKey stores a key and returns it with the secret, which is shown only
+// once.
+func newAPIKey(ctx context.Context, r repo.Repository, actor model.Actor, name string, scopes []string, expiresAt *time.Time) (model.APIKey, string, error) {
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+	k, err := r.CreateAPIKey(ctx, model.APIKey{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		CreatedBy: actor.UserID,
+		ExpiresAt: expiresAt,
+	})
+	return k, key, err
+}
+
+func (s *service) CreateAPIKey(ctx context.Context, actor model.Actor, name string, scopes []string, expiresAt *time.Time) (model.APIKey, string, error) {
+	if err := s.require(actor, auth.PermAPIKeyManage); err != nil {
+		return model.APIKey{}, "", err
+	}
+	if strings.TrimSpace(name) == "" {
+		return model.APIKey{}, "", e.Validation("name is required")
+	}
+	if err := s.validScopes(actor, scopes); err != nil {
+		return model.APIKey{}, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return model.APIKey{}, "", e.Validation("expiresAt must be in the future")
+	}
+	k, key, err := newAPIKey(ctx, s.repo, actor, name, scopes, expiresAt)
+	return k, key, e.WrapIfErr("failed to create api key", err)
+}
+
+func (s *service) ListAPIKeys(ctx context.Context, actor model.Actor) ([]model.APIKey, error) {
+	if err := s.require(actor, auth.PermAPIKeyManage); err != nil {
+		return nil, err
+	}
+	keys, err := s.repo.ListAPIKeys(ctx)
+	return keys, e.WrapIfErr("could not list api keys", err)
+}
+
+// RotateAPIKey issues a replacement with the same name, scopes and expiry and
+// revokes the old key.
+func (s *service) RotateAPIKey(ctx context.Context, actor model.Actor, id string) (model.APIKey, string, error) {
+	if err := s.require(actor, auth.PermAPIKeyManage); err != nil {
+		return model.APIKey{}, "", err
+	}
+	var (
+		k   model.APIKey
+		key string
+	)
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		old, err := r.GetAPIKey(ctx, id)
+		if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+			return e.NotFound("api key not found")
+		}
+		if err != nil {
+			return e.Wrap("failed to rotate api key", err)
+		}
+		if old.RevokedAt != nil {
+			return e.Precondition("api key already revoked")
+		}
+		if err := s.validScopes(actor, old.Scopes); err != nil {
+			return err
+		}
+		if k, key, err = newAPIKey(ctx, r, actor, old.Name, old.Scopes, old.ExpiresAt); err != nil {
+			return e.Wrap("failed to rotate api key", err)
+		}
+		return e.WrapIfErr("failed to rotate api key", r.RevokeAPIKey(ctx, old.ID, k.ID))
+	})
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+	return k, key, nil
+}
+
+func (s *service) RevokeAPIKey(ctx context.Context, actor model.Actor, id string) error {
+	if err := s.require(actor, auth.PermAPIKeyManage); err != nil {
+		return err
+	}
+	err := s.repo.RevokeAPIKey(ctx, id, "")
+	if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+		return e.NotFound("api key not found")
+	}
+	return e.WrapIfErr("failed to revoke api key", err)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type apiKeyRepo struct {
+	repo.Repository
+	keys map[string]model.APIKey
+}
+
+func (r *apiKeyRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+func (r *apiKeyRepo) CreateAPIKey(_ context.Context, k model.APIKey) (model.APIKey, error) {
+	k.CreatedAt = time.Now()
+	r.keys[k.ID] = k
+	return k, nil
+}
+func (r *apiKeyRepo) GetAPIKey(_ context.Context, id string) (model.APIKey, error) {
+	k, ok := r.keys[id]
+	if !ok {
+		return model.APIKey{}, e.NotFound("get api key: not found")
+	}
+	return k, nil
+}
+func (r *apiKeyRepo) RevokeAPIKey(_ context.Context, id, _ string) error {
+	k, ok := r.keys[id]
+	if !ok || k.RevokedAt != nil {
+		return e.NotFound("revoke api key: not found")
+	}
+	now := time.Now()
+	k.RevokedAt = &now
+	r.keys[id] = k
+	return nil
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	r := &apiKeyRepo{keys: map[string]model.APIKey{}}
+	svc := New(r, tokens)
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+
+	_, _, err := svc.CreateAPIKey(ctx, model.Actor{UserID: "u1", Role: RoleEmployee}, "erp", []string{"pvz:read"}, nil)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	for _, tc := range []struct {
+		name      string
+		scopes    []string
+		expiresAt *time.Time
+		kind      e.Kind
+	}{
+		{"", []string{"pvz:read"}, nil, e.KindValidation},
+		{"erp", nil, nil, e.KindValidation},
+		{"erp", []string{"pvz:delete"}, nil, e.KindValidation},
+		{"erp", []string{"pvz:read"}, &past, e.KindValidation},
+		{"erp", []string{"user:admin"}, nil, e.KindForbidden},
+		{"erp", []string{"reception:open"}, nil, e.KindForbidden},
+		{"erp", []string{"pvz:read", "pvz:all"}, nil, e.KindForbidden},
+	} {
+		_, _, err := svc.CreateAPIKey(ctx, moderator, tc.name, tc.scopes, tc.expiresAt)
+		assert.Equal(t, tc.kind, e.KindOf(err), "%q %v", tc.name, tc.scopes)
+	}
+
+	admin := model.Actor{UserID: "u3", Role: RoleAdmin}
+	k, key, err := svc.CreateAPIKey(ctx, admin, "erp", []string{"reception:open", "pvz:all"}, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, "pvz_"+k.Prefix+"_"))
+	assert.NotEqual(t, key, k.KeyHash)
+	assert.Equal(t, admin.UserID, k.CreatedBy)
+
+	k, _, err = svc.CreateAPIKey(ctx, moderator, "catalog", []string{"pvz:read", "catalog:manage"}, nil)
+	require.NoError(t, err)
+	_, _, err = svc.CreateAPIKey(ctx, model.Actor{APIKeyID: k.ID, Scopes: []string{"apikey:manage", "pvz:read"}}, "sub", []string{"catalog:manage"}, nil)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err), "keys are limited by their own scopes")
+}
+
+func TestRotateAndRevokeAPIKey(t *testing.T) {
+	r := &apiKeyRepo{keys: map[string]model.APIKey{}}
+	svc := New(r, tokens)
+	ctx := context.Background()
+
+	old, oldKey, err := svc.CreateAPIKey(ctx, model.Actor{UserID: "u3", Role: RoleAdmin}, "erp", []string{"product:add"}, nil)
+	require.NoError(t, err)
+	_, _, err = svc.RotateAPIKey(ctx, moderator, old.ID)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err), "moderators cannot rotate a key with scopes they lack")
+	old, oldKey, err = svc.CreateAPIKey(ctx, moderator, "erp", []string{"pvz:read"}, nil)
+	require.NoError(t, err)
+
+	k, key, err := svc.RotateAPIKey(ctx, moderator, old.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, old.ID, k.ID)
+	assert.NotEqual(t, oldKey, key)
+	assert.Equal(t, old.Name, k.Name)
+	assert.Equal(t, old.Scopes, k.Scopes)
+	assert.NotNil(t, r.keys[old.ID].RevokedAt)
+
+	_, _, err = svc.RotateAPIKey(ctx, moderator, old.ID)
+	assert.Equal(t, e.KindPrecondition, e.KindOf(err))
+	_, _, err = svc.RotateAPIKey(ctx, moderator, "missing")
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+
+	assert.Equal(t, e.KindForbidden, e.KindOf(svc.RevokeAPIKey(ctx, model.Actor{UserID: "u1", Role: RoleAuditor}, k.ID)))
+	assert.NoError(t, svc.RevokeAPIKey(ctx, moderator, k.ID))
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.RevokeAPIKey(ctx, moderator, k.ID)))
+}
+
+func TestRequirePVZ_APIKey(t *testing.T) {
+	svc := New(newAssignmentRepo(), tokens)
+	key := model.Actor{APIKeyID: "k1", Scopes: []string{"reception:open"}}
+	_, err := svc.OpenReception(context.Background(), key, "p1", nil)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	key.Scopes = append(key.Scopes, "pvz:all")
+	_, err = svc.OpenReception(context.Background(), key, "p1", nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+var ErrNotAssigned = e.Forbidden("access forbidden: not assigned to this PVZ")
+
+// requirePVZ checks perm and, unless the role may work at any PVZ, that the
+// actor is assigned to pvzID. API keys cannot be assigned, so they need
+// pvz:all.
+func (s *service) requirePVZ(ctx context.Context, r repo.Repository, actor model.Actor, perm auth.Permission, pvzID string) error {
+	if err := s.require(actor, perm); err != nil {
+		return err
+	}
+	if s.perms.ActorAllowed(actor, auth.PermPVZAll) {
+		return nil
+	}
+	if actor.APIKeyID != "" {
+		return e.Forbidden("access forbidden: %s scope required", auth.PermPVZAll)
+	}
+	ok, err := r.IsAssigned(ctx, actor.UserID, pvzID)
+	if err != nil {
+		return e.Wrap("failed to check PVZ assignment", err)
+	}
+	if !ok {
+		return ErrNotAssigned
+	}
+	return nil
+}
+
+func (s *service) ListPVZEmployees(ctx context.Context, actor model.Actor, pvzID string) ([]model.User, error) {
+	if err := s.require(actor, auth.PermUserRead); err != nil {
+		return nil, err
+	}
+	users, err := s.repo.ListPVZEmployees(ctx, pvzID)
+	return users, e.WrapIfErr("could not list PVZ employees", err)
+}
+
+func (s *service) AssignEmployee(ctx context.Context, actor model.Actor, pvzID, userID string) error {
+	if err := s.require(actor, auth.PermPVZAssign); err != nil {
+		return err
+	}
+	return s.repo.WithTx(ctx, func(r repo.Repository) error {
+		if _, err := r.LockPVZ(ctx, pvzID); e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+			return e.NotFound("pvz not found")
+		} else if err != nil {
+			return e.Wrap("failed to assign employee", err)
+		}
+		user, err := r.GetUserByID(ctx, userID)
+		if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+			return e.NotFound("user not found")
+		}
+		if err != nil {
+			return e.Wrap("failed to assign employee", err)
+		}
+		if !s.perms.Allowed(user.Role, auth.PermReceptionOpen) {
+			return e.Validation("%s users do not work at a PVZ", user.Role)
+		}
+		return e.WrapIfErr("failed to assign employee", r.AssignEmployee(ctx, pvzID, userID, actor.UserID))
+	})
+}
+
+func (s *service) UnassignEmployee(ctx context.Context, actor model.Actor, pvzID, userID string) error {
+	if err := s.require(actor, auth.PermPVZAssign); err != nil {
+		return err
+	}
+	err := s.repo.UnassignEmployee(ctx, pvzID, userID)
+	if e.IsKind(err, e.KindNotFound) {
+		return e.NotFound("assignment not found")
+	}
+	return e.WrapIfErr("failed to unassign employee", err)
+}
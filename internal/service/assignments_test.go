@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type assignmentRepo struct {
+	*sessionRepo
+	assigned map[string]bool
+}
+
+func newAssignmentRepo() *assignmentRepo {
+	r := &assignmentRepo{sessionRepo: newSessionRepo(), assigned: map[string]bool{}}
+	r.users["u3"] = model.User{ID: "u3", Email: "audit@pvz.ru", Role: RoleAuditor}
+	return r
+}
+
+func (r *assignmentRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+func (r *assignmentRepo) LockPVZ(_ context.Context, pvzID string) (model.PVZ, error) {
+	if pvzID != "p1" {
+		return model.PVZ{}, e.NotFound("lock pvz: not found")
+	}
+	return model.PVZ{ID: pvzID, Status: PVZActive}, nil
+}
+func (r *assignmentRepo) AssignEmployee(_ context.Context, pvzID, userID, _ string) error {
+	r.assigned[pvzID+"/"+userID] = true
+	return nil
+}
+func (r *assignmentRepo) UnassignEmployee(_ context.Context, pvzID, userID string) error {
+	if !r.assigned[pvzID+"/"+userID] {
+		return e.NotFound("unassign employee: not found")
+	}
+	delete(r.assigned, pvzID+"/"+userID)
+	return nil
+}
+func (r *assignmentRepo) IsAssigned(_ context.Context, userID, pvzID string) (bool, error) {
+	return r.assigned[pvzID+"/"+userID], nil
+}
+func (r *assignmentRepo) GetOpenReception(_ context.Context, pvzID string) (model.Reception, error) {
+	return model.Reception{}, e.NotFound("get open reception: not found")
+}
+func (r *assignmentRepo) OpenReception(_ context.Context, pvzID string, _ *model.Manifest) (model.Reception, error) {
+	return model.Reception{ID: "r1", PVZID: pvzID, Status: "in_progress"}, nil
+}
+
+func TestAssignEmployee(t *testing.T) {
+	r := newAssignmentRepo()
+	svc := New(r, tokens)
+	ctx := context.Background()
+	emp := model.Actor{UserID: "u1", Role: RoleEmployee}
+
+	_, err := svc.OpenReception(ctx, emp, "p1", nil)
+	assert.ErrorIs(t, err, ErrNotAssigned)
+
+	assert.Equal(t, e.KindForbidden, e.KindOf(svc.AssignEmployee(ctx, emp, "p1", "u1")))
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.AssignEmployee(ctx, moderator, "p2", "u1")))
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.AssignEmployee(ctx, moderator, "p1", "u9")))
+	assert.Equal(t, e.KindValidation, e.KindOf(svc.AssignEmployee(ctx, moderator, "p1", "u3")))
+	assert.NoError(t, svc.AssignEmployee(ctx, moderator, "p1", "u1"))
+
+	rec, err := svc.OpenReception(ctx, emp, "p1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", rec.PVZID)
+
+	assert.NoError(t, svc.UnassignEmployee(ctx, moderator, "p1", "u1"))
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.UnassignEmployee(ctx, moderator, "p1", "u1")))
+	_, err = svc.AddProduct(ctx, emp, "p1", model.Product{Type: "обувь"})
+	assert.ErrorIs(t, err, ErrNotAssigned)
+	assert.ErrorIs(t, svc.DeleteLastProduct(ctx, emp, "p1"), ErrNotAssigned)
+	_, err = svc.CloseReception(ctx, emp, "p1")
+	assert.ErrorIs(t, err, ErrNotAssigned)
+
+	_, err = svc.OpenReception(ctx, model.Actor{UserID: "u5", Role: RoleAdmin}, "p1", nil)
+	assert.NoError(t, err)
+}
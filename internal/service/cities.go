@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func cityName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", e.Validation("city name is required")
+	}
+	return name, nil
+}
+
+func (s *service) ListCities(ctx context.Context, actor model.Actor) ([]model.City, error) {
+	if err := s.require(actor, auth.PermPVZRead); err != nil {
+		return nil, err
+	}
+	cities, err := s.repo.ListCities(ctx)
+	return cities, e.WrapIfErr("could not list cities", err)
+}
+
+func (s *service) CreateCity(ctx context.Context, actor model.Actor, name string) (model.City, error) {
+	if err := s.require(actor, auth.PermCityManage); err != nil {
+		return model.City{}, err
+	}
+	name, err := cityName(name)
+	if err != nil {
+		return model.City{}, err
+	}
+	city, err := s.repo.CreateCity(ctx, name)
+	if e.IsKind(err, e.KindConflict) {
+		return model.City{}, e.Conflict("city %q already exists", name)
+	}
+	return city, e.WrapIfErr("failed to create city", err)
+}
+
+// RenameCity renames a city together with every PVZ registered in it.
+func (s *service) RenameCity(ctx context.Context, actor model.Actor, name, newName string) (model.City, error) {
+	if err := s.require(actor, auth.PermCityManage); err != nil {
+		return model.City{}, err
+	}
+	newName, err := cityName(newName)
+	if err != nil {
+		return model.City{}, err
+	}
+	city, err := s.repo.RenameCity(ctx, name, newName)
+	switch {
+	case e.IsKind(err, e.KindNotFound):
+		return model.City{}, e.NotFound("city not found")
+	case e.IsKind(err, e.KindConflict):
+		return model.City{}, e.Conflict("city %q already exists", newName)
+	}
+	return city, e.WrapIfErr("failed to rename city", err)
+}
+
+// DeleteCity removes a city that no PVZ is registered in.
+func (s *service) DeleteCity(ctx context.Context, actor model.Actor, name string) error {
+	if err := s.require(actor, auth.PermCityManage); err != nil {
+		return err
+	}
+	err := s.repo.DeleteCity(ctx, name)
+	switch {
+	case e.IsKind(err, e.KindNotFound):
+		return e.NotFound("city not found")
+	case e.IsKind(err, e.KindPrecondition):
+		return e.Precondition("city %q still has PVZs", name)
+	}
+	return e.WrapIfErr("failed to delete city", err)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type cityRepo struct {
+	repo.Repository
+	cities map[string]bool
+	inUse  map[string]bool
+}
+
+func newCityRepo() *cityRepo {
+	return &cityRepo{cities: map[string]bool{"Москва": true, "Казань": true}, inUse: map[string]bool{"Москва": true}}
+}
+
+func (r *cityRepo) ListCities(context.Context) ([]model.City, error) {
+	var list []model.City
+	for name := range r.cities {
+		list = append(list, model.City{Name: name})
+	}
+	return list, nil
+}
+func (r *cityRepo) CreateCity(_ context.Context, name string) (model.City, error) {
+	if r.cities[name] {
+		return model.City{}, e.Conflict("create city: already exists")
+	}
+	r.cities[name] = true
+	return model.City{Name: name, CreatedAt: time.Now()}, nil
+}
+func (r *cityRepo) RenameCity(_ context.Context, name, newName string) (model.City, error) {
+	if !r.cities[name] {
+		return model.City{}, e.NotFound("rename city: not found")
+	}
+	if r.cities[newName] {
+		return model.City{}, e.Conflict("rename city: already exists")
+	}
+	delete(r.cities, name)
+	r.cities[newName] = true
+	return model.City{Name: newName}, nil
+}
+func (r *cityRepo) DeleteCity(_ context.Context, name string) error {
+	if r.inUse[name] {
+		return e.Precondition("delete city: record is still referenced")
+	}
+	if !r.cities[name] {
+		return e.NotFound("delete city: not found")
+	}
+	delete(r.cities, name)
+	return nil
+}
+
+func TestCities(t *testing.T) {
+	r := newCityRepo()
+	svc := New(r, tokens)
+	ctx := context.Background()
+
+	list, err := svc.ListCities(ctx, employee)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	_, err = svc.CreateCity(ctx, employee, "Тверь")
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.CreateCity(ctx, moderator, "  ")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.CreateCity(ctx, moderator, "Казань")
+	assert.EqualError(t, err, `city "Казань" already exists`)
+
+	c, err := svc.CreateCity(ctx, moderator, " Тверь ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Тверь", c.Name)
+
+	_, err = svc.RenameCity(ctx, moderator, "Питер", "Санкт-Петербург")
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+	_, err = svc.RenameCity(ctx, moderator, "Тверь", "Казань")
+	assert.Equal(t, e.KindConflict, e.KindOf(err))
+	c, err = svc.RenameCity(ctx, moderator, "Тверь", "Торжок")
+	assert.NoError(t, err)
+	assert.Equal(t, "Торжок", c.Name)
+
+	assert.EqualError(t, svc.DeleteCity(ctx, moderator, "Москва"), `city "Москва" still has PVZs`)
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.DeleteCity(ctx, moderator, "Тверь")))
+	assert.NoError(t, svc.DeleteCity(ctx, moderator, "Торжок"))
+}
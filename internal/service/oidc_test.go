@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/auth/oidctest"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type oidcRepo struct {
+	*sessionRepo
+	logins     map[string]model.OIDCLogin
+	identities map[string]string
+}
+
+func newOIDCRepo() *oidcRepo {
+	return &oidcRepo{sessionRepo: newSessionRepo(), logins: map[string]model.OIDCLogin{}, identities: map[string]string{}}
+}
+
+func (r *oidcRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+func (r *oidcRepo) CreateOIDCLogin(_ context.Context, l model.OIDCLogin) error {
+	r.logins[l.StateHash] = l
+	return nil
+}
+func (r *oidcRepo) ConsumeOIDCLogin(_ context.Context, stateHash string) (model.OIDCLogin, error) {
+	l, ok := r.logins[stateHash]
+	if !ok {
+		return model.OIDCLogin{}, e.NotFound("consume oidc login: not found")
+	}
+	delete(r.logins, stateHash)
+	return l, nil
+}
+func (r *oidcRepo) GetUserByIdentity(ctx context.Context, issuer, subject string) (model.User, error) {
+	id, ok := r.identities[issuer+"|"+subject]
+	if !ok {
+		return model.User{}, e.NotFound("get user by identity: not found")
+	}
+	return r.GetUserByID(ctx, id)
+}
+func (r *oidcRepo) LinkUserIdentity(_ context.Context, issuer, subject, userID string) error {
+	r.identities[issuer+"|"+subject] = userID
+	return nil
+}
+
+func newOIDCService(t *testing.T) (*oidctest.Provider, *oidcRepo, Service) {
+	idp := oidctest.New(t, "pvz", "")
+	p := auth.NewOIDCProvider(auth.OIDCConfig{
+		Issuer:      idp.URL,
+		ClientID:    "pvz",
+		RedirectURL: "https://pvz.example/oidc/callback",
+		RoleClaim:   "groups",
+		RoleMapping: []auth.RoleMapping{{Value: "pvz-moderators", Role: RoleModerator}, {Value: "pvz-staff", Role: RoleEmployee}},
+	})
+	r := newOIDCRepo()
+	return idp, r, New(r, tokens, WithOIDC(p))
+}
+
+func ssoLogin(t *testing.T, idp *oidctest.Provider, svc Service, claims map[string]any) (model.TokenPair, error) {
+	authURL, err := svc.OIDCLoginURL(context.Background())
+	require.NoError(t, err)
+	code, state, err := idp.Authorize(authURL, claims)
+	require.NoError(t, err)
+	return svc.OIDCCallback(context.Background(), state, code)
+}
+
+func TestOIDC_ProvisionsAndLinksUser(t *testing.T) {
+	idp, r, svc := newOIDCService(t)
+	claims := map[string]any{"sub": "alice", "email": "Alice@Corp.ru", "email_verified": true, "groups": []string{"pvz-staff"}}
+
+	pair, err := ssoLogin(t, idp, svc, claims)
+	require.NoError(t, err)
+	user, err := r.GetUserByEmail(context.Background(), "alice@corp.ru")
+	require.NoError(t, err)
+	assert.Equal(t, RoleEmployee, user.Role)
+	assert.Empty(t, user.PasswordHash)
+	c, err := tokens.ParseToken(pair.Token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, c.Subject)
+	_, err = svc.Login(context.Background(), "alice@corp.ru", "", "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials, "SSO accounts have no local password")
+
+	claims["groups"] = []string{"pvz-moderators"}
+	claims["email"] = "renamed@corp.ru"
+	pair, err = ssoLogin(t, idp, svc, claims)
+	require.NoError(t, err)
+	c, _ = tokens.ParseToken(pair.Token)
+	assert.Equal(t, user.ID, c.Subject, "linked by subject, not email")
+	assert.Equal(t, RoleModerator, c.Role, "role follows the provider")
+	assert.True(t, r.revokedUsers[user.ID])
+	assert.Len(t, r.users, 2)
+}
+
+func TestOIDC_RoleChangeTokenIsUsable(t *testing.T) {
+	idp, r, svc := newOIDCService(t)
+	ctx := context.Background()
+	tm := auth.NewTokenManager(auth.NewHMACKeySet("secret"), time.Minute, time.Hour, r)
+	r.identities[idp.URL+"|carol"] = "u1"
+
+	old, err := ssoLogin(t, idp, svc, map[string]any{"sub": "carol", "groups": []string{"pvz-staff"}})
+	require.NoError(t, err)
+	require.False(t, r.revokedUsers["u1"])
+	oldClaims, err := tm.Verify(ctx, old.Token)
+	require.NoError(t, err)
+
+	pair, err := ssoLogin(t, idp, svc, map[string]any{"sub": "carol", "groups": []string{"pvz-moderators"}})
+	require.NoError(t, err)
+	require.True(t, r.revokedUsers["u1"])
+	claims, err := tm.Verify(ctx, pair.Token)
+	require.NoError(t, err, "the token of the new session survives the revocation")
+	assert.Equal(t, RoleModerator, claims.Role)
+	_, err = tm.Verify(ctx, old.Token)
+	assert.EqualError(t, err, "token revoked")
+
+	// The old token stays revoked when it was issued in the same second as
+	// the role change, just before it.
+	r.revokedAt["u1"] = oldClaims.IssuedAtTime().Add(time.Microsecond)
+	_, err = tm.Verify(ctx, old.Token)
+	assert.EqualError(t, err, "token revoked")
+	_, err = tm.Verify(ctx, pair.Token)
+	assert.NoError(t, err)
+}
+
+func TestOIDC_LinksExistingUserByVerifiedEmail(t *testing.T) {
+	idp, r, svc := newOIDCService(t)
+	_, err := ssoLogin(t, idp, svc, map[string]any{"sub": "bob", "email": "a@b", "email_verified": false, "groups": []string{"pvz-staff"}})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	pair, err := ssoLogin(t, idp, svc, map[string]any{"sub": "bob", "email": "a@b", "email_verified": true, "groups": []string{"pvz-staff"}})
+	require.NoError(t, err)
+	c, _ := tokens.ParseToken(pair.Token)
+	assert.Equal(t, "u1", c.Subject)
+	assert.Equal(t, "u1", r.identities[idp.URL+"|bob"])
+}
+
+func TestOIDC_Rejects(t *testing.T) {
+	idp, r, svc := newOIDCService(t)
+	ctx := context.Background()
+
+	_, err := ssoLogin(t, idp, svc, map[string]any{"email": "x@corp.ru", "email_verified": true, "groups": []string{"contractors"}})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err), "no role mapped")
+
+	authURL, _ := svc.OIDCLoginURL(ctx)
+	code, state, _ := idp.Authorize(authURL, map[string]any{"groups": []string{"pvz-staff"}})
+	_, err = svc.OIDCCallback(ctx, "forged", code)
+	assert.ErrorIs(t, err, ErrInvalidOIDCState)
+	_, err = svc.OIDCCallback(ctx, state, "bogus")
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err))
+	_, err = svc.OIDCCallback(ctx, state, code)
+	assert.ErrorIs(t, err, ErrInvalidOIDCState, "state is single use")
+
+	r.identities[idp.URL+"|user-1"] = "u1"
+	u := r.users["u1"]
+	u.DisabledAt = &u.CreatedAt
+	r.users["u1"] = u
+	_, err = ssoLogin(t, idp, svc, map[string]any{"groups": []string{"pvz-staff"}})
+	assert.ErrorIs(t, err, ErrAccountDisabled)
+
+	_, err = New(r, tokens).OIDCLoginURL(ctx)
+	assert.ErrorIs(t, err, ErrOIDCDisabled)
+}
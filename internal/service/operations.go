@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+// Product operation kinds. Only add, delete and retype change products and
+// can be undone; undo and redo entries record who reverted or reapplied them.
+const (
+	OpAdd    = "add"
+	OpDelete = "delete"
+	OpRetype = "retype"
+	OpUndo   = "undo"
+	OpRedo   = "redo"
+)
+
+// Operation states. Undone operations can be redone until a new operation
+// discards them.
+const (
+	OpDone   = "done"
+	OpUndone = "undone"
+)
+
+const MaxUndoSteps = 50
+
+var ErrProductNotFound = e.NotFound("product not found in the open reception")
+
+// recordOps logs new operations of the actor, which drops the redo stack of
+// the reception.
+func recordOps(ctx context.Context, r repo.Repository, actor model.Actor, ops ...model.ProductOperation) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := r.DiscardUndoneOperations(ctx, ops[0].ReceptionID); err != nil {
+		return err
+	}
+	return logOps(ctx, r, actor, ops)
+}
+
+func logOps(ctx context.Context, r repo.Repository, actor model.Actor, ops []model.ProductOperation) error {
+	for i := range ops {
+		ops[i].UserID, ops[i].APIKeyID = actor.UserID, actor.APIKeyID
+	}
+	_, err := r.RecordProductOperations(ctx, ops)
+	return e.WrapIfErr("failed to record product operations", err)
+}
+
+func productOps(kind string, products ...model.Product) []model.ProductOperation {
+	ops := make([]model.ProductOperation, len(products))
+	for i, p := range products {
+		ops[i] = model.ProductOperation{ReceptionID: p.ReceptionID, Kind: kind, Product: p}
+	}
+	return ops
+}
+
+// lockProduct finds a product of the reception by id or barcode.
+func lockProduct(ctx context.Context, r repo.Repository, receptionID, id, barcode string) (model.Product, error) {
+	p, err := r.GetReceptionProduct(ctx, receptionID, id, barcode)
+	if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+		return model.Product{}, ErrProductNotFound
+	}
+	return p, e.WrapIfErr("failed to get product", err)
+}
+
+// DeleteProduct removes a product of the open reception wherever it was
+// scanned, found by id or by barcode.
+func (s *service) DeleteProduct(ctx context.Context, actor model.Actor, pvzID, productID, barcode string) (model.Product, error) {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermProductDelete, pvzID); err != nil {
+		return model.Product{}, err
+	}
+	barcode = strings.TrimSpace(barcode)
+	if (productID == "") == (barcode == "") {
+		return model.Product{}, e.Validation("either a product id or a barcode is required")
+	}
+	var p model.Product
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		rec, err := openReception(ctx, r, pvzID)
+		if err != nil {
+			return err
+		}
+		if p, err = lockProduct(ctx, r, rec.ID, productID, barcode); err != nil {
+			return err
+		}
+		if err := r.DeleteProduct(ctx, p.ID); err != nil {
+			return e.Wrap("failed to delete product", err)
+		}
+		return recordOps(ctx, r, actor, productOps(OpDelete, p)...)
+	})
+	if err != nil {
+		return model.Product{}, err
+	}
+	return p, nil
+}
+
+// CorrectProductType changes the type of a mis-scanned product of the open
+// reception. The new type has to be usable for new products.
+func (s *service) CorrectProductType(ctx context.Context, actor model.Actor, pvzID, productID, productTypeName string) (model.Product, error) {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermProductAdd, pvzID); err != nil {
+		return model.Product{}, err
+	}
+	var p model.Product
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		rec, err := openReception(ctx, r, pvzID)
+		if err != nil {
+			return err
+		}
+		cur, err := lockProduct(ctx, r, rec.ID, productID, "")
+		if err != nil {
+			return err
+		}
+		if p = cur; cur.Type == productTypeName {
+			return nil
+		}
+		t, err := productType(ctx, r, productTypeName)
+		if err != nil {
+			return err
+		}
+		if err := fitsType(cur, t); err != nil {
+			return err
+		}
+		if p, err = r.SetProductType(ctx, cur.ID, t.Name); err != nil {
+			return e.Wrap("failed to correct product type", err)
+		}
+		op := model.ProductOperation{ReceptionID: rec.ID, Kind: OpRetype, Product: p, PreviousType: cur.Type}
+		return recordOps(ctx, r, actor, op)
+	})
+	if err != nil {
+		return model.Product{}, err
+	}
+	return p, nil
+}
+
+// applyOp redoes an operation or, with undo set, reverts it.
+func applyOp(ctx context.Context, r repo.Repository, op model.ProductOperation, undo bool) error {
+	switch {
+	case op.Kind == OpAdd && !undo, op.Kind == OpDelete && undo:
+		return r.RestoreProduct(ctx, op.Product)
+	case op.Kind == OpAdd, op.Kind == OpDelete:
+		return r.DeleteProduct(ctx, op.Product.ID)
+	case op.Kind == OpRetype:
+		productType := op.Product.Type
+		if undo {
+			productType = op.PreviousType
+		}
+		_, err := r.SetProductType(ctx, op.Product.ID, productType)
+		return err
+	}
+	return e.Internal("unknown product operation "+op.Kind, nil)
+}
+
+// UndoProductOperations reverts the last steps operations on the products of
+// the open reception, most recent first, and returns them.
+func (s *service) UndoProductOperations(ctx context.Context, actor model.Actor, pvzID string, steps int) ([]model.ProductOperation, error) {
+	return s.replayOps(ctx, actor, pvzID, steps, true)
+}
+
+// RedoProductOperations reapplies the last steps undone operations in their
+// original order.
+func (s *service) RedoProductOperations(ctx context.Context, actor model.Actor, pvzID string, steps int) ([]model.ProductOperation, error) {
+	return s.replayOps(ctx, actor, pvzID, steps, false)
+}
+
+func (s *service) replayOps(ctx context.Context, actor model.Actor, pvzID string, steps int, undo bool) ([]model.ProductOperation, error) {
+	// Undoing a delete adds the product back, so both permissions are needed.
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermProductDelete, pvzID); err != nil {
+		return nil, err
+	}
+	if err := s.require(actor, auth.PermProductAdd); err != nil {
+		return nil, err
+	}
+	if steps == 0 {
+		steps = 1
+	}
+	if steps < 0 || steps > MaxUndoSteps {
+		return nil, e.Validation("steps must be between 1 and %d", MaxUndoSteps)
+	}
+	action, kind, state := "undo", OpUndo, OpUndone
+	if !undo {
+		action, kind, state = "redo", OpRedo, OpDone
+	}
+	var ops []model.ProductOperation
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		rec, err := openReception(ctx, r, pvzID)
+		if err != nil {
+			return err
+		}
+		if undo {
+			ops, err = r.LockUndoStack(ctx, rec.ID, steps)
+		} else {
+			ops, err = r.LockRedoStack(ctx, rec.ID, steps)
+		}
+		if err != nil {
+			return e.Wrap("failed to "+action+" product operations", err)
+		}
+		if len(ops) == 0 {
+			return e.NotFound("nothing to %s in the open reception", action)
+		}
+		log := make([]model.ProductOperation, len(ops))
+		for i := range ops {
+			if err := applyOp(ctx, r, ops[i], undo); err != nil {
+				if e.IsKind(err, e.KindConflict) || e.IsKind(err, e.KindNotFound) {
+					return e.Precondition("cannot %s the %s of product %s: the reception has changed", action, ops[i].Kind, ops[i].Product.ID)
+				}
+				return e.Wrap("failed to "+action+" product operations", err)
+			}
+			if err := r.SetOperationState(ctx, ops[i].ID, state); err != nil {
+				return e.Wrap("failed to "+action+" product operations", err)
+			}
+			ops[i].State = state
+			log[i] = model.ProductOperation{ReceptionID: rec.ID, Kind: kind, Product: ops[i].Product, PreviousType: ops[i].PreviousType, TargetID: ops[i].ID}
+		}
+		return logOps(ctx, r, actor, log)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// ProductOperations returns the change log of the open reception of a PVZ,
+// oldest first. It is a plain read that does not lock the reception against
+// the writers.
+func (s *service) ProductOperations(ctx context.Context, actor model.Actor, pvzID string) ([]model.ProductOperation, error) {
+	if err := s.require(actor, auth.PermPVZRead); err != nil {
+		return nil, err
+	}
+	rec, err := s.repo.FindOpenReception(ctx, pvzID)
+	if e.IsKind(err, e.KindNotFound) {
+		return nil, ErrNoOpenReception
+	}
+	if err != nil {
+		return nil, e.Wrap("failed to get open reception", err)
+	}
+	ops, err := s.repo.ListProductOperations(ctx, rec.ID)
+	return ops, e.WrapIfErr("failed to list product operations", err)
+}
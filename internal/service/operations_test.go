@@ -0,0 +1,264 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+// opsRepo keeps the products and the operation log of reception r1 in memory.
+type opsRepo struct {
+	stubRepoSuccess
+	products []model.Product
+	ops      []model.ProductOperation
+	added    int
+	txs      int
+}
+
+func (r *opsRepo) product(id string) int {
+	return slices.IndexFunc(r.products, func(p model.Product) bool { return p.ID == id })
+}
+func (r *opsRepo) AddProduct(_ context.Context, recID string, p model.Product) (model.Product, error) {
+	r.added++
+	p.ID, p.ReceptionID = fmt.Sprintf("pr%d", r.added), recID
+	p.DateTime, p.Seq = time.Date(2025, 4, 20, 10, 0, r.added, 0, time.UTC), int64(r.added)
+	r.products = append(r.products, p)
+	return p, nil
+}
+func (r *opsRepo) DeleteLastProduct(_ context.Context, _ string) (model.Product, error) {
+	if len(r.products) == 0 {
+		return model.Product{}, e.NotFound("delete last product: not found")
+	}
+	p := r.products[len(r.products)-1]
+	r.products = r.products[:len(r.products)-1]
+	return p, nil
+}
+func (r *opsRepo) GetReceptionProduct(_ context.Context, _, id, barcode string) (model.Product, error) {
+	for _, p := range r.products {
+		if (id != "" && p.ID == id) || (id == "" && p.Barcode == barcode) {
+			return p, nil
+		}
+	}
+	return model.Product{}, e.NotFound("get product: not found")
+}
+func (r *opsRepo) DeleteProduct(_ context.Context, id string) error {
+	i := r.product(id)
+	if i < 0 {
+		return e.NotFound("delete product: not found")
+	}
+	r.products = slices.Delete(r.products, i, i+1)
+	return nil
+}
+func (r *opsRepo) RestoreProduct(_ context.Context, p model.Product) error {
+	if r.product(p.ID) >= 0 {
+		return e.Conflict("restore product: already exists")
+	}
+	r.products = append(r.products, p)
+	slices.SortFunc(r.products, func(a, b model.Product) int { return cmp.Compare(a.Seq, b.Seq) })
+	return nil
+}
+func (r *opsRepo) SetProductType(_ context.Context, id, productType string) (model.Product, error) {
+	i := r.product(id)
+	if i < 0 {
+		return model.Product{}, e.NotFound("set product type: not found")
+	}
+	r.products[i].Type = productType
+	return r.products[i], nil
+}
+func (r *opsRepo) RecordProductOperations(_ context.Context, ops []model.ProductOperation) ([]model.ProductOperation, error) {
+	for i := range ops {
+		ops[i].ID, ops[i].State = fmt.Sprintf("op%d", len(r.ops)+1), OpDone
+		r.ops = append(r.ops, ops[i])
+	}
+	return ops, nil
+}
+func (r *opsRepo) ListProductOperations(_ context.Context, _ string) ([]model.ProductOperation, error) {
+	return slices.Clone(r.ops), nil
+}
+func (r *opsRepo) LockUndoStack(_ context.Context, _ string, limit int) ([]model.ProductOperation, error) {
+	var res []model.ProductOperation
+	for i := len(r.ops) - 1; i >= 0 && len(res) < limit; i-- {
+		if op := r.ops[i]; op.State == OpDone && op.Kind != OpUndo && op.Kind != OpRedo {
+			res = append(res, op)
+		}
+	}
+	return res, nil
+}
+func (r *opsRepo) LockRedoStack(_ context.Context, _ string, limit int) ([]model.ProductOperation, error) {
+	var res []model.ProductOperation
+	for _, op := range r.ops {
+		if op.State == OpUndone && len(res) < limit {
+			res = append(res, op)
+		}
+	}
+	return res, nil
+}
+func (r *opsRepo) SetOperationState(_ context.Context, id, state string) error {
+	for i := range r.ops {
+		if r.ops[i].ID == id {
+			r.ops[i].State = state
+			return nil
+		}
+	}
+	return e.NotFound("set operation state: not found")
+}
+func (r *opsRepo) DiscardUndoneOperations(_ context.Context, _ string) error {
+	for i := range r.ops {
+		if r.ops[i].State == OpUndone {
+			r.ops[i].State = "discarded"
+		}
+	}
+	return nil
+}
+func (r *opsRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	r.txs++
+	return fn(r)
+}
+
+func (r *opsRepo) types() []string {
+	var types []string
+	for _, p := range r.products {
+		types = append(types, p.ID+":"+p.Type)
+	}
+	return types
+}
+
+func TestDeleteProduct(t *testing.T) {
+	ctx := context.Background()
+	r := &opsRepo{}
+	svc := New(r, tokens)
+	for _, p := range []model.Product{{Type: "обувь", Barcode: "4006381333931"}, {Type: "одежда"}, {Type: "обувь"}} {
+		_, err := svc.AddProduct(ctx, employee, "p1", p)
+		assert.NoError(t, err)
+	}
+
+	p, err := svc.DeleteProduct(ctx, employee, "p1", "", " 4006381333931 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "pr1", p.ID)
+	p, err = svc.DeleteProduct(ctx, employee, "p1", "pr3", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "pr3", p.ID)
+	assert.Equal(t, []string{"pr2:одежда"}, r.types())
+
+	_, err = svc.DeleteProduct(ctx, employee, "p1", "pr1", "")
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	_, err = svc.DeleteProduct(ctx, employee, "p1", "", "")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.DeleteProduct(ctx, employee, "p1", "pr2", "4006381333931")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.DeleteProduct(ctx, moderator, "p1", "pr2", "")
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	assert.NoError(t, svc.DeleteLastProduct(ctx, employee, "p1"))
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.DeleteLastProduct(ctx, employee, "p1")))
+
+	ops, err := svc.ProductOperations(ctx, employee, "p1")
+	assert.NoError(t, err)
+	assert.Len(t, ops, 6)
+	assert.Equal(t, OpDelete, ops[5].Kind)
+	assert.Equal(t, "pr2", ops[5].Product.ID)
+	assert.Equal(t, employee.UserID, ops[5].UserID)
+}
+
+func TestCorrectProductType(t *testing.T) {
+	ctx := context.Background()
+	r := &opsRepo{}
+	svc := New(r, tokens)
+	_, err := svc.AddProduct(ctx, employee, "p1", model.Product{Type: "обувь"})
+	assert.NoError(t, err)
+
+	p, err := svc.CorrectProductType(ctx, employee, "p1", "pr1", "одежда")
+	assert.NoError(t, err)
+	assert.Equal(t, "одежда", p.Type)
+	assert.Equal(t, OpRetype, r.ops[1].Kind)
+	assert.Equal(t, "обувь", r.ops[1].PreviousType)
+
+	_, err = svc.CorrectProductType(ctx, employee, "p1", "pr1", "одежда")
+	assert.NoError(t, err)
+	assert.Len(t, r.ops, 2)
+	_, err = svc.CorrectProductType(ctx, employee, "p1", "pr9", "обувь")
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestUndoRedoProductOperations(t *testing.T) {
+	ctx := context.Background()
+	r := &opsRepo{}
+	svc := New(r, tokens)
+	for _, p := range []model.Product{{Type: "обувь"}, {Type: "одежда"}, {Type: "обувь"}} {
+		_, err := svc.AddProduct(ctx, employee, "p1", p)
+		assert.NoError(t, err)
+	}
+	_, err := svc.DeleteProduct(ctx, employee, "p1", "pr1", "")
+	assert.NoError(t, err)
+	_, err = svc.CorrectProductType(ctx, employee, "p1", "pr2", "электроника")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pr2:электроника", "pr3:обувь"}, r.types())
+
+	undone, err := svc.UndoProductOperations(ctx, employee, "p1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, OpRetype, undone[0].Kind)
+	assert.Equal(t, OpDelete, undone[1].Kind)
+	assert.Equal(t, OpUndone, undone[1].State)
+	assert.Equal(t, []string{"pr1:обувь", "pr2:одежда", "pr3:обувь"}, r.types())
+
+	redone, err := svc.RedoProductOperations(ctx, employee, "p1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, OpDelete, redone[0].Kind)
+	assert.Equal(t, []string{"pr2:одежда", "pr3:обувь"}, r.types())
+	_, err = svc.RedoProductOperations(ctx, employee, "p1", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pr2:электроника", "pr3:обувь"}, r.types())
+	_, err = svc.RedoProductOperations(ctx, employee, "p1", 1)
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+
+	// A new operation after an undo drops what could be redone.
+	_, err = svc.UndoProductOperations(ctx, employee, "p1", 1)
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DeleteLastProduct(ctx, employee, "p1"))
+	_, err = svc.RedoProductOperations(ctx, employee, "p1", 1)
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+
+	undone, err = svc.UndoProductOperations(ctx, employee, "p1", MaxUndoSteps)
+	assert.NoError(t, err)
+	assert.Len(t, undone, 5)
+	assert.Empty(t, r.types())
+	_, err = svc.UndoProductOperations(ctx, employee, "p1", 1)
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+
+	last := r.ops[len(r.ops)-1]
+	assert.Equal(t, OpUndo, last.Kind)
+	assert.Equal(t, "op1", last.TargetID)
+	assert.Equal(t, employee.UserID, last.UserID)
+
+	_, err = svc.UndoProductOperations(ctx, employee, "p1", MaxUndoSteps+1)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.UndoProductOperations(ctx, moderator, "p1", 1)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = New(&opsRepo{stubRepoSuccess: stubRepoSuccess{noOpenReception: true}}, tokens).UndoProductOperations(ctx, employee, "p1", 1)
+	assert.ErrorIs(t, err, ErrNoOpenReception)
+}
+
+func TestProductOperations_ReadsWithoutTx(t *testing.T) {
+	ctx := context.Background()
+	r := &opsRepo{}
+	svc := New(r, tokens)
+	_, err := svc.AddProduct(ctx, employee, "p1", model.Product{Type: "обувь"})
+	assert.NoError(t, err)
+
+	txs := r.txs
+	ops, err := svc.ProductOperations(ctx, model.Actor{UserID: "u3", Role: RoleAuditor}, "p1")
+	assert.NoError(t, err)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, txs, r.txs, "listing opens no transaction")
+
+	_, err = New(&opsRepo{stubRepoSuccess: stubRepoSuccess{noOpenReception: true}}, tokens).ProductOperations(ctx, employee, "p1")
+	assert.ErrorIs(t, err, ErrNoOpenReception)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+const maxProductTypeLen = 64
+
+func productTypeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", e.Validation("product type name is required")
+	}
+	if utf8.RuneCountInString(name) > maxProductTypeLen {
+		return "", e.Validation("product type name must be at most %d characters", maxProductTypeLen)
+	}
+	return name, nil
+}
+
+// usableType checks that new products may be received as t.
+func usableType(t model.ProductType) error {
+	if t.DeprecatedAt != nil {
+		return e.Validation("product type %q is deprecated", t.Name)
+	}
+	return nil
+}
+
+// fitsType checks a product against the attributes of its type.
+func fitsType(p model.Product, t model.ProductType) error {
+	if err := usableType(t); err != nil {
+		return err
+	}
+	if p.WeightGrams != nil && t.MaxWeightGrams != nil && *p.WeightGrams > *t.MaxWeightGrams {
+		return e.Validation("%s must weigh at most %d g", t.Name, *t.MaxWeightGrams)
+	}
+	return nil
+}
+
+func productType(ctx context.Context, r repo.Repository, name string) (model.ProductType, error) {
+	t, err := r.GetProductType(ctx, name)
+	if e.IsKind(err, e.KindNotFound) {
+		return model.ProductType{}, e.Validation("unknown product type %q", name)
+	}
+	return t, err
+}
+
+func productCatalog(ctx context.Context, r repo.Repository) (map[string]model.ProductType, error) {
+	types, err := r.ListProductTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make(map[string]model.ProductType, len(types))
+	for _, t := range types {
+		catalog[t.Name] = t
+	}
+	return catalog, nil
+}
+
+// checkManifestTypes makes sure a manifest only expects types from the
+// catalog that are still in use.
+func checkManifestTypes(ctx context.Context, r repo.Repository, m *model.Manifest) error {
+	if m == nil || len(m.Items) == 0 {
+		return nil
+	}
+	catalog, err := productCatalog(ctx, r)
+	if err != nil {
+		return err
+	}
+	for _, it := range m.Items {
+		t, ok := catalog[it.Type]
+		if !ok {
+			return e.Validation("unknown product type %q", it.Type)
+		}
+		if err := usableType(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) ListProductTypes(ctx context.Context, actor model.Actor) ([]model.ProductType, error) {
+	if err := s.require(actor, auth.PermPVZRead); err != nil {
+		return nil, err
+	}
+	types, err := s.repo.ListProductTypes(ctx)
+	return types, e.WrapIfErr("could not list product types", err)
+}
+
+func (s *service) CreateProductType(ctx context.Context, actor model.Actor, t model.ProductType) (model.ProductType, error) {
+	if err := s.require(actor, auth.PermCatalogManage); err != nil {
+		return model.ProductType{}, err
+	}
+	name, err := productTypeName(t.Name)
+	if err != nil {
+		return model.ProductType{}, err
+	}
+	if t.MaxWeightGrams != nil && *t.MaxWeightGrams <= 0 {
+		return model.ProductType{}, e.Validation("max weight must be positive")
+	}
+	t.Name = name
+	created, err := s.repo.CreateProductType(ctx, t)
+	if e.IsKind(err, e.KindConflict) {
+		return model.ProductType{}, e.Conflict("product type %q already exists", name)
+	}
+	return created, e.WrapIfErr("failed to create product type", err)
+}
+
+// UpdateProductType changes the attributes of a type. Deprecating it keeps
+// the products already received but rejects new ones.
+func (s *service) UpdateProductType(ctx context.Context, actor model.Actor, name string, u model.ProductTypeUpdate) (model.ProductType, error) {
+	if err := s.require(actor, auth.PermCatalogManage); err != nil {
+		return model.ProductType{}, err
+	}
+	if u == (model.ProductTypeUpdate{}) {
+		return model.ProductType{}, e.Validation("nothing to update")
+	}
+	if u.MaxWeightGrams != nil && *u.MaxWeightGrams < 0 {
+		return model.ProductType{}, e.Validation("max weight must not be negative")
+	}
+	t, err := s.repo.UpdateProductType(ctx, name, u)
+	if e.IsKind(err, e.KindNotFound) {
+		return model.ProductType{}, e.NotFound("product type not found")
+	}
+	return t, e.WrapIfErr("failed to update product type", err)
+}
+
+// DeleteProductType removes a type no product was ever received as; types
+// with history can only be deprecated.
+func (s *service) DeleteProductType(ctx context.Context, actor model.Actor, name string) error {
+	if err := s.require(actor, auth.PermCatalogManage); err != nil {
+		return err
+	}
+	err := s.repo.DeleteProductType(ctx, name)
+	switch {
+	case e.IsKind(err, e.KindNotFound):
+		return e.NotFound("product type not found")
+	case e.IsKind(err, e.KindPrecondition):
+		return e.Precondition("product type %q is used by products, deprecate it instead", name)
+	}
+	return e.WrapIfErr("failed to delete product type", err)
+}
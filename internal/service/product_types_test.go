@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type catalogRepo struct {
+	stubRepoSuccess
+	types map[string]model.ProductType
+	inUse map[string]bool
+}
+
+func newCatalogRepo() *catalogRepo {
+	limit, deprecated := 1000, time.Now()
+	return &catalogRepo{
+		types: map[string]model.ProductType{
+			"обувь":       {Name: "обувь"},
+			"электроника": {Name: "электроника", Fragile: true, MaxWeightGrams: &limit},
+			"кассеты":     {Name: "кассеты", DeprecatedAt: &deprecated},
+		},
+		inUse: map[string]bool{"обувь": true, "кассеты": true},
+	}
+}
+
+func (r *catalogRepo) ListProductTypes(context.Context) ([]model.ProductType, error) {
+	var list []model.ProductType
+	for _, t := range r.types {
+		list = append(list, t)
+	}
+	return list, nil
+}
+func (r *catalogRepo) GetProductType(_ context.Context, name string) (model.ProductType, error) {
+	t, ok := r.types[name]
+	if !ok {
+		return model.ProductType{}, e.NotFound("get product type: not found")
+	}
+	return t, nil
+}
+func (r *catalogRepo) CreateProductType(_ context.Context, t model.ProductType) (model.ProductType, error) {
+	if _, ok := r.types[t.Name]; ok {
+		return model.ProductType{}, e.Conflict("create product type: already exists")
+	}
+	t.CreatedAt = time.Now()
+	r.types[t.Name] = t
+	return t, nil
+}
+func (r *catalogRepo) UpdateProductType(_ context.Context, name string, u model.ProductTypeUpdate) (model.ProductType, error) {
+	t, ok := r.types[name]
+	if !ok {
+		return model.ProductType{}, e.NotFound("update product type: not found")
+	}
+	if u.Deprecated != nil {
+		t.DeprecatedAt = nil
+		if *u.Deprecated {
+			now := time.Now()
+			t.DeprecatedAt = &now
+		}
+	}
+	r.types[name] = t
+	return t, nil
+}
+func (r *catalogRepo) DeleteProductType(_ context.Context, name string) error {
+	if r.inUse[name] {
+		return e.Precondition("delete product type: record is still referenced")
+	}
+	if _, ok := r.types[name]; !ok {
+		return e.NotFound("delete product type: not found")
+	}
+	delete(r.types, name)
+	return nil
+}
+func (r *catalogRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+
+func TestProductTypes(t *testing.T) {
+	r := newCatalogRepo()
+	svc := New(r, tokens)
+	ctx := context.Background()
+
+	list, err := svc.ListProductTypes(ctx, employee)
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+
+	_, err = svc.CreateProductType(ctx, employee, model.ProductType{Name: "косметика"})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.CreateProductType(ctx, moderator, model.ProductType{Name: " "})
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	zero := 0
+	_, err = svc.CreateProductType(ctx, moderator, model.ProductType{Name: "косметика", MaxWeightGrams: &zero})
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.CreateProductType(ctx, moderator, model.ProductType{Name: "обувь"})
+	assert.EqualError(t, err, `product type "обувь" already exists`)
+
+	pt, err := svc.CreateProductType(ctx, moderator, model.ProductType{Name: " косметика ", RequiresIDCheck: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "косметика", pt.Name)
+	assert.True(t, pt.RequiresIDCheck)
+
+	_, err = svc.UpdateProductType(ctx, moderator, "косметика", model.ProductTypeUpdate{})
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	yes := true
+	_, err = svc.UpdateProductType(ctx, moderator, "мебель", model.ProductTypeUpdate{Deprecated: &yes})
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+	pt, err = svc.UpdateProductType(ctx, moderator, "косметика", model.ProductTypeUpdate{Deprecated: &yes})
+	assert.NoError(t, err)
+	assert.NotNil(t, pt.DeprecatedAt)
+
+	assert.EqualError(t, svc.DeleteProductType(ctx, moderator, "обувь"), `product type "обувь" is used by products, deprecate it instead`)
+	assert.Equal(t, e.KindNotFound, e.KindOf(svc.DeleteProductType(ctx, moderator, "мебель")))
+	assert.NoError(t, svc.DeleteProductType(ctx, moderator, "косметика"))
+}
+
+func TestAddProduct_Catalog(t *testing.T) {
+	svc := New(newCatalogRepo(), tokens)
+	ctx := context.Background()
+	light, heavy := 800, 1500
+
+	_, err := svc.AddProduct(ctx, employee, "p1", model.Product{Type: "электроника", WeightGrams: &light})
+	assert.NoError(t, err)
+	_, err = svc.AddProduct(ctx, employee, "p1", model.Product{Type: "электроника", WeightGrams: &heavy})
+	assert.EqualError(t, err, "электроника must weigh at most 1000 g")
+	_, err = svc.AddProduct(ctx, employee, "p1", model.Product{Type: "мебель"})
+	assert.EqualError(t, err, `unknown product type "мебель"`)
+	_, err = svc.AddProduct(ctx, employee, "p1", model.Product{Type: "кассеты"})
+	assert.EqualError(t, err, `product type "кассеты" is deprecated`)
+}
+
+func TestOpenReception_ManifestCatalog(t *testing.T) {
+	r := newCatalogRepo()
+	r.noOpenReception = true
+	svc := New(r, tokens)
+	ctx := context.Background()
+	manifest := func(typ string) *model.Manifest {
+		return &model.Manifest{Items: []model.ManifestItem{{Type: typ, Count: 1}}}
+	}
+
+	_, err := svc.OpenReception(ctx, employee, "p1", manifest("мебель"))
+	assert.EqualError(t, err, `unknown product type "мебель"`)
+	_, err = svc.OpenReception(ctx, employee, "p1", manifest("кассеты"))
+	assert.EqualError(t, err, `product type "кассеты" is deprecated`)
+	_, err = svc.OpenReception(ctx, employee, "p1", manifest("обувь"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/metrics"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+const (
+	maxBarcodeLen = 48
+	maxSKULen     = 64
+	// maxBarcodeMatches bounds a lookup: the same barcode comes back with
+	// every delivery of that item.
+	maxBarcodeMatches = 50
+	MaxBatchProducts  = 500
+)
+
+// Batch item statuses. When a batch is rejected as a whole, its valid items
+// are reported as skipped.
+const (
+	BatchAdded   = "added"
+	BatchFailed  = "failed"
+	BatchSkipped = "skipped"
+)
+
+// validateBarcode accepts EAN-13 codes, whose check digit must match, and
+// otherwise Code 128 text: printable ASCII.
+func validateBarcode(code string) error {
+	if code == "" {
+		return e.Validation("barcode must not be empty")
+	}
+	if len(code) == 13 && strings.Trim(code, "0123456789") == "" {
+		if !validEAN13(code) {
+			return e.Validation("invalid EAN-13 check digit in %q", code)
+		}
+		return nil
+	}
+	if utf8.RuneCountInString(code) > maxBarcodeLen {
+		return e.Validation("barcode must be at most %d characters", maxBarcodeLen)
+	}
+	for _, r := range code {
+		if r < 0x20 || r > 0x7e {
+			return e.Validation("barcode %q has characters Code 128 cannot encode", code)
+		}
+	}
+	return nil
+}
+
+func validEAN13(code string) bool {
+	sum := 0
+	for i, d := range code[:12] {
+		n := int(d - '0')
+		if i%2 == 1 {
+			n *= 3
+		}
+		sum += n
+	}
+	return int(code[12]-'0') == (10-sum%10)%10
+}
+
+// validateProduct checks the identity fields of a product being received.
+// The barcode stays optional for clients that only send a type.
+func validateProduct(p *model.Product) error {
+	p.Barcode, p.SKU = strings.TrimSpace(p.Barcode), strings.TrimSpace(p.SKU)
+	if p.Barcode != "" {
+		if err := validateBarcode(p.Barcode); err != nil {
+			return err
+		}
+	}
+	if utf8.RuneCountInString(p.SKU) > maxSKULen {
+		return e.Validation("sku must be at most %d characters", maxSKULen)
+	}
+	if p.WeightGrams != nil && *p.WeightGrams <= 0 {
+		return e.Validation("weight must be positive")
+	}
+	if d := p.Dimensions; d != nil && (d.LengthMm <= 0 || d.WidthMm <= 0 || d.HeightMm <= 0) {
+		return e.Validation("dimensions must be positive")
+	}
+	return nil
+}
+
+// ProductsByBarcode finds received products by barcode, most recent first.
+func (s *service) ProductsByBarcode(ctx context.Context, actor model.Actor, barcode string) ([]model.Product, error) {
+	if err := s.require(actor, auth.PermPVZRead); err != nil {
+		return nil, err
+	}
+	barcode = strings.TrimSpace(barcode)
+	if err := validateBarcode(barcode); err != nil {
+		return nil, err
+	}
+	products, err := s.repo.ProductsByBarcode(ctx, barcode, maxBarcodeMatches)
+	if err != nil {
+		return nil, e.Wrap("could not look up barcode", err)
+	}
+	if len(products) == 0 {
+		return nil, e.NotFound("no product with barcode %q", barcode)
+	}
+	return products, nil
+}
+
+// checkBatchProduct validates one item of a batch; barcodes holds those
+// already in the reception or earlier in the batch.
+func checkBatchProduct(p *model.Product, catalog map[string]model.ProductType, barcodes map[string]bool) error {
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+	t, ok := catalog[p.Type]
+	if !ok {
+		return e.Validation("unknown product type %q", p.Type)
+	}
+	if err := fitsType(*p, t); err != nil {
+		return err
+	}
+	if p.Barcode != "" {
+		if barcodes[p.Barcode] {
+			return e.Conflict("barcode %q is already in this reception", p.Barcode)
+		}
+		barcodes[p.Barcode] = true
+	}
+	return nil
+}
+
+// AddProducts adds a scanner batch to the open reception of a PVZ in one
+// transaction. Every item is checked first; unless partial is set, a single
+// invalid item rejects the whole batch.
+func (s *service) AddProducts(ctx context.Context, actor model.Actor, pvzID string, products []model.Product, partial bool) (model.BatchResult, error) {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermProductAdd, pvzID); err != nil {
+		return model.BatchResult{}, err
+	}
+	if len(products) == 0 || len(products) > MaxBatchProducts {
+		return model.BatchResult{}, e.Validation("a batch must hold between 1 and %d products", MaxBatchProducts)
+	}
+	var res model.BatchResult
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		res = model.BatchResult{Items: make([]model.BatchItem, len(products))}
+		rec, err := openReception(ctx, r, pvzID)
+		if err != nil {
+			return err
+		}
+		catalog, err := productCatalog(ctx, r)
+		if err != nil {
+			return err
+		}
+		existing, err := r.ListReceptionBarcodes(ctx, rec.ID)
+		if err != nil {
+			return err
+		}
+		barcodes := make(map[string]bool, len(existing))
+		for _, b := range existing {
+			barcodes[b] = true
+		}
+		var valid []model.Product
+		var positions []int
+		for i, p := range products {
+			res.Items[i].Index = i
+			if err := checkBatchProduct(&p, catalog, barcodes); err != nil {
+				res.Items[i].Status, res.Items[i].Error = BatchFailed, e.Message(err)
+				res.Failed++
+				continue
+			}
+			valid, positions = append(valid, p), append(positions, i)
+		}
+		if len(valid) == 0 || (res.Failed > 0 && !partial) {
+			for _, i := range positions {
+				res.Items[i].Status = BatchSkipped
+			}
+			return nil
+		}
+		added, err := r.AddProducts(ctx, rec.ID, valid)
+		if e.IsKind(err, e.KindConflict) {
+			return e.Conflict("a barcode of the batch was added to the reception meanwhile, retry the batch")
+		}
+		if err != nil {
+			return e.Wrap("failed to add products", err)
+		}
+		for j, i := range positions {
+			res.Items[i].Status, res.Items[i].Product = BatchAdded, &added[j]
+		}
+		res.Added = len(added)
+		return recordOps(ctx, r, actor, productOps(OpAdd, added...)...)
+	})
+	if err != nil {
+		return model.BatchResult{}, err
+	}
+	metrics.ProductsAdded.Add(float64(res.Added))
+	return res, nil
+}
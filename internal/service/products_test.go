@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type productRepo struct {
+	stubRepoSuccess
+	products []model.Product
+}
+
+func (r *productRepo) AddProduct(_ context.Context, recID string, p model.Product) (model.Product, error) {
+	for _, got := range r.products {
+		if p.Barcode != "" && got.ReceptionID == recID && got.Barcode == p.Barcode {
+			return model.Product{}, e.Conflict("add product: already exists")
+		}
+	}
+	p.ID, p.ReceptionID = "pr1", recID
+	r.products = append(r.products, p)
+	return p, nil
+}
+func (r *productRepo) ProductsByBarcode(_ context.Context, barcode string, limit int) ([]model.Product, error) {
+	var res []model.Product
+	for _, p := range r.products {
+		if p.Barcode == barcode && len(res) < limit {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+func (r *productRepo) AddProducts(_ context.Context, recID string, ps []model.Product) ([]model.Product, error) {
+	var added []model.Product
+	for i, p := range ps {
+		p.ID, p.ReceptionID = fmt.Sprintf("pr%d", len(r.products)+i+1), recID
+		added = append(added, p)
+	}
+	r.products = append(r.products, added...)
+	return added, nil
+}
+func (r *productRepo) ListReceptionBarcodes(_ context.Context, recID string) ([]string, error) {
+	var barcodes []string
+	for _, p := range r.products {
+		if p.ReceptionID == recID && p.Barcode != "" {
+			barcodes = append(barcodes, p.Barcode)
+		}
+	}
+	return barcodes, nil
+}
+func (r *productRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+
+func TestValidateBarcode(t *testing.T) {
+	for _, ok := range []string{"4006381333931", "5901234123457", "PVZ-000123", "123456789012", "ABC 12/x"} {
+		assert.NoError(t, validateBarcode(ok), ok)
+	}
+	for _, bad := range []string{"", "4006381333932", "штрихкод", "tab\there", strings.Repeat("X", maxBarcodeLen+1)} {
+		assert.Equal(t, e.KindValidation, e.KindOf(validateBarcode(bad)), bad)
+	}
+}
+
+func TestValidateProduct(t *testing.T) {
+	weight, zero := 450, 0
+	p := model.Product{Type: "обувь", Barcode: " 4006381333931 ", SKU: " SH-42 ", WeightGrams: &weight,
+		Dimensions: &model.Dimensions{LengthMm: 300, WidthMm: 200, HeightMm: 120}}
+	assert.NoError(t, validateProduct(&p))
+	assert.Equal(t, "4006381333931", p.Barcode)
+	assert.Equal(t, "SH-42", p.SKU)
+	assert.NoError(t, validateProduct(&model.Product{Type: "обувь"}))
+
+	for _, bad := range []model.Product{
+		{Barcode: "4006381333932"},
+		{SKU: strings.Repeat("s", maxSKULen+1)},
+		{WeightGrams: &zero},
+		{Dimensions: &model.Dimensions{LengthMm: 300, WidthMm: 0, HeightMm: 120}},
+	} {
+		assert.Equal(t, e.KindValidation, e.KindOf(validateProduct(&bad)), bad)
+	}
+}
+
+func TestAddProduct_DuplicateBarcode(t *testing.T) {
+	svc := New(&productRepo{}, tokens)
+	_, err := svc.AddProduct(context.Background(), employee, "p1", model.Product{Type: "обувь", Barcode: "4006381333931"})
+	assert.NoError(t, err)
+
+	_, err = svc.AddProduct(context.Background(), employee, "p1", model.Product{Type: "обувь", Barcode: "4006381333931"})
+	assert.Equal(t, e.KindConflict, e.KindOf(err))
+
+	_, err = svc.AddProduct(context.Background(), employee, "p1", model.Product{Type: "обувь"})
+	assert.NoError(t, err)
+	_, err = svc.AddProduct(context.Background(), employee, "p1", model.Product{Type: "обувь"})
+	assert.NoError(t, err)
+}
+
+func TestProductsByBarcode(t *testing.T) {
+	r := &productRepo{products: []model.Product{{ID: "pr1", Type: "обувь", Barcode: "4006381333931"}}}
+	svc := New(r, tokens)
+
+	products, err := svc.ProductsByBarcode(context.Background(), employee, "4006381333931")
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+
+	_, err = svc.ProductsByBarcode(context.Background(), employee, "5901234123457")
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+
+	_, err = svc.ProductsByBarcode(context.Background(), employee, "4006381333932")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+
+	_, err = svc.ProductsByBarcode(context.Background(), model.Actor{UserID: "k", APIKeyID: "k1"}, "4006381333931")
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+}
+
+func TestAddProducts(t *testing.T) {
+	r := &productRepo{products: []model.Product{{ID: "pr0", ReceptionID: "r1", Type: "обувь", Barcode: "4006381333931"}}}
+	svc := New(r, tokens)
+	ctx := context.Background()
+	batch := []model.Product{
+		{Type: "обувь", Barcode: "5901234123457"},
+		{Type: "мебель"},
+		{Type: "одежда", Barcode: "4006381333931"},
+		{Type: "одежда", Barcode: "5901234123457"},
+		{Type: "электроника"},
+	}
+
+	res, err := svc.AddProducts(ctx, employee, "p1", batch, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Added)
+	assert.Equal(t, 3, res.Failed)
+	assert.Equal(t, []string{BatchSkipped, BatchFailed, BatchFailed, BatchFailed, BatchSkipped},
+		[]string{res.Items[0].Status, res.Items[1].Status, res.Items[2].Status, res.Items[3].Status, res.Items[4].Status})
+	assert.Equal(t, `unknown product type "мебель"`, res.Items[1].Error)
+	assert.Equal(t, `barcode "4006381333931" is already in this reception`, res.Items[2].Error)
+	assert.Len(t, r.products, 1)
+
+	res, err = svc.AddProducts(ctx, employee, "p1", batch, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Added)
+	assert.Equal(t, 3, res.Failed)
+	assert.Equal(t, BatchAdded, res.Items[4].Status)
+	assert.Equal(t, 4, res.Items[4].Index)
+	assert.Equal(t, "r1", res.Items[4].Product.ReceptionID)
+	assert.Len(t, r.products, 3)
+
+	res, err = svc.AddProducts(ctx, employee, "p1", []model.Product{{Type: "одежда"}}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Added)
+}
+
+func TestAddProducts_Rejected(t *testing.T) {
+	ctx := context.Background()
+	svc := New(&productRepo{}, tokens)
+
+	_, err := svc.AddProducts(ctx, employee, "p1", nil, false)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.AddProducts(ctx, employee, "p1", make([]model.Product, MaxBatchProducts+1), true)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.AddProducts(ctx, model.Actor{UserID: "k", APIKeyID: "k1"}, "p1", []model.Product{{Type: "обувь"}}, false)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	svc = New(&productRepo{stubRepoSuccess: stubRepoSuccess{noOpenReception: true}}, tokens)
+	_, err = svc.AddProducts(ctx, employee, "p1", []model.Product{{Type: "обувь"}}, true)
+	assert.Equal(t, ErrNoOpenReception, err)
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+	_ "time/tzdata" // PVZ timezones must resolve even on hosts without a zoneinfo database.
+
+	"github.com/google/uuid"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+// PVZ lifecycle: receptions are only opened at active PVZs. A suspended PVZ
+// can be reactivated, a closed one cannot.
+const (
+	PVZActive    = "active"
+	PVZSuspended = "suspended"
+	PVZClosed    = "closed"
+)
+
+const DefaultPVZTimezone = "Europe/Moscow"
+
+var ErrInvalidCursor = e.Validation("invalid cursor")
+
+// encodePVZCursor makes an opaque cursor pointing right after p.
+func encodePVZCursor(p model.PVZ) string {
+	raw := p.RegistrationDate.UTC().Format(time.RFC3339Nano) + "," + p.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePVZCursor(cursor string) (*model.PVZCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	date, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &model.PVZCursor{RegistrationDate: t, ID: id}, nil
+}
+
+// parseClock parses "HH:MM" into minutes since midnight; "24:00" is allowed
+// so that a day can end at midnight.
+func parseClock(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, e.Validation("invalid time %q, want HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func validateWorkingHours(hours []model.WorkingHours) error {
+	seen := map[int]bool{}
+	for _, wh := range hours {
+		if wh.Weekday < 1 || wh.Weekday > 7 {
+			return e.Validation("weekday must be between 1 (Monday) and 7 (Sunday), got %d", wh.Weekday)
+		}
+		if seen[wh.Weekday] {
+			return e.Validation("weekday %d is listed twice", wh.Weekday)
+		}
+		seen[wh.Weekday] = true
+		opens, err := parseClock(wh.Opens)
+		if err != nil {
+			return err
+		}
+		closes, err := parseClock(wh.Closes)
+		if err != nil {
+			return err
+		}
+		if closes <= opens {
+			return e.Validation("weekday %d closes before it opens", wh.Weekday)
+		}
+	}
+	return nil
+}
+
+func validatePVZ(p model.PVZ) error {
+	if (p.Latitude == nil) != (p.Longitude == nil) {
+		return e.Validation("latitude and longitude must be set together")
+	}
+	if p.Latitude != nil && (*p.Latitude < -90 || *p.Latitude > 90) {
+		return e.Validation("latitude must be between -90 and 90")
+	}
+	if p.Longitude != nil && (*p.Longitude < -180 || *p.Longitude > 180) {
+		return e.Validation("longitude must be between -180 and 180")
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "" || p.Timezone == "Local" {
+		return e.Validation("unknown timezone %q", p.Timezone)
+	}
+	if p.Capacity != nil && *p.Capacity < 0 {
+		return e.Validation("capacity must not be negative")
+	}
+	return validateWorkingHours(p.WorkingHours)
+}
+
+// applyPVZUpdate returns p with the fields set in u, so the result can be
+// validated as a whole.
+func applyPVZUpdate(p model.PVZ, u model.PVZUpdate) model.PVZ {
+	if u.City != nil {
+		p.City = *u.City
+	}
+	if u.Address != nil {
+		p.Address = *u.Address
+	}
+	if u.Latitude != nil {
+		p.Latitude = u.Latitude
+	}
+	if u.Longitude != nil {
+		p.Longitude = u.Longitude
+	}
+	if u.Timezone != nil {
+		p.Timezone = *u.Timezone
+	}
+	if u.WorkingHours != nil {
+		p.WorkingHours = *u.WorkingHours
+	}
+	if u.Capacity != nil {
+		p.Capacity = u.Capacity
+	}
+	if u.Status != nil {
+		p.Status = *u.Status
+	}
+	return p
+}
+
+func lockPVZ(ctx context.Context, r repo.Repository, id string) (model.PVZ, error) {
+	p, err := r.LockPVZ(ctx, id)
+	if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+		return model.PVZ{}, e.NotFound("pvz not found")
+	}
+	return p, err
+}
+
+// UpdatePVZ changes the fields set in u. Status may switch between active
+// and suspended; closing goes through DeactivatePVZ.
+func (s *service) UpdatePVZ(ctx context.Context, actor model.Actor, id string, u model.PVZUpdate) (model.PVZ, error) {
+	if err := s.require(actor, auth.PermPVZManage); err != nil {
+		return model.PVZ{}, err
+	}
+	if u.Status != nil && *u.Status != PVZActive && *u.Status != PVZSuspended {
+		return model.PVZ{}, e.Validation("status must be %s or %s", PVZActive, PVZSuspended)
+	}
+	var pvz model.PVZ
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		cur, err := lockPVZ(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		if cur.Status == PVZClosed {
+			return e.Precondition("pvz is closed")
+		}
+		if err := validatePVZ(applyPVZUpdate(cur, u)); err != nil {
+			return err
+		}
+		if u == (model.PVZUpdate{}) {
+			pvz = cur
+			return nil
+		}
+		pvz, err = r.UpdatePVZ(ctx, id, u)
+		return err
+	})
+	return pvz, e.WrapIfErr("failed to update PVZ", err)
+}
+
+// DeactivatePVZ closes a PVZ for good. Its open reception, if any, has to be
+// closed first.
+func (s *service) DeactivatePVZ(ctx context.Context, actor model.Actor, id string) (model.PVZ, error) {
+	if err := s.require(actor, auth.PermPVZManage); err != nil {
+		return model.PVZ{}, err
+	}
+	var pvz model.PVZ
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		cur, err := lockPVZ(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		if cur.Status == PVZClosed {
+			return e.Precondition("pvz is already closed")
+		}
+		if _, err := openReception(ctx, r, id); err == nil {
+			return e.Precondition("pvz has an open reception")
+		} else if !errors.Is(err, ErrNoOpenReception) {
+			return err
+		}
+		status := PVZClosed
+		pvz, err = r.UpdatePVZ(ctx, id, model.PVZUpdate{Status: &status})
+		return err
+	})
+	return pvz, e.WrapIfErr("failed to deactivate PVZ", err)
+}
+
+const (
+	DefaultNearbyRadiusKm = 5.0
+	MaxNearbyRadiusKm     = 100.0
+	// maxNearbyScan bounds how many PVZs are read when only open ones are
+	// wanted, since opening hours are checked after the query.
+	maxNearbyScan = 500
+)
+
+// isOpenAt reports whether an active PVZ is working at t according to its
+// hours in its own timezone.
+func isOpenAt(p model.PVZ, t time.Time) bool {
+	if p.Status != PVZActive {
+		return false
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return false
+	}
+	local := t.In(loc)
+	weekday := int(local.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	now := local.Hour()*60 + local.Minute()
+	for _, wh := range p.WorkingHours {
+		if wh.Weekday != weekday {
+			continue
+		}
+		opens, err1 := parseClock(wh.Opens)
+		closes, err2 := parseClock(wh.Closes)
+		return err1 == nil && err2 == nil && opens <= now && now < closes
+	}
+	return false
+}
+
+// NearbyPVZ finds active PVZs within radiusKm of a point, nearest first.
+// With openOnly it skips those closed at the moment.
+func (s *service) NearbyPVZ(ctx context.Context, actor model.Actor, lat, lon, radiusKm float64, limit int, openOnly bool) ([]model.NearbyPVZ, error) {
+	if err := s.require(actor, auth.PermPVZRead); err != nil {
+		return nil, err
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return nil, e.Validation("latitude must be between -90 and 90 and longitude between -180 and 180")
+	}
+	if radiusKm == 0 {
+		radiusKm = DefaultNearbyRadiusKm
+	}
+	if radiusKm < 0 || radiusKm > MaxNearbyRadiusKm {
+		return nil, e.Validation("radius must be between 0 and %g km", MaxNearbyRadiusKm)
+	}
+	limit, _, err := paginate(1, limit)
+	if err != nil {
+		return nil, err
+	}
+	scan := limit
+	if openOnly {
+		scan = maxNearbyScan
+	}
+	found, err := s.repo.NearbyPVZ(ctx, lat, lon, radiusKm, scan)
+	if err != nil {
+		return nil, e.Wrap("could not search PVZs", err)
+	}
+	now := time.Now()
+	res := []model.NearbyPVZ{}
+	for _, n := range found {
+		n.OpenNow = isOpenAt(n.PVZ, now)
+		if openOnly && !n.OpenNow {
+			continue
+		}
+		if res = append(res, n); len(res) == limit {
+			break
+		}
+	}
+	return res, nil
+}
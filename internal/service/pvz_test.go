@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func TestValidatePVZ(t *testing.T) {
+	lat, lon, neg := 55.75, 37.62, -1
+	bad := 91.0
+	ok := model.PVZ{City: "Москва", Timezone: "Europe/Moscow", Latitude: &lat, Longitude: &lon,
+		WorkingHours: []model.WorkingHours{{Weekday: 1, Opens: "09:00", Closes: "21:00"}, {Weekday: 7, Opens: "00:00", Closes: "24:00"}}}
+	assert.NoError(t, validatePVZ(ok))
+
+	cases := []func(p *model.PVZ){
+		func(p *model.PVZ) { p.Longitude = nil },
+		func(p *model.PVZ) { p.Latitude = &bad },
+		func(p *model.PVZ) { p.Timezone = "Mars/Olympus" },
+		func(p *model.PVZ) { p.Timezone = "" },
+		func(p *model.PVZ) { p.Capacity = &neg },
+		func(p *model.PVZ) {
+			p.WorkingHours = []model.WorkingHours{{Weekday: 8, Opens: "09:00", Closes: "21:00"}}
+		},
+		func(p *model.PVZ) {
+			p.WorkingHours = append(p.WorkingHours, model.WorkingHours{Weekday: 1, Opens: "10:00", Closes: "11:00"})
+		},
+		func(p *model.PVZ) {
+			p.WorkingHours = []model.WorkingHours{{Weekday: 2, Opens: "21:00", Closes: "09:00"}}
+		},
+		func(p *model.PVZ) { p.WorkingHours = []model.WorkingHours{{Weekday: 2, Opens: "9am", Closes: "21:00"}} },
+	}
+	for i, mutate := range cases {
+		p := ok
+		mutate(&p)
+		assert.Equal(t, e.KindValidation, e.KindOf(validatePVZ(p)), i)
+	}
+}
+
+func TestUpdatePVZ(t *testing.T) {
+	ctx := context.Background()
+	addr, suspended, closed, tz := "Тверская, 1", PVZSuspended, PVZClosed, "Asia/Tokyo"
+
+	_, err := New(&stubRepoSuccess{}, tokens).UpdatePVZ(ctx, employee, "p1", model.PVZUpdate{Address: &addr})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	p, err := New(&stubRepoSuccess{}, tokens).UpdatePVZ(ctx, moderator, "p1", model.PVZUpdate{Address: &addr, Status: &suspended, Timezone: &tz})
+	assert.NoError(t, err)
+	assert.Equal(t, addr, p.Address)
+	assert.Equal(t, PVZSuspended, p.Status)
+
+	_, err = New(&stubRepoSuccess{}, tokens).UpdatePVZ(ctx, moderator, "p1", model.PVZUpdate{Status: &closed})
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+
+	_, err = New(&stubRepoSuccess{pvzStatus: PVZClosed}, tokens).UpdatePVZ(ctx, moderator, "p1", model.PVZUpdate{Address: &addr})
+	assert.Equal(t, e.KindPrecondition, e.KindOf(err))
+
+	_, err = New(&missingPVZRepo{}, tokens).UpdatePVZ(ctx, moderator, "p1", model.PVZUpdate{Address: &addr})
+	assert.Equal(t, "pvz not found", e.Message(err))
+}
+
+func TestDeactivatePVZ(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := New(&stubRepoSuccess{}, tokens).DeactivatePVZ(ctx, moderator, "p1")
+	assert.Equal(t, "pvz has an open reception", e.Message(err))
+
+	p, err := New(&stubRepoSuccess{noOpenReception: true}, tokens).DeactivatePVZ(ctx, moderator, "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, PVZClosed, p.Status)
+
+	_, err = New(&stubRepoSuccess{pvzStatus: PVZClosed}, tokens).DeactivatePVZ(ctx, moderator, "p1")
+	assert.Equal(t, e.KindPrecondition, e.KindOf(err))
+}
+
+func TestOpenReception_InactivePVZ(t *testing.T) {
+	_, err := New(&stubRepoSuccess{noOpenReception: true, pvzStatus: PVZSuspended}, tokens).OpenReception(context.Background(), employee, "p1", nil)
+	assert.Equal(t, e.KindPrecondition, e.KindOf(err))
+	assert.Equal(t, "pvz is suspended, receptions cannot be opened", e.Message(err))
+}
+
+func TestIsOpenAt(t *testing.T) {
+	p := model.PVZ{Status: PVZActive, Timezone: "Asia/Vladivostok", WorkingHours: []model.WorkingHours{
+		{Weekday: 1, Opens: "09:00", Closes: "21:00"},
+		{Weekday: 7, Opens: "00:00", Closes: "24:00"},
+	}}
+	// Monday 10:00 in Vladivostok is Monday 00:00 UTC.
+	monday := time.Date(2025, 4, 21, 0, 0, 0, 0, time.UTC)
+	assert.True(t, isOpenAt(p, monday))
+	assert.False(t, isOpenAt(p, monday.Add(-2*time.Hour)))
+	assert.True(t, isOpenAt(p, monday.Add(-15*time.Hour)))
+	assert.False(t, isOpenAt(p, monday.Add(11*time.Hour)))
+	assert.False(t, isOpenAt(p, monday.Add(24*time.Hour)))
+
+	p.Status = PVZSuspended
+	assert.False(t, isOpenAt(p, monday))
+}
+
+type nearbyRepo struct {
+	stubRepoSuccess
+	found []model.NearbyPVZ
+	limit int
+}
+
+func (r *nearbyRepo) NearbyPVZ(_ context.Context, _, _, _ float64, limit int) ([]model.NearbyPVZ, error) {
+	r.limit = limit
+	return r.found, nil
+}
+
+func TestNearbyPVZ(t *testing.T) {
+	ctx := context.Background()
+	always := []model.WorkingHours{}
+	for d := 1; d <= 7; d++ {
+		always = append(always, model.WorkingHours{Weekday: d, Opens: "00:00", Closes: "24:00"})
+	}
+	r := &nearbyRepo{found: []model.NearbyPVZ{
+		{PVZ: model.PVZ{ID: "p1", Status: PVZActive, Timezone: DefaultPVZTimezone}, DistanceKm: 0.5},
+		{PVZ: model.PVZ{ID: "p2", Status: PVZActive, Timezone: DefaultPVZTimezone, WorkingHours: always}, DistanceKm: 1.5},
+	}}
+	svc := New(r, tokens)
+
+	list, err := svc.NearbyPVZ(ctx, employee, 55.75, 37.62, 0, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, r.limit)
+	assert.Len(t, list, 2)
+	assert.False(t, list[0].OpenNow)
+	assert.True(t, list[1].OpenNow)
+
+	list, err = svc.NearbyPVZ(ctx, employee, 55.75, 37.62, 5, 1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, maxNearbyScan, r.limit)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "p2", list[0].ID)
+
+	_, err = svc.NearbyPVZ(ctx, employee, 95, 37.62, 5, 10, false)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.NearbyPVZ(ctx, employee, 55.75, 37.62, MaxNearbyRadiusKm+1, 10, false)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+}
+
+// pagedRepo serves PVZs registered a day apart, newest first, honouring the
+// filter's cursor and limit the way the SQL does.
+type pagedRepo struct {
+	stubRepoSuccess
+	pvzs []model.PVZ
+}
+
+func newPagedRepo(n int) *pagedRepo {
+	r := &pagedRepo{}
+	day := time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		r.pvzs = append(r.pvzs, model.PVZ{ID: uuid.NewString(), RegistrationDate: day.AddDate(0, 0, -i)})
+	}
+	return r
+}
+
+func (r *pagedRepo) ListPVZWithReceptions(_ context.Context, f model.PVZFilter) ([]model.PVZWithReceptions, error) {
+	res := []model.PVZWithReceptions{}
+	for _, p := range r.pvzs[f.Offset:] {
+		if f.After != nil && !p.RegistrationDate.Before(f.After.RegistrationDate) {
+			continue
+		}
+		if len(res) == f.Limit {
+			break
+		}
+		res = append(res, model.PVZWithReceptions{PVZ: p})
+	}
+	return res, nil
+}
+
+func TestListPVZ_Cursor(t *testing.T) {
+	r := newPagedRepo(3)
+	s := New(r, tokens)
+
+	list, next, err := s.ListPVZ(context.Background(), "", "", 0, 2, "")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.NotEmpty(t, next)
+
+	list, next, err = s.ListPVZ(context.Background(), "", "", 0, 2, next)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, r.pvzs[2].ID, list[0].PVZ.ID)
+	assert.Empty(t, next)
+
+	_, next, err = s.ListPVZ(context.Background(), "", "", 1, 3, "")
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+
+	for _, bad := range []string{"!!", "bm9jb21tYQ", encodePVZCursor(model.PVZ{ID: "p1"})} {
+		_, _, err = s.ListPVZ(context.Background(), "", "", 0, 2, bad)
+		assert.ErrorIs(t, err, ErrInvalidCursor, bad)
+	}
+
+	_, _, err = s.ListPVZ(context.Background(), "", "", 2, 2, encodePVZCursor(r.pvzs[0]))
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+}
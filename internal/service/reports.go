@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+// Report statuses: a report with discrepancies stays open until a moderator
+// accepts it.
+const (
+	ReportMatched       = "matched"
+	ReportDiscrepancies = "discrepancies"
+	ReportAccepted      = "accepted"
+)
+
+var ErrReportNotFound = e.NotFound("reception report not found")
+
+func validateManifest(m *model.Manifest) error {
+	if m == nil {
+		return nil
+	}
+	m.Supplier = strings.TrimSpace(m.Supplier)
+	if len(m.Items) == 0 && len(m.Barcodes) == 0 {
+		return e.Validation("manifest must list expected items or barcodes")
+	}
+	seen := map[string]bool{}
+	for _, it := range m.Items {
+		if seen[it.Type] {
+			return e.Validation("product type %q is listed twice", it.Type)
+		}
+		seen[it.Type] = true
+		if it.Count <= 0 {
+			return e.Validation("expected count of %q must be positive", it.Type)
+		}
+	}
+	seenBarcodes := map[string]bool{}
+	for i, b := range m.Barcodes {
+		b = strings.TrimSpace(b)
+		if err := validateBarcode(b); err != nil {
+			return err
+		}
+		if seenBarcodes[b] {
+			return e.Validation("barcode %q is listed twice", b)
+		}
+		seenBarcodes[b] = true
+		m.Barcodes[i] = b
+	}
+	return nil
+}
+
+// compareManifest builds the discrepancy lines of a report from what a
+// reception expected and what it actually received. Barcodes are compared
+// only when the manifest lists them.
+func compareManifest(m model.Manifest, received map[string]int, barcodes []string) model.ReceptionReport {
+	rep := model.ReceptionReport{Supplier: m.Supplier}
+	expected := map[string]bool{}
+	for _, it := range m.Items {
+		expected[it.Type] = true
+		line := model.DiscrepancyLine{Type: it.Type, Expected: it.Count, Received: received[it.Type]}
+		switch {
+		case line.Received < line.Expected:
+			rep.Missing = append(rep.Missing, line)
+		case line.Received > line.Expected:
+			rep.Surplus = append(rep.Surplus, line)
+		}
+	}
+	for typ, n := range received {
+		if !expected[typ] && n > 0 {
+			rep.Unexpected = append(rep.Unexpected, model.DiscrepancyLine{Type: typ, Received: n})
+		}
+	}
+	sort.Slice(rep.Unexpected, func(i, j int) bool { return rep.Unexpected[i].Type < rep.Unexpected[j].Type })
+	if len(m.Barcodes) == 0 {
+		return rep
+	}
+	for _, b := range m.Barcodes {
+		if !slices.Contains(barcodes, b) {
+			rep.MissingBarcodes = append(rep.MissingBarcodes, b)
+		}
+	}
+	for _, b := range barcodes {
+		if !slices.Contains(m.Barcodes, b) {
+			rep.UnexpectedBarcodes = append(rep.UnexpectedBarcodes, b)
+		}
+	}
+	sort.Strings(rep.MissingBarcodes)
+	sort.Strings(rep.UnexpectedBarcodes)
+	return rep
+}
+
+func reportStatus(rep model.ReceptionReport) string {
+	switch {
+	case rep.AcceptedAt != nil:
+		return ReportAccepted
+	case len(rep.Missing)+len(rep.Surplus)+len(rep.Unexpected)+len(rep.MissingBarcodes)+len(rep.UnexpectedBarcodes) > 0:
+		return ReportDiscrepancies
+	default:
+		return ReportMatched
+	}
+}
+
+// createReport stores the report of a reception that is being closed.
+func createReport(ctx context.Context, r repo.Repository, rec model.Reception) error {
+	received, err := r.CountProductsByType(ctx, rec.ID)
+	if err != nil {
+		return err
+	}
+	var barcodes []string
+	if len(rec.Manifest.Barcodes) > 0 {
+		if barcodes, err = r.ListReceptionBarcodes(ctx, rec.ID); err != nil {
+			return err
+		}
+	}
+	rep := compareManifest(*rec.Manifest, received, barcodes)
+	rep.ReceptionID = rec.ID
+	return r.CreateReceptionReport(ctx, rep)
+}
+
+func getReport(ctx context.Context, r repo.Repository, receptionID string) (model.ReceptionReport, error) {
+	rep, err := r.GetReceptionReport(ctx, receptionID)
+	if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+		return model.ReceptionReport{}, ErrReportNotFound
+	}
+	if err != nil {
+		return model.ReceptionReport{}, err
+	}
+	rep.Status = reportStatus(rep)
+	return rep, nil
+}
+
+// ReceptionReport returns the discrepancy report of a closed reception that
+// was opened against a manifest.
+func (s *service) ReceptionReport(ctx context.Context, actor model.Actor, receptionID string) (model.ReceptionReport, error) {
+	if err := s.require(actor, auth.PermPVZRead); err != nil {
+		return model.ReceptionReport{}, err
+	}
+	rep, err := getReport(ctx, s.repo, receptionID)
+	return rep, e.WrapIfErr("could not get reception report", err)
+}
+
+// AcceptReceptionReport lets a moderator sign off the discrepancies of a
+// report.
+func (s *service) AcceptReceptionReport(ctx context.Context, actor model.Actor, receptionID, comment string) (model.ReceptionReport, error) {
+	if err := s.require(actor, auth.PermReceptionAccept); err != nil {
+		return model.ReceptionReport{}, err
+	}
+	var rep model.ReceptionReport
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		cur, err := getReport(ctx, r, receptionID)
+		if err != nil {
+			return err
+		}
+		switch cur.Status {
+		case ReportMatched:
+			return e.Precondition("reception report has no discrepancies to accept")
+		case ReportAccepted:
+			return e.Precondition("reception report already accepted")
+		}
+		if err := r.AcceptReceptionReport(ctx, receptionID, actor.UserID, strings.TrimSpace(comment)); err != nil {
+			return err
+		}
+		rep, err = getReport(ctx, r, receptionID)
+		return err
+	})
+	return rep, e.WrapIfErr("failed to accept reception report", err)
+}
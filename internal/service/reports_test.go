@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type reportRepo struct {
+	stubRepoSuccess
+	manifest *model.Manifest
+	received map[string]int
+	reports  map[string]model.ReceptionReport
+}
+
+func newReportRepo(m *model.Manifest, received map[string]int) *reportRepo {
+	return &reportRepo{manifest: m, received: received, reports: map[string]model.ReceptionReport{}}
+}
+
+func (r *reportRepo) GetOpenReception(_ context.Context, pvzID string) (model.Reception, error) {
+	return model.Reception{ID: "r1", PVZID: pvzID, Status: "in_progress", Manifest: r.manifest}, nil
+}
+func (r *reportRepo) CountProductsByType(context.Context, string) (map[string]int, error) {
+	return r.received, nil
+}
+func (r *reportRepo) CreateReceptionReport(_ context.Context, rep model.ReceptionReport) error {
+	rep.CreatedAt = time.Now()
+	r.reports[rep.ReceptionID] = rep
+	return nil
+}
+func (r *reportRepo) GetReceptionReport(_ context.Context, id string) (model.ReceptionReport, error) {
+	rep, ok := r.reports[id]
+	if !ok {
+		return model.ReceptionReport{}, e.NotFound("get reception report: not found")
+	}
+	return rep, nil
+}
+func (r *reportRepo) AcceptReceptionReport(_ context.Context, id, userID, comment string) error {
+	rep := r.reports[id]
+	now := time.Now()
+	rep.AcceptedBy, rep.AcceptedAt, rep.Comment = &userID, &now, comment
+	r.reports[id] = rep
+	return nil
+}
+func (r *reportRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+
+func TestValidateManifest(t *testing.T) {
+	assert.NoError(t, validateManifest(nil))
+	assert.NoError(t, validateManifest(&model.Manifest{Items: []model.ManifestItem{{Type: "обувь", Count: 1}}}))
+	assert.NoError(t, validateManifest(&model.Manifest{Barcodes: []string{"4006381333931"}}))
+
+	for _, m := range []model.Manifest{
+		{},
+		{Items: []model.ManifestItem{{Type: "обувь", Count: 0}}},
+		{Items: []model.ManifestItem{{Type: "обувь", Count: 1}, {Type: "обувь", Count: 2}}},
+		{Barcodes: []string{"4006381333932"}},
+		{Barcodes: []string{"CODE-1", " CODE-1"}},
+	} {
+		assert.Equal(t, e.KindValidation, e.KindOf(validateManifest(&m)), m)
+	}
+}
+
+func TestCompareManifest(t *testing.T) {
+	m := model.Manifest{Supplier: "ООО Поставка", Items: []model.ManifestItem{{Type: "обувь", Count: 3}, {Type: "одежда", Count: 2}}}
+	rep := compareManifest(m, map[string]int{"обувь": 1, "одежда": 4, "электроника": 2}, nil)
+	assert.Equal(t, []model.DiscrepancyLine{{Type: "обувь", Expected: 3, Received: 1}}, rep.Missing)
+	assert.Equal(t, []model.DiscrepancyLine{{Type: "одежда", Expected: 2, Received: 4}}, rep.Surplus)
+	assert.Equal(t, []model.DiscrepancyLine{{Type: "электроника", Received: 2}}, rep.Unexpected)
+	assert.Equal(t, ReportDiscrepancies, reportStatus(rep))
+
+	rep = compareManifest(m, map[string]int{"обувь": 3, "одежда": 2}, []string{"CODE-1"})
+	assert.Equal(t, ReportMatched, reportStatus(rep))
+	assert.Empty(t, rep.UnexpectedBarcodes)
+
+	m = model.Manifest{Barcodes: []string{"4006381333931", "CODE-1"}}
+	rep = compareManifest(m, map[string]int{"обувь": 2}, []string{"CODE-1", "CODE-2"})
+	assert.Equal(t, []string{"4006381333931"}, rep.MissingBarcodes)
+	assert.Equal(t, []string{"CODE-2"}, rep.UnexpectedBarcodes)
+	assert.Equal(t, []model.DiscrepancyLine{{Type: "обувь", Received: 2}}, rep.Unexpected)
+	assert.Equal(t, ReportDiscrepancies, reportStatus(rep))
+}
+
+func TestCloseReception_Report(t *testing.T) {
+	m := &model.Manifest{Items: []model.ManifestItem{{Type: "обувь", Count: 3}}}
+	r := newReportRepo(m, map[string]int{"обувь": 2})
+	svc := New(r, tokens)
+
+	_, err := svc.CloseReception(context.Background(), employee, "p1")
+	assert.NoError(t, err)
+
+	rep, err := svc.ReceptionReport(context.Background(), employee, "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, ReportDiscrepancies, rep.Status)
+	assert.Equal(t, []model.DiscrepancyLine{{Type: "обувь", Expected: 3, Received: 2}}, rep.Missing)
+
+	r = newReportRepo(nil, nil)
+	_, err = New(r, tokens).CloseReception(context.Background(), employee, "p1")
+	assert.NoError(t, err)
+	assert.Empty(t, r.reports)
+	_, err = New(r, tokens).ReceptionReport(context.Background(), employee, "r1")
+	assert.ErrorIs(t, err, ErrReportNotFound)
+}
+
+func TestAcceptReceptionReport(t *testing.T) {
+	r := newReportRepo(&model.Manifest{Items: []model.ManifestItem{{Type: "обувь", Count: 3}}}, map[string]int{"обувь": 2})
+	svc := New(r, tokens)
+	_, err := svc.CloseReception(context.Background(), employee, "p1")
+	assert.NoError(t, err)
+
+	_, err = svc.AcceptReceptionReport(context.Background(), employee, "r1", "")
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	rep, err := svc.AcceptReceptionReport(context.Background(), moderator, "r1", " one pair damaged in transit ")
+	assert.NoError(t, err)
+	assert.Equal(t, ReportAccepted, rep.Status)
+	assert.Equal(t, moderator.UserID, *rep.AcceptedBy)
+	assert.Equal(t, "one pair damaged in transit", rep.Comment)
+
+	_, err = svc.AcceptReceptionReport(context.Background(), moderator, "r1", "")
+	assert.Equal(t, e.KindPrecondition, e.KindOf(err))
+
+	_, err = svc.AcceptReceptionReport(context.Background(), moderator, "r2", "")
+	assert.ErrorIs(t, err, ErrReportNotFound)
+
+	r.reports["r3"] = model.ReceptionReport{ReceptionID: "r3"}
+	_, err = svc.AcceptReceptionReport(context.Background(), moderator, "r3", "")
+	assert.Equal(t, e.KindPrecondition, e.KindOf(err))
+}
+
+func TestOpenReception_InvalidManifest(t *testing.T) {
+	_, err := New(&stubRepoSuccess{noOpenReception: true}, tokens).OpenReception(context.Background(), employee, "p1", &model.Manifest{})
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+
+	m := &model.Manifest{Supplier: " ООО Поставка ", Items: []model.ManifestItem{{Type: "обувь", Count: 3}}}
+	rec, err := New(&stubRepoSuccess{noOpenReception: true}, tokens).OpenReception(context.Background(), employee, "p1", m)
+	assert.NoError(t, err)
+	assert.Equal(t, "ООО Поставка", rec.Manifest.Supplier)
+}
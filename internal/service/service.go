@@ -1,202 +1,536 @@
 package service
 
 import (
-	"net/http"
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	openapi_types "github.com/oapi-codegen/runtime/types"
-	api "pvz-backend-service/internal/api/types"
 	"pvz-backend-service/internal/auth"
 	"pvz-backend-service/internal/metrics"
+	"pvz-backend-service/internal/model"
 	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
 )
 
-var _ api.ServerInterface = (*service)(nil)
+const (
+	RoleEmployee  = auth.RoleEmployee
+	RoleModerator = auth.RoleModerator
+	RoleAuditor   = auth.RoleAuditor
+	RoleAdmin     = auth.RoleAdmin
+)
+
+// Registration modes control who may use public self-registration.
+const (
+	RegistrationOpen     = "open"
+	RegistrationEmployee = "employee"
+	RegistrationInvite   = "invite"
+)
+
+var (
+	ErrInvalidCredentials  = e.Unauthorized("invalid credentials")
+	ErrInvalidRefreshToken = e.Unauthorized("invalid refresh token")
+	ErrRefreshTokenReused  = e.Unauthorized("refresh token reuse detected, session revoked")
+	ErrAccountDisabled     = e.Forbidden("account disabled")
+	ErrInvalidInvite       = e.Unauthorized("invalid or expired invitation")
+	ErrNoOpenReception     = e.NotFound("no open reception found")
+	ErrOpenReceptionExists = e.Conflict("open reception exists")
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	DummyLogin(ctx context.Context, role string) (string, error)
+	Register(ctx context.Context, email, password, role string) (model.TokenPair, error)
+	Login(ctx context.Context, email, password, clientIP string) (model.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error)
+	Logout(ctx context.Context, actor model.Actor, refreshToken string) error
+	LogoutAll(ctx context.Context, actor model.Actor) error
+	JWKS() auth.JWKS
+	OIDCLoginURL(ctx context.Context) (string, error)
+	OIDCCallback(ctx context.Context, state, code string) (model.TokenPair, error)
+	ListUsers(ctx context.Context, actor model.Actor, query, role string, page, limit int) ([]model.User, error)
+	ChangeUserRole(ctx context.Context, actor model.Actor, userID, role string) (model.User, error)
+	SetUserDisabled(ctx context.Context, actor model.Actor, userID string, disabled bool) (model.User, error)
+	ResetUserPassword(ctx context.Context, actor model.Actor, userID, password string) error
+	InviteUser(ctx context.Context, actor model.Actor, email, role string) (model.Invite, string, error)
+	AcceptInvite(ctx context.Context, token, password string) (model.TokenPair, error)
+	ListPVZEmployees(ctx context.Context, actor model.Actor, pvzID string) ([]model.User, error)
+	AssignEmployee(ctx context.Context, actor model.Actor, pvzID, userID string) error
+	UnassignEmployee(ctx context.Context, actor model.Actor, pvzID, userID string) error
+	CreateAPIKey(ctx context.Context, actor model.Actor, name string, scopes []string, expiresAt *time.Time) (model.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, actor model.Actor) ([]model.APIKey, error)
+	RotateAPIKey(ctx context.Context, actor model.Actor, id string) (model.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, actor model.Actor, id string) error
+	ListCities(ctx context.Context, actor model.Actor) ([]model.City, error)
+	CreateCity(ctx context.Context, actor model.Actor, name string) (model.City, error)
+	RenameCity(ctx context.Context, actor model.Actor, name, newName string) (model.City, error)
+	DeleteCity(ctx context.Context, actor model.Actor, name string) error
+	ListProductTypes(ctx context.Context, actor model.Actor) ([]model.ProductType, error)
+	CreateProductType(ctx context.Context, actor model.Actor, t model.ProductType) (model.ProductType, error)
+	UpdateProductType(ctx context.Context, actor model.Actor, name string, u model.ProductTypeUpdate) (model.ProductType, error)
+	DeleteProductType(ctx context.Context, actor model.Actor, name string) error
+	CreatePVZ(ctx context.Context, actor model.Actor, p model.PVZ) (model.PVZ, error)
+	UpdatePVZ(ctx context.Context, actor model.Actor, id string, u model.PVZUpdate) (model.PVZ, error)
+	DeactivatePVZ(ctx context.Context, actor model.Actor, id string) (model.PVZ, error)
+	ListPVZ(ctx context.Context, start, end string, page, limit int, after string) ([]model.PVZWithReceptions, string, error)
+	ListAllPVZ(ctx context.Context) ([]model.PVZ, error)
+	NearbyPVZ(ctx context.Context, actor model.Actor, lat, lon, radiusKm float64, limit int, openOnly bool) ([]model.NearbyPVZ, error)
+	OpenReception(ctx context.Context, actor model.Actor, pvzID string, m *model.Manifest) (model.Reception, error)
+	AddProduct(ctx context.Context, actor model.Actor, pvzID string, p model.Product) (model.Product, error)
+	AddProducts(ctx context.Context, actor model.Actor, pvzID string, products []model.Product, partial bool) (model.BatchResult, error)
+	ProductsByBarcode(ctx context.Context, actor model.Actor, barcode string) ([]model.Product, error)
+	DeleteLastProduct(ctx context.Context, actor model.Actor, pvzID string) error
+	DeleteProduct(ctx context.Context, actor model.Actor, pvzID, productID, barcode string) (model.Product, error)
+	CorrectProductType(ctx context.Context, actor model.Actor, pvzID, productID, productType string) (model.Product, error)
+	UndoProductOperations(ctx context.Context, actor model.Actor, pvzID string, steps int) ([]model.ProductOperation, error)
+	RedoProductOperations(ctx context.Context, actor model.Actor, pvzID string, steps int) ([]model.ProductOperation, error)
+	ProductOperations(ctx context.Context, actor model.Actor, pvzID string) ([]model.ProductOperation, error)
+	CloseReception(ctx context.Context, actor model.Actor, pvzID string) (model.Reception, error)
+	ReceptionReport(ctx context.Context, actor model.Actor, receptionID string) (model.ReceptionReport, error)
+	AcceptReceptionReport(ctx context.Context, actor model.Actor, receptionID, comment string) (model.ReceptionReport, error)
+}
 
 type service struct {
-	repo   repo.Repository
-	secret string
+	repo         repo.Repository
+	tokens       *auth.TokenManager
+	registration string
+	inviteTTL    time.Duration
+	throttle     LoginThrottle
+	passwords    auth.PasswordPolicy
+	perms        *auth.Permissions
+	oidc         OIDCProvider
 }
 
-func New(r repo.Repository, secret string) api.ServerInterface {
-	return &service{repo: r, secret: secret}
+type Option func(*service)
+
+func WithRegistrationMode(mode string) Option {
+	return func(s *service) { s.registration = mode }
 }
 
-func (s *service) PostDummyLogin(c *gin.Context) {
-	var body struct {
-		Role string `json:"role"`
+func WithInviteTTL(d time.Duration) Option {
+	return func(s *service) { s.inviteTTL = d }
+}
+
+func WithPasswordPolicy(p auth.PasswordPolicy) Option {
+	return func(s *service) { s.passwords = p }
+}
+
+func WithPermissions(p *auth.Permissions) Option {
+	return func(s *service) { s.perms = p }
+}
+
+func New(r repo.Repository, tokens *auth.TokenManager, opts ...Option) Service {
+	s := &service{repo: r, tokens: tokens, registration: RegistrationOpen, inviteTTL: 72 * time.Hour, throttle: DefaultLoginThrottle, passwords: auth.DefaultPasswordPolicy, perms: auth.DefaultPermissions()}
+	for _, opt := range opts {
+		opt(s)
 	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
-		return
+	return s
+}
+
+func (s *service) require(actor model.Actor, perm auth.Permission) error {
+	return s.perms.Require(actor, perm)
+}
+
+func (s *service) validRole(role string) error {
+	if !s.perms.IsRole(role) {
+		return e.Validation("unknown role %q", role)
 	}
-	tok, _ := auth.GenerateToken(uuid.NewString(), body.Role, s.secret)
-	c.JSON(http.StatusOK, gin.H{"token": tok})
+	return nil
 }
 
-func (s *service) PostRegister(c *gin.Context) {
-	var body struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-		Role     string `json:"role"`
+func paginate(page, limit int) (int, int, error) {
+	if page == 0 {
+		page = 1
 	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid registration data"})
-		return
+	if limit == 0 {
+		limit = 10
 	}
-	hash, _ := auth.HashPassword(body.Password)
-	user, err := s.repo.CreateUser(c.Request.Context(), body.Email, hash, body.Role)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "registration failed: " + err.Error()})
-		return
+	if page < 0 || limit < 0 {
+		return 0, 0, e.Validation("page and limit must be positive")
 	}
-	tok, _ := auth.GenerateToken(user.ID, user.Role, s.secret)
-	c.JSON(http.StatusCreated, gin.H{"token": tok})
+	return limit, (page - 1) * limit, nil
 }
 
-func (s *service) PostLogin(c *gin.Context) {
-	var body struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid login data"})
-		return
+// normalizeEmail lowercases email so addresses differing only in case map to
+// one account.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "", e.Validation("invalid email address %q", email)
+	}
+	return email, nil
+}
+
+// hashPassword checks the password against the policy before hashing it.
+func (s *service) hashPassword(password string) (string, error) {
+	if err := s.passwords.Validate(password); err != nil {
+		return "", err
 	}
-	user, err := s.repo.GetUserByEmail(c.Request.Context(), body.Email)
-	if err != nil || auth.CheckPassword(user.PasswordHash, body.Password) != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "invalid credentials"})
-		return
+	hash, err := auth.HashPassword(password)
+	return hash, e.WrapIfErr("failed to hash password", err)
+}
+
+func (s *service) DummyLogin(_ context.Context, role string) (string, error) {
+	if err := s.validRole(role); err != nil {
+		return "", err
 	}
-	tok, _ := auth.GenerateToken(user.ID, user.Role, s.secret)
-	c.JSON(http.StatusOK, gin.H{"token": tok})
+	return s.tokens.IssueAccessToken(uuid.NewString(), role)
 }
 
-func (s *service) PostPvz(c *gin.Context) {
-	if c.GetString("role") != "moderator" {
-		c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden: moderator role required"})
-		return
+func (s *service) Register(ctx context.Context, email, password, role string) (model.TokenPair, error) {
+	switch {
+	case s.registration == RegistrationInvite:
+		return model.TokenPair{}, e.Forbidden("registration is by invitation only")
+	case s.registration == RegistrationEmployee && role != RoleEmployee:
+		return model.TokenPair{}, e.Forbidden("public registration is limited to the %s role", RoleEmployee)
 	}
-	var body struct {
-		City string `json:"city"`
+	if err := s.validRole(role); err != nil {
+		return model.TokenPair{}, err
 	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid PVZ data"})
-		return
+	if s.perms.Allowed(role, auth.PermUserAdmin) {
+		return model.TokenPair{}, e.Forbidden("the %s role cannot be self-registered", role)
 	}
-	pvz, err := s.repo.CreatePVZ(c.Request.Context(), body.City)
+	email, err := normalizeEmail(email)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "failed to create PVZ: " + err.Error()})
-		return
+		return model.TokenPair{}, err
 	}
-	metrics.PvzCreated.Inc()
-	c.JSON(http.StatusCreated, pvz)
+	hash, err := s.hashPassword(password)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	user, err := s.repo.CreateUser(ctx, email, hash, role)
+	if e.IsKind(err, e.KindConflict) {
+		return model.TokenPair{}, e.Conflict("user with this email already exists")
+	}
+	if err != nil {
+		return model.TokenPair{}, e.Wrap("registration failed", err)
+	}
+	pair, _, err := s.issueSession(ctx, s.repo, user, "")
+	return pair, err
 }
 
-func (s *service) GetPvz(c *gin.Context, params api.GetPvzParams) {
-	start, end := "", ""
-	if params.StartDate != nil {
-		start = params.StartDate.Format(time.RFC3339)
+func (s *service) Login(ctx context.Context, email, password, clientIP string) (model.TokenPair, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	keys := s.loginKeys(email, clientIP)
+	if err := s.checkLoginLock(ctx, keys); err != nil {
+		return model.TokenPair{}, err
+	}
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil && !e.IsKind(err, e.KindNotFound) {
+		return model.TokenPair{}, e.Wrap("login failed", err)
 	}
-	if params.EndDate != nil {
-		end = params.EndDate.Format(time.RFC3339)
+	if err != nil {
+		auth.SimulatePasswordCheck(password)
+		return model.TokenPair{}, s.loginFailed(ctx, keys)
 	}
-	page, limit := 1, 10
-	if params.Page != nil {
-		page = int(*params.Page)
+	if auth.CheckPassword(user.PasswordHash, password) != nil {
+		return model.TokenPair{}, s.loginFailed(ctx, keys)
 	}
-	if params.Limit != nil {
-		limit = int(*params.Limit)
+	if user.DisabledAt != nil {
+		metrics.LoginFailures.WithLabelValues("disabled").Inc()
+		return model.TokenPair{}, ErrAccountDisabled
 	}
-	offset := (page - 1) * limit
-	list, err := s.repo.ListPVZ(c.Request.Context(), start, end, limit, offset)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "could not list PVZs: " + err.Error()})
-		return
+	if err := s.repo.ClearLoginFailures(ctx, keys[0].key); err != nil {
+		return model.TokenPair{}, e.Wrap("login failed", err)
 	}
-	c.JSON(http.StatusOK, list)
+	pair, _, err := s.issueSession(ctx, s.repo, user, "")
+	return pair, err
 }
 
-func (s *service) PostReceptions(c *gin.Context) {
-	if c.GetString("role") != "employee" {
-		c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden: employee role required"})
-		return
+// issueSession mints an access token and a refresh token; an empty familyID
+// starts a new refresh token family (a new login session).
+func (s *service) issueSession(ctx context.Context, r repo.Repository, user model.User, familyID string) (model.TokenPair, string, error) {
+	access, err := s.tokens.IssueAccessToken(user.ID, user.Role)
+	if err != nil {
+		return model.TokenPair{}, "", err
+	}
+	refresh, hash, expiresAt, err := s.tokens.NewRefreshToken()
+	if err != nil {
+		return model.TokenPair{}, "", err
 	}
-	var body struct {
-		PVZID openapi_types.UUID `json:"pvzId"`
+	if familyID == "" {
+		familyID = uuid.NewString()
 	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid reception data"})
-		return
+	rt := model.RefreshToken{ID: uuid.NewString(), UserID: user.ID, FamilyID: familyID, TokenHash: hash, ExpiresAt: expiresAt}
+	if err := r.CreateRefreshToken(ctx, rt); err != nil {
+		return model.TokenPair{}, "", e.Wrap("failed to store refresh token", err)
 	}
-	rec, err := s.repo.OpenReception(c.Request.Context(), body.PVZID.String())
+	return model.TokenPair{Token: access, RefreshToken: refresh}, rt.ID, nil
+}
+
+func (s *service) Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error) {
+	var pair model.TokenPair
+	reused := false
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		rt, err := r.GetRefreshToken(ctx, auth.HashToken(refreshToken))
+		if e.IsKind(err, e.KindNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+		if rt.RevokedAt != nil {
+			reused = true
+			return r.RevokeRefreshTokenFamily(ctx, rt.FamilyID)
+		}
+		if time.Now().After(rt.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+		user, err := r.GetUserByID(ctx, rt.UserID)
+		if err != nil {
+			return e.Wrap("failed to load token owner", err)
+		}
+		if user.DisabledAt != nil {
+			return ErrInvalidRefreshToken
+		}
+		var newID string
+		if pair, newID, err = s.issueSession(ctx, r, user, rt.FamilyID); err != nil {
+			return err
+		}
+		return r.RevokeRefreshToken(ctx, rt.ID, newID)
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "failed to open reception: " + err.Error()})
-		return
+		return model.TokenPair{}, err
 	}
-	metrics.ReceptionCreated.Inc()
-	c.JSON(http.StatusCreated, rec)
+	if reused {
+		return model.TokenPair{}, ErrRefreshTokenReused
+	}
+	return pair, nil
+}
+
+func (s *service) Logout(ctx context.Context, actor model.Actor, refreshToken string) error {
+	return s.repo.WithTx(ctx, func(r repo.Repository) error {
+		if actor.TokenID != "" {
+			if err := r.RevokeAccessToken(ctx, actor.TokenID, actor.TokenExpiresAt); err != nil {
+				return err
+			}
+		}
+		if refreshToken == "" {
+			return nil
+		}
+		rt, err := r.GetRefreshToken(ctx, auth.HashToken(refreshToken))
+		if e.IsKind(err, e.KindNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+		if rt.UserID != actor.UserID {
+			return e.Forbidden("refresh token belongs to another user")
+		}
+		return r.RevokeRefreshTokenFamily(ctx, rt.FamilyID)
+	})
 }
 
-func (s *service) PostProducts(c *gin.Context) {
-	if c.GetString("role") != "employee" {
-		c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden: employee role required"})
-		return
+func (s *service) LogoutAll(ctx context.Context, actor model.Actor) error {
+	return e.WrapIfErr("failed to revoke sessions", s.repo.RevokeUserTokens(ctx, actor.UserID))
+}
+
+func (s *service) JWKS() auth.JWKS {
+	return s.tokens.JWKS()
+}
+
+func (s *service) CreatePVZ(ctx context.Context, actor model.Actor, p model.PVZ) (model.PVZ, error) {
+	if err := s.require(actor, auth.PermPVZCreate); err != nil {
+		return model.PVZ{}, err
 	}
-	var body struct {
-		PVZID openapi_types.UUID `json:"pvzId"`
-		Type  string             `json:"type"`
+	p.Status = PVZActive
+	if p.Timezone == "" {
+		p.Timezone = DefaultPVZTimezone
 	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid product data"})
-		return
+	if err := validatePVZ(p); err != nil {
+		return model.PVZ{}, err
 	}
-	rec, err := s.repo.GetOpenReception(c.Request.Context(), body.PVZID.String())
+	pvz, err := s.repo.CreatePVZ(ctx, p)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "no open reception found"})
-		return
+		return model.PVZ{}, e.Wrap("failed to create PVZ", err)
 	}
-	prod, err := s.repo.AddProduct(c.Request.Context(), rec.ID, body.Type)
+	metrics.PvzCreated.Inc()
+	return pvz, nil
+}
+
+// ListPVZ returns a page of PVZs, newest first, and the cursor of the next
+// page, empty on the last one. A cursor from an earlier call in after
+// continues the listing from there instead of the page offset.
+func (s *service) ListPVZ(ctx context.Context, start, end string, page, limit int, after string) ([]model.PVZWithReceptions, string, error) {
+	limit, offset, err := paginate(page, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	// One extra row tells whether another page follows.
+	f := model.PVZFilter{Start: start, End: end, Limit: limit + 1, Offset: offset}
+	if after != "" {
+		if page > 1 {
+			return nil, "", e.Validation("page cannot be combined with a cursor")
+		}
+		if f.After, err = decodePVZCursor(after); err != nil {
+			return nil, "", err
+		}
+	}
+	list, err := s.repo.ListPVZWithReceptions(ctx, f)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "failed to add product: " + err.Error()})
-		return
+		return nil, "", e.Wrap("could not list PVZs", err)
 	}
-	metrics.ProductsAdded.Inc()
-	c.JSON(http.StatusCreated, prod)
+	next := ""
+	if len(list) > limit {
+		list = list[:limit]
+		next = encodePVZCursor(list[limit-1].PVZ)
+	}
+	return list, next, nil
 }
 
-func (s *service) PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId openapi_types.UUID) {
-	if c.GetString("role") != "employee" {
-		c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden: employee role required"})
-		return
-	}
-	rec, err := s.repo.GetOpenReception(c.Request.Context(), pvzId.String())
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "no open reception found"})
-		return
+// pvzBatch is how many PVZs ListAllPVZ reads per query.
+const pvzBatch = 500
+
+// ListAllPVZ returns every PVZ without receptions, newest first, reading them
+// in batches by cursor.
+func (s *service) ListAllPVZ(ctx context.Context) ([]model.PVZ, error) {
+	res := []model.PVZ{}
+	f := model.PVZFilter{Limit: pvzBatch}
+	for {
+		list, err := s.repo.ListPVZ(ctx, f)
+		if err != nil {
+			return nil, e.Wrap("could not list PVZs", err)
+		}
+		res = append(res, list...)
+		if len(list) < pvzBatch {
+			return res, nil
+		}
+		last := list[len(list)-1]
+		f.After = &model.PVZCursor{RegistrationDate: last.RegistrationDate, ID: last.ID}
 	}
-	if err := s.repo.DeleteLastProduct(c.Request.Context(), rec.ID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "failed to delete last product: " + err.Error()})
-		return
+}
+
+func openReception(ctx context.Context, r repo.Repository, pvzID string) (model.Reception, error) {
+	rec, err := r.GetOpenReception(ctx, pvzID)
+	if e.IsKind(err, e.KindNotFound) {
+		return model.Reception{}, ErrNoOpenReception
 	}
-	c.Status(http.StatusOK)
+	return rec, e.WrapIfErr("failed to get open reception", err)
 }
 
-func (s *service) PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId openapi_types.UUID) {
-	if c.GetString("role") != "employee" {
-		c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden: employee role required"})
-		return
+// OpenReception opens a reception, optionally against a manifest of the
+// expected delivery that is checked when the reception is closed.
+func (s *service) OpenReception(ctx context.Context, actor model.Actor, pvzID string, m *model.Manifest) (model.Reception, error) {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermReceptionOpen, pvzID); err != nil {
+		return model.Reception{}, err
+	}
+	if err := validateManifest(m); err != nil {
+		return model.Reception{}, err
+	}
+	var rec model.Reception
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		pvz, err := r.LockPVZ(ctx, pvzID)
+		if err != nil {
+			if e.IsKind(err, e.KindNotFound) {
+				return e.NotFound("pvz not found")
+			}
+			return err
+		}
+		if pvz.Status != PVZActive {
+			return e.Precondition("pvz is %s, receptions cannot be opened", pvz.Status)
+		}
+		if err := checkManifestTypes(ctx, r, m); err != nil {
+			return err
+		}
+		if _, err := openReception(ctx, r, pvzID); err == nil {
+			return ErrOpenReceptionExists
+		} else if !errors.Is(err, ErrNoOpenReception) {
+			return err
+		}
+		rec, err = r.OpenReception(ctx, pvzID, m)
+		if e.IsKind(err, e.KindConflict) {
+			return ErrOpenReceptionExists
+		}
+		return e.WrapIfErr("failed to open reception", err)
+	})
+	if err != nil {
+		return model.Reception{}, err
 	}
-	rec, err := s.repo.GetOpenReception(c.Request.Context(), pvzId.String())
+	metrics.ReceptionCreated.Inc()
+	return rec, nil
+}
+
+func (s *service) AddProduct(ctx context.Context, actor model.Actor, pvzID string, p model.Product) (model.Product, error) {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermProductAdd, pvzID); err != nil {
+		return model.Product{}, err
+	}
+	if err := validateProduct(&p); err != nil {
+		return model.Product{}, err
+	}
+	var prod model.Product
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		t, err := productType(ctx, r, p.Type)
+		if err != nil {
+			return err
+		}
+		if err := fitsType(p, t); err != nil {
+			return err
+		}
+		rec, err := openReception(ctx, r, pvzID)
+		if err != nil {
+			return err
+		}
+		prod, err = r.AddProduct(ctx, rec.ID, p)
+		if e.IsKind(err, e.KindConflict) {
+			return e.Conflict("barcode %q is already in this reception", p.Barcode)
+		}
+		if err != nil {
+			return e.Wrap("failed to add product", err)
+		}
+		return recordOps(ctx, r, actor, productOps(OpAdd, prod)...)
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "no open reception found"})
-		return
+		return model.Product{}, err
 	}
-	if err := s.repo.CloseReception(c.Request.Context(), rec.ID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "failed to close reception: " + err.Error()})
-		return
+	metrics.ProductsAdded.Inc()
+	return prod, nil
+}
+
+func (s *service) DeleteLastProduct(ctx context.Context, actor model.Actor, pvzID string) error {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermProductDelete, pvzID); err != nil {
+		return err
+	}
+	return s.repo.WithTx(ctx, func(r repo.Repository) error {
+		rec, err := openReception(ctx, r, pvzID)
+		if err != nil {
+			return err
+		}
+		p, err := r.DeleteLastProduct(ctx, rec.ID)
+		if e.IsKind(err, e.KindNotFound) {
+			return e.NotFound("no products to delete in the open reception")
+		}
+		if err != nil {
+			return e.Wrap("failed to delete last product", err)
+		}
+		return recordOps(ctx, r, actor, productOps(OpDelete, p)...)
+	})
+}
+
+func (s *service) CloseReception(ctx context.Context, actor model.Actor, pvzID string) (model.Reception, error) {
+	if err := s.requirePVZ(ctx, s.repo, actor, auth.PermReceptionClose, pvzID); err != nil {
+		return model.Reception{}, err
+	}
+	var rec model.Reception
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		var err error
+		if rec, err = openReception(ctx, r, pvzID); err != nil {
+			return err
+		}
+		if err := r.CloseReception(ctx, rec.ID); err != nil {
+			return e.Wrap("failed to close reception", err)
+		}
+		if rec.Manifest == nil {
+			return nil
+		}
+		return e.WrapIfErr("failed to create reception report", createReport(ctx, r, rec))
+	})
+	if err != nil {
+		return model.Reception{}, err
 	}
-	c.JSON(http.StatusOK, rec)
+	rec.Status = "close"
+	return rec, nil
 }
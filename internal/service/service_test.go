@@ -1,23 +1,30 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"errors"
-	"net/http"
-	"net/http/httptest"
-	"pvz-backend-service/internal/auth"
 	"testing"
+	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
-	api "pvz-backend-service/internal/api/types"
+	"pvz-backend-service/internal/auth"
 	"pvz-backend-service/internal/model"
 	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+var (
+	employee  = model.Actor{UserID: "u1", Role: RoleEmployee}
+	moderator = model.Actor{UserID: "u2", Role: RoleModerator}
 )
 
-type stubRepoSuccess struct{}
+var tokens = auth.NewTokenManager(auth.NewHMACKeySet("secret"), time.Minute, time.Hour, nil)
+
+type stubRepoSuccess struct {
+	repo.Repository
+	noOpenReception bool
+	pvzStatus       string
+}
 
 var _ repo.Repository = (*stubRepoSuccess)(nil)
 
@@ -28,245 +35,278 @@ func (s *stubRepoSuccess) GetUserByEmail(_ context.Context, email string) (model
 	hash, _ := auth.HashPassword("p")
 	return model.User{ID: "u1", Email: email, PasswordHash: hash, Role: "employee"}, nil
 }
-func (s *stubRepoSuccess) CreatePVZ(_ context.Context, city string) (model.PVZ, error) {
-	return model.PVZ{ID: "p1", City: city}, nil
+func (s *stubRepoSuccess) CreatePVZ(_ context.Context, p model.PVZ) (model.PVZ, error) {
+	p.ID = "p1"
+	return p, nil
 }
-func (s *stubRepoSuccess) ListPVZ(_ context.Context, _, _ string, _, _ int) ([]model.PVZ, error) {
+func (s *stubRepoSuccess) UpdatePVZ(ctx context.Context, id string, u model.PVZUpdate) (model.PVZ, error) {
+	p, _ := s.LockPVZ(ctx, id)
+	return applyPVZUpdate(p, u), nil
+}
+func (s *stubRepoSuccess) ListPVZ(_ context.Context, _ model.PVZFilter) ([]model.PVZ, error) {
 	return []model.PVZ{{ID: "p1", City: "Москва"}}, nil
 }
-func (s *stubRepoSuccess) OpenReception(_ context.Context, pvzID string) (model.Reception, error) {
-	return model.Reception{ID: "r1", PVZID: pvzID}, nil
+func (s *stubRepoSuccess) ListPVZWithReceptions(_ context.Context, _ model.PVZFilter) ([]model.PVZWithReceptions, error) {
+	return []model.PVZWithReceptions{{PVZ: model.PVZ{ID: "p1", City: "Москва"}, Receptions: []model.ReceptionWithProducts{}}}, nil
+}
+func (s *stubRepoSuccess) OpenReception(_ context.Context, pvzID string, m *model.Manifest) (model.Reception, error) {
+	return model.Reception{ID: "r1", PVZID: pvzID, Status: "in_progress", Manifest: m}, nil
+}
+func (s *stubRepoSuccess) FindOpenReception(ctx context.Context, pvzID string) (model.Reception, error) {
+	return s.GetOpenReception(ctx, pvzID)
 }
 func (s *stubRepoSuccess) GetOpenReception(_ context.Context, pvzID string) (model.Reception, error) {
-	return model.Reception{ID: "r1", PVZID: pvzID}, nil
+	if s.noOpenReception {
+		return model.Reception{}, e.NotFound("get open reception: not found")
+	}
+	return model.Reception{ID: "r1", PVZID: pvzID, Status: "in_progress"}, nil
+}
+func (s *stubRepoSuccess) AddProduct(_ context.Context, recID string, p model.Product) (model.Product, error) {
+	p.ID, p.ReceptionID = "pr1", recID
+	return p, nil
+}
+func (s *stubRepoSuccess) GetProductType(_ context.Context, name string) (model.ProductType, error) {
+	return model.ProductType{Name: name}, nil
 }
-func (s *stubRepoSuccess) AddProduct(_ context.Context, recID, typ string) (model.Product, error) {
-	return model.Product{ID: "pr1", ReceptionID: recID, Type: typ}, nil
+func (s *stubRepoSuccess) ListProductTypes(_ context.Context) ([]model.ProductType, error) {
+	return []model.ProductType{{Name: "электроника"}, {Name: "одежда"}, {Name: "обувь"}}, nil
 }
-func (s *stubRepoSuccess) DeleteLastProduct(_ context.Context, recID string) error {
+func (s *stubRepoSuccess) DeleteLastProduct(_ context.Context, recID string) (model.Product, error) {
+	return model.Product{ID: "pr1", ReceptionID: recID, Type: "обувь"}, nil
+}
+func (s *stubRepoSuccess) DiscardUndoneOperations(_ context.Context, _ string) error {
 	return nil
 }
+func (s *stubRepoSuccess) RecordProductOperations(_ context.Context, ops []model.ProductOperation) ([]model.ProductOperation, error) {
+	return ops, nil
+}
 func (s *stubRepoSuccess) CloseReception(_ context.Context, recID string) error {
 	return nil
 }
+func (s *stubRepoSuccess) CreateRefreshToken(_ context.Context, _ model.RefreshToken) error {
+	return nil
+}
+func (s *stubRepoSuccess) LoginLockedUntil(_ context.Context, _ []string) (time.Time, error) {
+	return time.Time{}, nil
+}
+func (s *stubRepoSuccess) RecordLoginFailure(_ context.Context, _ string, _ time.Time) (int, error) {
+	return 1, nil
+}
+func (s *stubRepoSuccess) ClearLoginFailures(_ context.Context, _ string) error {
+	return nil
+}
+func (s *stubRepoSuccess) LockPVZ(_ context.Context, id string) (model.PVZ, error) {
+	status := s.pvzStatus
+	if status == "" {
+		status = PVZActive
+	}
+	return model.PVZ{ID: id, City: "Москва", Timezone: DefaultPVZTimezone, Status: status}, nil
+}
+func (s *stubRepoSuccess) IsAssigned(_ context.Context, _, _ string) (bool, error) {
+	return true, nil
+}
+func (s *stubRepoSuccess) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(s)
+}
+
+type conflictRepo struct {
+	stubRepoSuccess
+}
 
-type stubRepoError struct{}
+func (c *conflictRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(c)
+}
+
+func (c *conflictRepo) OpenReception(_ context.Context, _ string, _ *model.Manifest) (model.Reception, error) {
+	return model.Reception{}, e.New(e.KindConflict, "open reception: already exists", nil)
+}
+
+type stubRepoError struct {
+	repo.Repository
+}
 
 var _ repo.Repository = (*stubRepoError)(nil)
 
 func (r *stubRepoError) CreateUser(_ context.Context, _, _, _ string) (model.User, error) {
 	return model.User{}, errors.New("db create user failed")
 }
+func (r *stubRepoError) LoginLockedUntil(_ context.Context, _ []string) (time.Time, error) {
+	return time.Time{}, nil
+}
 func (r *stubRepoError) GetUserByEmail(_ context.Context, _ string) (model.User, error) {
 	return model.User{}, errors.New("db get user failed")
 }
-func (r *stubRepoError) CreatePVZ(_ context.Context, _ string) (model.PVZ, error) {
+func (r *stubRepoError) CreatePVZ(_ context.Context, _ model.PVZ) (model.PVZ, error) {
 	return model.PVZ{}, errors.New("db create pvz failed")
 }
-func (r *stubRepoError) ListPVZ(_ context.Context, _, _ string, _, _ int) ([]model.PVZ, error) {
+func (r *stubRepoError) ListPVZ(_ context.Context, _ model.PVZFilter) ([]model.PVZ, error) {
 	return nil, errors.New("db list pvz failed")
 }
-func (r *stubRepoError) OpenReception(_ context.Context, _ string) (model.Reception, error) {
+func (r *stubRepoError) ListPVZWithReceptions(_ context.Context, _ model.PVZFilter) ([]model.PVZWithReceptions, error) {
+	return nil, errors.New("db list pvz failed")
+}
+func (r *stubRepoError) OpenReception(_ context.Context, _ string, _ *model.Manifest) (model.Reception, error) {
 	return model.Reception{}, errors.New("db open reception failed")
 }
 func (r *stubRepoError) GetOpenReception(_ context.Context, _ string) (model.Reception, error) {
 	return model.Reception{}, errors.New("db get open reception failed")
 }
-func (r *stubRepoError) AddProduct(_ context.Context, _, _ string) (model.Product, error) {
+func (r *stubRepoError) AddProduct(_ context.Context, _ string, _ model.Product) (model.Product, error) {
 	return model.Product{}, errors.New("db add product failed")
 }
-func (r *stubRepoError) DeleteLastProduct(_ context.Context, _ string) error {
-	return errors.New("db delete product failed")
+func (r *stubRepoError) DeleteLastProduct(_ context.Context, _ string) (model.Product, error) {
+	return model.Product{}, errors.New("db delete product failed")
 }
 func (r *stubRepoError) CloseReception(_ context.Context, _ string) error {
 	return errors.New("db close reception failed")
 }
+func (r *stubRepoError) LockPVZ(_ context.Context, _ string) (model.PVZ, error) {
+	return model.PVZ{}, errors.New("db lock pvz failed")
+}
+func (r *stubRepoError) IsAssigned(_ context.Context, _, _ string) (bool, error) {
+	return true, nil
+}
+func (r *stubRepoError) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
 
-func newContext(method, path, body string) (*gin.Context, *httptest.ResponseRecorder) {
-	gin.SetMode(gin.TestMode)
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest(method, path, bytes.NewBufferString(body))
-	if body != "" {
-		c.Request.Header.Set("Content-Type", "application/json")
-	}
-	return c, w
+func TestDummyLogin(t *testing.T) {
+	svc := New(&stubRepoSuccess{}, tokens)
+	tok, err := svc.DummyLogin(context.Background(), RoleEmployee)
+	assert.NoError(t, err)
+	claims, err := tokens.ParseToken(tok)
+	assert.NoError(t, err)
+	assert.Equal(t, RoleEmployee, claims.Role)
+
+	_, err = svc.DummyLogin(context.Background(), "root")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+}
+
+func TestRegister(t *testing.T) {
+	pair, err := New(&stubRepoSuccess{}, tokens).Register(context.Background(), "a@b", "Secret-pass1", RoleEmployee)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.Token)
+	assert.NotEmpty(t, pair.RefreshToken)
+}
+
+func TestRegister_DBError(t *testing.T) {
+	_, err := New(&stubRepoError{}, tokens).Register(context.Background(), "a@b", "Secret-pass1", RoleEmployee)
+	assert.ErrorContains(t, err, "registration failed")
 }
 
-func TestPostDummyLogin(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/dummyLogin", `{"role":"employee"}`)
-	svc.PostDummyLogin(c)
-	assert.Equal(t, http.StatusOK, w.Code)
+func TestLogin(t *testing.T) {
+	svc := New(&stubRepoSuccess{}, tokens)
+	pair, err := svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.Token)
+	assert.NotEmpty(t, pair.RefreshToken)
+
+	_, err = svc.Login(context.Background(), "a@b", "wrong", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = New(&stubRepoError{}, tokens).Login(context.Background(), "a@b", "p", "10.0.0.1")
+	assert.Equal(t, e.KindInternal, e.KindOf(err))
+}
+
+func TestCreatePVZ(t *testing.T) {
+	svc := New(&stubRepoSuccess{}, tokens)
+	pvz, err := svc.CreatePVZ(context.Background(), moderator, model.PVZ{City: "Казань"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Казань", pvz.City)
+	assert.Equal(t, PVZActive, pvz.Status)
+	assert.Equal(t, DefaultPVZTimezone, pvz.Timezone)
+
+	_, err = svc.CreatePVZ(context.Background(), employee, model.PVZ{City: "Казань"})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	_, err = New(&stubRepoError{}, tokens).CreatePVZ(context.Background(), moderator, model.PVZ{City: "Казань"})
+	assert.ErrorContains(t, err, "failed to create PVZ")
+}
+
+func TestListPVZ(t *testing.T) {
+	list, next, err := New(&stubRepoSuccess{}, tokens).ListPVZ(context.Background(), "", "", 0, 0, "")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Empty(t, next)
+
+	_, _, err = New(&stubRepoSuccess{}, tokens).ListPVZ(context.Background(), "", "", -1, 10, "")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+
+	_, _, err = New(&stubRepoError{}, tokens).ListPVZ(context.Background(), "", "", 1, 10, "")
+	assert.Error(t, err)
 }
 
-func TestPostRegister(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/register", `{"email":"a@b","password":"p","role":"employee"}`)
-	svc.PostRegister(c)
-	assert.Equal(t, http.StatusCreated, w.Code)
+func TestOpenReception(t *testing.T) {
+	rec, err := New(&stubRepoSuccess{noOpenReception: true}, tokens).OpenReception(context.Background(), employee, "p1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", rec.PVZID)
 }
 
-func TestPostRegister_DBError(t *testing.T) {
-	svc := New(&stubRepoError{}, "secret")
-	c, w := newContext("POST", "/register", `{"email":"a@b","password":"p","role":"employee"}`)
-	svc.PostRegister(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+func TestOpenReception_OnePerPVZ(t *testing.T) {
+	_, err := New(&stubRepoSuccess{}, tokens).OpenReception(context.Background(), employee, "p1", nil)
+	assert.ErrorIs(t, err, ErrOpenReceptionExists)
 }
 
-func TestPostLogin(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/login", `{"email":"a@b","password":"p"}`)
-	svc.PostLogin(c)
-	assert.Equal(t, http.StatusOK, w.Code)
+func TestOpenReception_RaceConflict(t *testing.T) {
+	svc := New(&conflictRepo{stubRepoSuccess{noOpenReception: true}}, tokens)
+	_, err := svc.OpenReception(context.Background(), employee, "p1", nil)
+	assert.ErrorIs(t, err, ErrOpenReceptionExists)
+	assert.Equal(t, e.KindConflict, e.KindOf(err))
 }
 
-func TestPostLogin_BadCreds(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/login", `{"email":"a@b","password":"wrong"}`)
-	svc.PostLogin(c)
-	assert.Equal(t, http.StatusUnauthorized, w.Code)
+func TestOpenReception_Forbidden(t *testing.T) {
+	_, err := New(&stubRepoSuccess{noOpenReception: true}, tokens).OpenReception(context.Background(), moderator, "p1", nil)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
 }
 
-func TestPostPvz(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/pvz", `{"city":"Казань"}`)
-	c.Set("role", "moderator")
-	svc.PostPvz(c)
-	assert.Equal(t, http.StatusCreated, w.Code)
+func TestOpenReception_DBError(t *testing.T) {
+	_, err := New(&stubRepoError{}, tokens).OpenReception(context.Background(), employee, "p1", nil)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrOpenReceptionExists)
 }
 
-func TestPostPvz_Forbidden(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/pvz", `{"city":"Казань"}`)
-	c.Set("role", "employee")
-	svc.PostPvz(c)
-	assert.Equal(t, http.StatusForbidden, w.Code)
+func TestAddProduct(t *testing.T) {
+	prod, err := New(&stubRepoSuccess{}, tokens).AddProduct(context.Background(), employee, "p1", model.Product{Type: "электроника"})
+	assert.NoError(t, err)
+	assert.Equal(t, "r1", prod.ReceptionID)
+
+	_, err = New(&stubRepoSuccess{noOpenReception: true}, tokens).AddProduct(context.Background(), employee, "p1", model.Product{Type: "электроника"})
+	assert.ErrorIs(t, err, ErrNoOpenReception)
+
+	_, err = New(&stubRepoSuccess{}, tokens).AddProduct(context.Background(), moderator, "p1", model.Product{Type: "электроника"})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
 }
 
-func TestGetPvz(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	params := api.GetPvzParams{}
-	c, w := newContext("GET", "/pvz", "")
-	svc.GetPvz(c, params)
-	assert.Equal(t, http.StatusOK, w.Code)
+func TestDeleteLastProduct(t *testing.T) {
+	assert.NoError(t, New(&stubRepoSuccess{}, tokens).DeleteLastProduct(context.Background(), employee, "p1"))
+
+	err := New(&stubRepoSuccess{noOpenReception: true}, tokens).DeleteLastProduct(context.Background(), employee, "p1")
+	assert.ErrorIs(t, err, ErrNoOpenReception)
 }
 
-func TestPostReceptions(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	id := uuid.New().String()
-	c, w := newContext("POST", "/receptions", `{"pvzId":"`+id+`"}`)
-	c.Set("role", "employee")
-	svc.PostReceptions(c)
-	assert.Equal(t, http.StatusCreated, w.Code)
+func TestCloseReception(t *testing.T) {
+	rec, err := New(&stubRepoSuccess{}, tokens).CloseReception(context.Background(), employee, "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, "close", rec.Status)
+
+	_, err = New(&stubRepoSuccess{noOpenReception: true}, tokens).CloseReception(context.Background(), employee, "p1")
+	assert.ErrorIs(t, err, ErrNoOpenReception)
 }
 
-func TestPostReceptions_DBError(t *testing.T) {
-	svc := New(&stubRepoError{}, "secret")
-	id := uuid.New().String()
-	c, w := newContext("POST", "/receptions", `{"pvzId":"`+id+`"}`)
-	c.Set("role", "employee")
-	svc.PostReceptions(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+type missingPVZRepo struct {
+	stubRepoSuccess
 }
 
-func TestPostProducts(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	id := uuid.New().String()
-	c, w := newContext("POST", "/products", `{"pvzId":"`+id+`","type":"электроника"}`)
-	c.Set("role", "employee")
-	svc.PostProducts(c)
-	assert.Equal(t, http.StatusCreated, w.Code)
+func (m *missingPVZRepo) LockPVZ(_ context.Context, _ string) (model.PVZ, error) {
+	return model.PVZ{}, e.NotFound("lock pvz: not found")
 }
 
-func TestPostProducts_DBError(t *testing.T) {
-	svc := New(&stubRepoError{}, "secret")
-	id := uuid.New().String()
-	c, w := newContext("POST", "/products", `{"pvzId":"`+id+`","type":"электроника"}`)
-	c.Set("role", "employee")
-	svc.PostProducts(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+func (m *missingPVZRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(m)
 }
 
-func TestPostPvzPvzIdDeleteLastProduct(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	id := uuid.New()
-	c, w := newContext("POST", "/pvz/"+string(id.String())+"/delete_last_product", "")
-	c.Set("role", "employee")
-	svc.PostPvzPvzIdDeleteLastProduct(c, id)
-	assert.Equal(t, http.StatusOK, w.Code)
-}
-
-func TestPostPvzPvzIdCloseLastReception(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	id := uuid.New()
-	c, w := newContext("POST", "/pvz/"+string(id.String())+"/close_last_reception", "")
-	c.Set("role", "employee")
-	svc.PostPvzPvzIdCloseLastReception(c, id)
-	assert.Equal(t, http.StatusOK, w.Code)
-}
-
-func TestInvalidJSON(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/dummyLogin", `{bad}`)
-	svc.PostDummyLogin(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestForbiddenRoles(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/pvz", `{"city":"Москва"}`)
-	c.Set("role", "employee")
-	svc.PostPvz(c)
-	assert.Equal(t, http.StatusForbidden, w.Code)
-}
-
-func TestPostPvz_InvalidJSON(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/pvz", `{bad json}`)
-	c.Set("role", "moderator")
-	svc.PostPvz(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestPostPvz_DBError(t *testing.T) {
-	svc := New(&stubRepoError{}, "secret")
-	c, w := newContext("POST", "/pvz", `{"city":"Казань"}`)
-	c.Set("role", "moderator")
-	svc.PostPvz(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestPostReceptions_InvalidJSON(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/receptions", `{oops}`)
-	c.Set("role", "employee")
-	svc.PostReceptions(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestPostReceptions_Forbidden(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	id := uuid.New().String()
-	c, w := newContext("POST", "/receptions", `{"pvzId":"`+id+`"}`)
-	c.Set("role", "moderator")
-	svc.PostReceptions(c)
-	assert.Equal(t, http.StatusForbidden, w.Code)
-}
-
-func TestPostProducts_InvalidJSON(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	c, w := newContext("POST", "/products", `{nope}`)
-	c.Set("role", "employee")
-	svc.PostProducts(c)
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-}
-
-func TestPostProducts_Forbidden(t *testing.T) {
-	svc := New(&stubRepoSuccess{}, "secret")
-	id := uuid.New().String()
-	c, w := newContext("POST", "/products", `{"pvzId":"`+id+`","type":"электроника"}`)
-	c.Set("role", "moderator")
-	svc.PostProducts(c)
-	assert.Equal(t, http.StatusForbidden, w.Code)
+func TestOpenReception_PVZNotFound(t *testing.T) {
+	_, err := New(&missingPVZRepo{}, tokens).OpenReception(context.Background(), employee, "p1", nil)
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+	assert.Equal(t, "pvz not found", e.Message(err))
 }
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+type sessionRepo struct {
+	repo.Repository
+	users           map[string]model.User
+	refresh         map[string]*model.RefreshToken
+	revokedAccess   map[string]bool
+	revokedUsers    map[string]bool
+	revokedAt       map[string]time.Time
+	revokedFamilies map[string]bool
+	invites         map[string]*model.Invite
+	lastFilter      model.UserFilter
+	failures        map[string]int
+	lockedUntil     map[string]time.Time
+}
+
+func newSessionRepo() *sessionRepo {
+	hash, _ := auth.HashPassword("p")
+	return &sessionRepo{
+		users:           map[string]model.User{"u1": {ID: "u1", Email: "a@b", PasswordHash: hash, Role: RoleEmployee}},
+		refresh:         map[string]*model.RefreshToken{},
+		revokedAccess:   map[string]bool{},
+		revokedUsers:    map[string]bool{},
+		revokedAt:       map[string]time.Time{},
+		revokedFamilies: map[string]bool{},
+		invites:         map[string]*model.Invite{},
+		failures:        map[string]int{},
+		lockedUntil:     map[string]time.Time{},
+	}
+}
+
+func (r *sessionRepo) WithTx(_ context.Context, fn func(repo.Repository) error) error {
+	return fn(r)
+}
+func (r *sessionRepo) GetUserByEmail(_ context.Context, email string) (model.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return model.User{}, e.NotFound("get user: not found")
+}
+func (r *sessionRepo) GetUserByID(_ context.Context, id string) (model.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return model.User{}, e.NotFound("get user: not found")
+	}
+	return u, nil
+}
+func (r *sessionRepo) CreateRefreshToken(_ context.Context, t model.RefreshToken) error {
+	r.refresh[t.TokenHash] = &t
+	return nil
+}
+func (r *sessionRepo) GetRefreshToken(_ context.Context, hash string) (model.RefreshToken, error) {
+	t, ok := r.refresh[hash]
+	if !ok {
+		return model.RefreshToken{}, e.NotFound("get refresh token: not found")
+	}
+	return *t, nil
+}
+func (r *sessionRepo) RevokeRefreshToken(_ context.Context, id, replacedBy string) error {
+	now := time.Now()
+	for _, t := range r.refresh {
+		if t.ID == id {
+			t.RevokedAt, t.ReplacedBy = &now, &replacedBy
+		}
+	}
+	return nil
+}
+func (r *sessionRepo) RevokeRefreshTokenFamily(_ context.Context, familyID string) error {
+	r.revokedFamilies[familyID] = true
+	now := time.Now()
+	for _, t := range r.refresh {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+func (r *sessionRepo) RevokeUserTokens(_ context.Context, userID string) error {
+	r.revokedUsers[userID] = true
+	r.revokedAt[userID] = time.Now()
+	return nil
+}
+func (r *sessionRepo) RecordLoginFailure(_ context.Context, key string, _ time.Time) (int, error) {
+	r.failures[key]++
+	return r.failures[key], nil
+}
+func (r *sessionRepo) LockLogin(_ context.Context, key string, until time.Time) error {
+	r.lockedUntil[key] = until
+	return nil
+}
+func (r *sessionRepo) LoginLockedUntil(_ context.Context, keys []string) (time.Time, error) {
+	var until time.Time
+	for _, k := range keys {
+		if r.lockedUntil[k].After(until) {
+			until = r.lockedUntil[k]
+		}
+	}
+	return until, nil
+}
+func (r *sessionRepo) ClearLoginFailures(_ context.Context, key string) error {
+	delete(r.failures, key)
+	return nil
+}
+func (r *sessionRepo) RevokeAccessToken(_ context.Context, jti string, _ time.Time) error {
+	r.revokedAccess[jti] = true
+	return nil
+}
+
+// IsAccessTokenRevoked compares to the microsecond like the repository does.
+func (r *sessionRepo) IsAccessTokenRevoked(_ context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	at, ok := r.revokedAt[userID]
+	return r.revokedAccess[jti] || ok && at.Truncate(time.Microsecond).After(issuedAt), nil
+}
+
+func TestRefresh_Rotation(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+	pair, err := svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	assert.NoError(t, err)
+
+	next, err := svc.Refresh(context.Background(), pair.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, pair.RefreshToken, next.RefreshToken)
+	assert.NotEmpty(t, next.Token)
+
+	old := r.refresh[auth.HashToken(pair.RefreshToken)]
+	fresh := r.refresh[auth.HashToken(next.RefreshToken)]
+	assert.NotNil(t, old.RevokedAt)
+	assert.Equal(t, fresh.ID, *old.ReplacedBy)
+	assert.Equal(t, old.FamilyID, fresh.FamilyID)
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+	pair, _ := svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	next, _ := svc.Refresh(context.Background(), pair.RefreshToken)
+
+	_, err := svc.Refresh(context.Background(), pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Equal(t, e.KindUnauthorized, e.KindOf(err))
+
+	_, err = svc.Refresh(context.Background(), next.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+}
+
+func TestRefresh_Invalid(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+	_, err := svc.Refresh(context.Background(), "nope")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	r.refresh[auth.HashToken("old")] = &model.RefreshToken{ID: "t1", UserID: "u1", FamilyID: "f1", ExpiresAt: time.Now().Add(-time.Minute)}
+	_, err = svc.Refresh(context.Background(), "old")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestLogout(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+	pair, _ := svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	claims, _ := tokens.ParseToken(pair.Token)
+	actor := auth.ActorFromClaims(claims)
+
+	assert.NoError(t, svc.Logout(context.Background(), actor, pair.RefreshToken))
+	assert.True(t, r.revokedAccess[claims.ID])
+	_, err := svc.Refresh(context.Background(), pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	other := model.Actor{UserID: "u9", Role: RoleEmployee}
+	pair, _ = svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	err = svc.Logout(context.Background(), other, pair.RefreshToken)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+}
+
+func TestLogoutAll(t *testing.T) {
+	r := newSessionRepo()
+	assert.NoError(t, New(r, tokens).LogoutAll(context.Background(), employee))
+	assert.True(t, r.revokedUsers[employee.UserID])
+}
+
+func TestLogoutAll_ThenLogin(t *testing.T) {
+	ctx := context.Background()
+	r := newSessionRepo()
+	tm := auth.NewTokenManager(auth.NewHMACKeySet("secret"), time.Minute, time.Hour, r)
+	svc := New(r, tm)
+	old, err := svc.Login(ctx, "a@b", "p", "10.0.0.1")
+	assert.NoError(t, err)
+	claims, _ := tm.ParseToken(old.Token)
+
+	assert.NoError(t, svc.LogoutAll(ctx, auth.ActorFromClaims(claims)))
+	_, err = tm.Verify(ctx, old.Token)
+	assert.Error(t, err)
+	pair, err := svc.Login(ctx, "a@b", "p", "10.0.0.1")
+	assert.NoError(t, err)
+	_, err = tm.Verify(ctx, pair.Token)
+	assert.NoError(t, err)
+
+	// A token issued a microsecond before the logout, within the same
+	// second, is revoked as well.
+	r.revokedAt["u1"] = claims.IssuedAtTime().Add(time.Microsecond)
+	_, err = tm.Verify(ctx, old.Token)
+	assert.EqualError(t, err, "token revoked")
+}
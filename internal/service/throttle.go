@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"pvz-backend-service/internal/metrics"
+	"pvz-backend-service/lib/e"
+)
+
+// LoginThrottle limits password guessing. Failures are counted per account
+// and per client IP; once a counter reaches its limit the key is locked out
+// for BaseLockout, doubling with every further failure up to MaxLockout.
+// Counters reset after Window without failures.
+type LoginThrottle struct {
+	MaxFailures   int
+	MaxIPFailures int
+	Window        time.Duration
+	BaseLockout   time.Duration
+	MaxLockout    time.Duration
+}
+
+var DefaultLoginThrottle = LoginThrottle{
+	MaxFailures:   5,
+	MaxIPFailures: 20,
+	Window:        15 * time.Minute,
+	BaseLockout:   time.Minute,
+	MaxLockout:    time.Hour,
+}
+
+func WithLoginThrottle(t LoginThrottle) Option {
+	return func(s *service) { s.throttle = t }
+}
+
+var ErrLoginLocked = e.TooManyRequests("too many failed login attempts, try again later")
+
+// LoginLockedError wraps ErrLoginLocked with the remaining lockout time.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (l *LoginLockedError) Error() string { return ErrLoginLocked.Error() }
+func (l *LoginLockedError) Unwrap() error { return ErrLoginLocked }
+
+type loginKey struct {
+	key   string
+	limit int
+}
+
+func (s *service) loginKeys(email, ip string) []loginKey {
+	keys := []loginKey{{"email:" + strings.ToLower(strings.TrimSpace(email)), s.throttle.MaxFailures}}
+	if ip != "" {
+		keys = append(keys, loginKey{"ip:" + ip, s.throttle.MaxIPFailures})
+	}
+	return keys
+}
+
+func (s *service) checkLoginLock(ctx context.Context, keys []loginKey) error {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.key
+	}
+	until, err := s.repo.LoginLockedUntil(ctx, names)
+	if err != nil {
+		return e.Wrap("login failed", err)
+	}
+	if wait := time.Until(until); wait > 0 {
+		metrics.LoginFailures.WithLabelValues("locked").Inc()
+		return &LoginLockedError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// loginFailed records the failure against every key and locks the keys that
+// reached their limit. It always reports invalid credentials to the caller.
+func (s *service) loginFailed(ctx context.Context, keys []loginKey) error {
+	metrics.LoginFailures.WithLabelValues("invalid_credentials").Inc()
+	now := time.Now()
+	for _, k := range keys {
+		n, err := s.repo.RecordLoginFailure(ctx, k.key, now.Add(-s.throttle.Window))
+		if err != nil {
+			return e.Wrap("login failed", err)
+		}
+		if k.limit <= 0 || n < k.limit {
+			continue
+		}
+		if err := s.repo.LockLogin(ctx, k.key, now.Add(s.throttle.lockout(n-k.limit))); err != nil {
+			return e.Wrap("login failed", err)
+		}
+	}
+	return ErrInvalidCredentials
+}
+
+func (t LoginThrottle) lockout(excess int) time.Duration {
+	d := t.BaseLockout
+	for i := 0; i < excess && d < t.MaxLockout; i++ {
+		d *= 2
+	}
+	return min(d, t.MaxLockout)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"pvz-backend-service/lib/e"
+)
+
+func TestLoginThrottle_LocksAccount(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens, WithLoginThrottle(LoginThrottle{MaxFailures: 3, MaxIPFailures: 100, Window: time.Hour, BaseLockout: time.Minute, MaxLockout: time.Hour}))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.Login(ctx, "A@b", "wrong", "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	assert.WithinDuration(t, time.Now().Add(time.Minute), r.lockedUntil["email:a@b"], time.Second)
+
+	_, err := svc.Login(ctx, "a@b", "p", "10.0.0.2")
+	var locked *LoginLockedError
+	assert.True(t, errors.As(err, &locked))
+	assert.ErrorIs(t, err, ErrLoginLocked)
+	assert.Equal(t, e.KindTooManyRequests, e.KindOf(err))
+	assert.InDelta(t, time.Minute.Seconds(), locked.RetryAfter.Seconds(), 1)
+
+	r.lockedUntil["email:a@b"] = time.Now().Add(-time.Second)
+	_, err = svc.Login(ctx, "a@b", "wrong", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), r.lockedUntil["email:a@b"], time.Second, "lockout doubles")
+
+	r.lockedUntil["email:a@b"] = time.Time{}
+	_, err = svc.Login(ctx, "a@b", "p", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Zero(t, r.failures["email:a@b"], "successful login resets the account counter")
+	assert.Equal(t, 4, r.failures["ip:10.0.0.1"], "but not the IP counter")
+}
+
+func TestLoginThrottle_UnknownUserCountsAndLocksIP(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens, WithLoginThrottle(LoginThrottle{MaxFailures: 100, MaxIPFailures: 2, Window: time.Hour, BaseLockout: time.Minute, MaxLockout: time.Hour}))
+	ctx := context.Background()
+
+	_, err := svc.Login(ctx, "nobody@b", "x", "10.0.0.9")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = svc.Login(ctx, "other@b", "x", "10.0.0.9")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Equal(t, 1, r.failures["email:nobody@b"])
+
+	_, err = svc.Login(ctx, "a@b", "p", "10.0.0.9")
+	assert.Equal(t, e.KindTooManyRequests, e.KindOf(err))
+	_, err = svc.Login(ctx, "a@b", "p", "10.0.0.10")
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottle_Lockout(t *testing.T) {
+	th := LoginThrottle{BaseLockout: time.Minute, MaxLockout: 10 * time.Minute}
+	assert.Equal(t, time.Minute, th.lockout(0))
+	assert.Equal(t, 4*time.Minute, th.lockout(2))
+	assert.Equal(t, 10*time.Minute, th.lockout(4))
+	assert.Equal(t, 10*time.Minute, th.lockout(1000))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/internal/repo"
+	"pvz-backend-service/lib/e"
+)
+
+func (s *service) ListUsers(ctx context.Context, actor model.Actor, query, role string, page, limit int) ([]model.User, error) {
+	if err := s.require(actor, auth.PermUserRead); err != nil {
+		return nil, err
+	}
+	if role != "" {
+		if err := s.validRole(role); err != nil {
+			return nil, err
+		}
+	}
+	limit, offset, err := paginate(page, limit)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.repo.ListUsers(ctx, model.UserFilter{Query: query, Role: role, Limit: limit, Offset: offset})
+	return users, e.WrapIfErr("could not list users", err)
+}
+
+func (s *service) ChangeUserRole(ctx context.Context, actor model.Actor, userID, role string) (model.User, error) {
+	if err := s.require(actor, auth.PermUserManage); err != nil {
+		return model.User{}, err
+	}
+	if err := s.validRole(role); err != nil {
+		return model.User{}, err
+	}
+	if err := s.canGrant(actor, role); err != nil {
+		return model.User{}, err
+	}
+	if userID == actor.UserID {
+		return model.User{}, e.Validation("cannot change your own role")
+	}
+	return s.updateUser(ctx, actor, userID, func(r repo.Repository) error {
+		return r.UpdateUserRole(ctx, userID, role)
+	})
+}
+
+func (s *service) SetUserDisabled(ctx context.Context, actor model.Actor, userID string, disabled bool) (model.User, error) {
+	if err := s.require(actor, auth.PermUserManage); err != nil {
+		return model.User{}, err
+	}
+	if disabled && userID == actor.UserID {
+		return model.User{}, e.Validation("cannot disable your own account")
+	}
+	return s.updateUser(ctx, actor, userID, func(r repo.Repository) error {
+		return r.SetUserDisabled(ctx, userID, disabled)
+	})
+}
+
+func (s *service) ResetUserPassword(ctx context.Context, actor model.Actor, userID, password string) error {
+	if err := s.require(actor, auth.PermUserManage); err != nil {
+		return err
+	}
+	hash, err := s.hashPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = s.updateUser(ctx, actor, userID, func(r repo.Repository) error {
+		return r.UpdateUserPassword(ctx, userID, hash)
+	})
+	return err
+}
+
+// canGrant keeps actors from handing out roles more privileged than their own.
+func (s *service) canGrant(actor model.Actor, role string) error {
+	if !s.perms.CanGrant(actor.Role, role) {
+		return e.Forbidden("access forbidden: cannot manage %s accounts", role)
+	}
+	return nil
+}
+
+// updateUser applies a moderator change and signs the user out everywhere, so
+// the change takes effect immediately rather than when old tokens expire.
+func (s *service) updateUser(ctx context.Context, actor model.Actor, userID string, update func(repo.Repository) error) (model.User, error) {
+	var user model.User
+	err := s.repo.WithTx(ctx, func(r repo.Repository) error {
+		target, err := r.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.canGrant(actor, target.Role); err != nil {
+			return err
+		}
+		if err := update(r); err != nil {
+			return err
+		}
+		if err := r.RevokeUserTokens(ctx, userID); err != nil {
+			return err
+		}
+		user, err = r.GetUserByID(ctx, userID)
+		return err
+	})
+	if e.IsKind(err, e.KindNotFound) || e.IsKind(err, e.KindValidation) {
+		return model.User{}, e.NotFound("user not found")
+	}
+	return user, e.WrapIfErr("failed to update user", err)
+}
+
+// InviteUser creates a one-time activation token for email. The token is
+// returned only once; the moderator delivers it to the invitee.
+func (s *service) InviteUser(ctx context.Context, actor model.Actor, email, role string) (model.Invite, string, error) {
+	if err := s.require(actor, auth.PermUserInvite); err != nil {
+		return model.Invite{}, "", err
+	}
+	if role == "" {
+		role = RoleEmployee
+	}
+	if err := s.validRole(role); err != nil {
+		return model.Invite{}, "", err
+	}
+	if err := s.canGrant(actor, role); err != nil {
+		return model.Invite{}, "", err
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return model.Invite{}, "", err
+	}
+	if _, err := s.repo.GetUserByEmail(ctx, email); err == nil {
+		return model.Invite{}, "", e.Conflict("user with this email already exists")
+	} else if !e.IsKind(err, e.KindNotFound) {
+		return model.Invite{}, "", e.Wrap("failed to create invite", err)
+	}
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return model.Invite{}, "", err
+	}
+	inv := model.Invite{
+		ID:        uuid.NewString(),
+		Email:     email,
+		Role:      role,
+		TokenHash: hash,
+		InvitedBy: actor.UserID,
+		ExpiresAt: time.Now().Add(s.inviteTTL),
+	}
+	if err := s.repo.CreateInvite(ctx, inv); err != nil {
+		return model.Invite{}, "", e.Wrap("failed to create invite", err)
+	}
+	return inv, token, nil
+}
+
+func (s *service) AcceptInvite(ctx context.Context, token, password string) (model.TokenPair, error) {
+	hash, err := s.hashPassword(password)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	var pair model.TokenPair
+	err = s.repo.WithTx(ctx, func(r repo.Repository) error {
+		inv, err := r.GetInvite(ctx, auth.HashToken(token))
+		if e.IsKind(err, e.KindNotFound) {
+			return ErrInvalidInvite
+		}
+		if err != nil {
+			return err
+		}
+		if inv.AcceptedAt != nil || time.Now().After(inv.ExpiresAt) {
+			return ErrInvalidInvite
+		}
+		user, err := r.CreateUser(ctx, inv.Email, hash, inv.Role)
+		if e.IsKind(err, e.KindConflict) {
+			return e.Conflict("user with this email already exists")
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.AcceptInvite(ctx, inv.ID); err != nil {
+			return err
+		}
+		pair, _, err = s.issueSession(ctx, r, user, "")
+		return err
+	})
+	return pair, e.WrapIfErr("failed to accept invite", err)
+}
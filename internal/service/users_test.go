@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pvz-backend-service/internal/auth"
+	"pvz-backend-service/internal/model"
+	"pvz-backend-service/lib/e"
+)
+
+func (r *sessionRepo) CreateUser(_ context.Context, email, hash, role string) (model.User, error) {
+	if _, err := r.GetUserByEmail(context.Background(), email); err == nil {
+		return model.User{}, e.Conflict("create user: already exists")
+	}
+	u := model.User{ID: "u" + email, Email: email, PasswordHash: hash, Role: role}
+	r.users[u.ID] = u
+	return u, nil
+}
+func (r *sessionRepo) ListUsers(_ context.Context, f model.UserFilter) ([]model.User, error) {
+	r.lastFilter = f
+	return []model.User{r.users["u1"]}, nil
+}
+func (r *sessionRepo) update(id string, fn func(*model.User)) error {
+	u, ok := r.users[id]
+	if !ok {
+		return e.NotFound("user not found")
+	}
+	fn(&u)
+	r.users[id] = u
+	return nil
+}
+func (r *sessionRepo) UpdateUserRole(_ context.Context, id, role string) error {
+	return r.update(id, func(u *model.User) { u.Role = role })
+}
+func (r *sessionRepo) SetUserDisabled(_ context.Context, id string, disabled bool) error {
+	return r.update(id, func(u *model.User) {
+		u.DisabledAt = nil
+		if disabled {
+			now := time.Now()
+			u.DisabledAt = &now
+		}
+	})
+}
+func (r *sessionRepo) UpdateUserPassword(_ context.Context, id, hash string) error {
+	return r.update(id, func(u *model.User) { u.PasswordHash = hash })
+}
+func (r *sessionRepo) CreateInvite(_ context.Context, inv model.Invite) error {
+	r.invites[inv.TokenHash] = &inv
+	return nil
+}
+func (r *sessionRepo) GetInvite(_ context.Context, hash string) (model.Invite, error) {
+	inv, ok := r.invites[hash]
+	if !ok {
+		return model.Invite{}, e.NotFound("get invite: not found")
+	}
+	return *inv, nil
+}
+func (r *sessionRepo) AcceptInvite(_ context.Context, id string) error {
+	for _, inv := range r.invites {
+		if inv.ID == id {
+			now := time.Now()
+			inv.AcceptedAt = &now
+		}
+	}
+	return nil
+}
+
+func TestUserManagement_RequiresModerator(t *testing.T) {
+	svc := New(newSessionRepo(), tokens)
+	ctx := context.Background()
+
+	_, err := svc.ListUsers(ctx, employee, "", "", 0, 0)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.ChangeUserRole(ctx, employee, "u1", RoleModerator)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.SetUserDisabled(ctx, employee, "u1", true)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	assert.Equal(t, e.KindForbidden, e.KindOf(svc.ResetUserPassword(ctx, employee, "u1", "x")))
+	_, _, err = svc.InviteUser(ctx, employee, "new@pvz.ru", "")
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+}
+
+func TestUserManagement_Permissions(t *testing.T) {
+	r := newSessionRepo()
+	r.users["u3"] = model.User{ID: "u3", Email: "root@pvz.ru", Role: RoleAdmin}
+	svc := New(r, tokens)
+	ctx := context.Background()
+	auditor := model.Actor{UserID: "u4", Role: RoleAuditor}
+	admin := model.Actor{UserID: "u5", Role: RoleAdmin}
+
+	_, err := svc.ListUsers(ctx, auditor, "", "", 0, 0)
+	assert.NoError(t, err)
+	_, err = svc.SetUserDisabled(ctx, auditor, "u1", true)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.CreatePVZ(ctx, auditor, model.PVZ{City: "Москва"})
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	_, err = svc.ChangeUserRole(ctx, moderator, "u1", RoleAdmin)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, _, err = svc.InviteUser(ctx, moderator, "new@pvz.ru", RoleAdmin)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.SetUserDisabled(ctx, moderator, "u3", true)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	assert.False(t, r.revokedUsers["u3"])
+
+	u, err := svc.ChangeUserRole(ctx, admin, "u1", RoleAuditor)
+	require.NoError(t, err)
+	assert.Equal(t, RoleAuditor, u.Role)
+	_, err = svc.SetUserDisabled(ctx, admin, "u3", true)
+	assert.NoError(t, err)
+}
+
+func TestListUsers_Pagination(t *testing.T) {
+	r := newSessionRepo()
+	users, err := New(r, tokens).ListUsers(context.Background(), moderator, "a@", RoleEmployee, 3, 20)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, model.UserFilter{Query: "a@", Role: RoleEmployee, Limit: 20, Offset: 40}, r.lastFilter)
+
+	_, err = New(r, tokens).ListUsers(context.Background(), moderator, "", "root", 0, 0)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+}
+
+func TestChangeUserRole(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+
+	u, err := svc.ChangeUserRole(context.Background(), moderator, "u1", RoleModerator)
+	assert.NoError(t, err)
+	assert.Equal(t, RoleModerator, u.Role)
+	assert.True(t, r.revokedUsers["u1"])
+
+	_, err = svc.ChangeUserRole(context.Background(), moderator, "u2", RoleEmployee)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = svc.ChangeUserRole(context.Background(), moderator, "missing", RoleEmployee)
+	assert.Equal(t, e.KindNotFound, e.KindOf(err))
+}
+
+func TestDisableUser_BlocksLoginAndRefresh(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+	pair, err := svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	require.NoError(t, err)
+
+	_, err = svc.SetUserDisabled(context.Background(), moderator, "u1", true)
+	assert.NoError(t, err)
+	assert.True(t, r.revokedUsers["u1"])
+
+	_, err = svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrAccountDisabled)
+	_, err = svc.Refresh(context.Background(), pair.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	_, err = svc.SetUserDisabled(context.Background(), moderator, "u1", false)
+	assert.NoError(t, err)
+	_, err = svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	assert.NoError(t, err)
+
+	_, err = svc.SetUserDisabled(context.Background(), moderator, "u2", true)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+}
+
+func TestResetUserPassword(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens)
+	assert.NoError(t, svc.ResetUserPassword(context.Background(), moderator, "u1", "new-pass"))
+	assert.True(t, r.revokedUsers["u1"])
+
+	_, err := svc.Login(context.Background(), "a@b", "p", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = svc.Login(context.Background(), "a@b", "new-pass", "10.0.0.1")
+	assert.NoError(t, err)
+}
+
+func TestInviteFlow(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens, WithInviteTTL(time.Hour))
+	ctx := context.Background()
+
+	inv, token, err := svc.InviteUser(ctx, moderator, "new@pvz.ru", "")
+	require.NoError(t, err)
+	assert.Equal(t, RoleEmployee, inv.Role)
+	assert.Equal(t, "u2", inv.InvitedBy)
+	assert.Equal(t, auth.HashToken(token), inv.TokenHash)
+
+	pair, err := svc.AcceptInvite(ctx, token, "secret-pass")
+	require.NoError(t, err)
+	assert.NotEmpty(t, pair.RefreshToken)
+	_, err = svc.Login(ctx, "new@pvz.ru", "secret-pass", "10.0.0.1")
+	assert.NoError(t, err)
+
+	_, err = svc.AcceptInvite(ctx, token, "Secret-pass2")
+	assert.ErrorIs(t, err, ErrInvalidInvite)
+	_, err = svc.AcceptInvite(ctx, "bogus", "Secret-pass2")
+	assert.ErrorIs(t, err, ErrInvalidInvite)
+
+	_, _, err = svc.InviteUser(ctx, moderator, "a@b", "")
+	assert.Equal(t, e.KindConflict, e.KindOf(err))
+}
+
+func TestInvite_Expired(t *testing.T) {
+	r := newSessionRepo()
+	svc := New(r, tokens, WithInviteTTL(-time.Minute))
+	_, token, err := svc.InviteUser(context.Background(), moderator, "new@pvz.ru", RoleEmployee)
+	require.NoError(t, err)
+	_, err = svc.AcceptInvite(context.Background(), token, "Secret-pass1")
+	assert.ErrorIs(t, err, ErrInvalidInvite)
+}
+
+func TestRegister_Modes(t *testing.T) {
+	ctx := context.Background()
+	_, err := New(newSessionRepo(), tokens, WithRegistrationMode(RegistrationInvite)).Register(ctx, "x@y", "Secret-pass1", RoleEmployee)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+
+	svc := New(newSessionRepo(), tokens, WithRegistrationMode(RegistrationEmployee))
+	_, err = svc.Register(ctx, "x@y", "Secret-pass1", RoleModerator)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+	_, err = svc.Register(ctx, "x@y", "Secret-pass1", RoleEmployee)
+	assert.NoError(t, err)
+
+	_, err = New(newSessionRepo(), tokens).Register(ctx, "x@y", "Secret-pass1", "root")
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	_, err = New(newSessionRepo(), tokens).Register(ctx, "x@y", "Secret-pass1", RoleAdmin)
+	assert.Equal(t, e.KindForbidden, e.KindOf(err))
+}
+
+func TestRegister_PasswordPolicyAndEmailCase(t *testing.T) {
+	ctx := context.Background()
+	svc := New(newSessionRepo(), tokens, WithPasswordPolicy(auth.PasswordPolicy{MinLength: 10, MinClasses: 3}))
+
+	_, err := svc.Register(ctx, "new@pvz.ru", "short", RoleEmployee)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+	assert.ErrorContains(t, err, "at least 10 characters")
+	_, err = svc.Register(ctx, "not-an-email", "Secret-pass1", RoleEmployee)
+	assert.Equal(t, e.KindValidation, e.KindOf(err))
+
+	_, err = svc.Register(ctx, " New@PVZ.ru", "Secret-pass1", RoleEmployee)
+	require.NoError(t, err)
+	_, err = svc.Register(ctx, "new@pvz.ru", "Secret-pass1", RoleEmployee)
+	assert.Equal(t, e.KindConflict, e.KindOf(err))
+	_, err = svc.Login(ctx, "NEW@pvz.ru", "Secret-pass1", "10.0.0.1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, e.KindValidation, e.KindOf(svc.ResetUserPassword(ctx, moderator, "u1", "weak")))
+}
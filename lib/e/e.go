@@ -1,8 +1,125 @@
 package e
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindForbidden
+	KindUnauthorized
+	KindTooManyRequests
+	// KindPrecondition is a conflict with the current state of a record, as
+	// opposed to KindConflict for a record that already exists.
+	KindPrecondition
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindForbidden:
+		return "forbidden"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindTooManyRequests:
+		return "too many requests"
+	case KindPrecondition:
+		return "precondition failed"
+	}
+	return "internal"
+}
+
+// Error is a domain error whose Msg is safe to show to clients; Err keeps
+// the underlying cause for logs.
+type Error struct {
+	Kind Kind
+	Msg  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Msg
+	}
+	return e.Msg + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, msg string, err error) error {
+	return &Error{Kind: kind, Msg: msg, Err: err}
+}
+
+func NotFound(format string, args ...any) error {
+	return New(KindNotFound, fmt.Sprintf(format, args...), nil)
+}
+
+func Conflict(format string, args ...any) error {
+	return New(KindConflict, fmt.Sprintf(format, args...), nil)
+}
+
+func Validation(format string, args ...any) error {
+	return New(KindValidation, fmt.Sprintf(format, args...), nil)
+}
+
+func Forbidden(format string, args ...any) error {
+	return New(KindForbidden, fmt.Sprintf(format, args...), nil)
+}
+
+func Unauthorized(format string, args ...any) error {
+	return New(KindUnauthorized, fmt.Sprintf(format, args...), nil)
+}
+
+func TooManyRequests(format string, args ...any) error {
+	return New(KindTooManyRequests, fmt.Sprintf(format, args...), nil)
+}
+
+func Precondition(format string, args ...any) error {
+	return New(KindPrecondition, fmt.Sprintf(format, args...), nil)
+}
+
+func Internal(msg string, err error) error {
+	return New(KindInternal, msg, err)
+}
+
+func KindOf(err error) Kind {
+	var de *Error
+	if errors.As(err, &de) {
+		return de.Kind
+	}
+	return KindInternal
+}
+
+func IsKind(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
+
+// Message returns the client-facing message of err, hiding internal details.
+func Message(err error) string {
+	var de *Error
+	if errors.As(err, &de) && de.Kind != KindInternal {
+		return de.Msg
+	}
+	return "internal error"
+}
 
 func Wrap(msg string, err error) error {
+	if err == nil {
+		return errors.New(msg)
+	}
 	return fmt.Errorf("%s: %w", msg, err)
 }
 
@@ -0,0 +1,37 @@
+package e
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap_NilCause(t *testing.T) {
+	assert.EqualError(t, Wrap("invalid city", nil), "invalid city")
+	assert.Nil(t, WrapIfErr("x", nil))
+}
+
+func TestKindOf(t *testing.T) {
+	err := Wrap("open reception", Conflict("open reception exists"))
+	assert.Equal(t, KindConflict, KindOf(err))
+	assert.True(t, IsKind(err, KindConflict))
+	assert.Equal(t, "open reception exists", Message(err))
+
+	assert.Equal(t, KindInternal, KindOf(errors.New("boom")))
+	assert.False(t, IsKind(nil, KindInternal))
+}
+
+func TestMessage_HidesInternal(t *testing.T) {
+	err := Internal("db failed", errors.New("connection refused"))
+	assert.Equal(t, "internal error", Message(err))
+	assert.Equal(t, "internal error", Message(errors.New("raw")))
+	assert.EqualError(t, err, "db failed: connection refused")
+}
+
+func TestErrorsIs_Sentinel(t *testing.T) {
+	sentinel := NotFound("no open reception found")
+	assert.ErrorIs(t, Wrap("close", sentinel), sentinel)
+	assert.Equal(t, "not found", KindOf(sentinel).String())
+	assert.Equal(t, "too many requests", KindOf(TooManyRequests("slow down")).String())
+}
@@ -0,0 +1,20 @@
+ALTER TABLE users
+    ADD COLUMN tokens_revoked_at TIMESTAMPTZ;
+CREATE TABLE refresh_tokens
+(
+    id          UUID PRIMARY KEY,
+    user_id     UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id   UUID        NOT NULL,
+    token_hash  TEXT UNIQUE NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at  TIMESTAMPTZ,
+    replaced_by UUID
+);
+CREATE INDEX refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE TABLE revoked_tokens
+(
+    jti        UUID PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
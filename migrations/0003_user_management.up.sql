@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN disabled_at TIMESTAMPTZ;
+CREATE TABLE invites
+(
+    id          UUID PRIMARY KEY,
+    email       TEXT        NOT NULL,
+    role        TEXT        NOT NULL,
+    token_hash  TEXT UNIQUE NOT NULL,
+    invited_by  UUID,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    accepted_at TIMESTAMPTZ
+);
+CREATE INDEX invites_email ON invites (email);
@@ -0,0 +1,8 @@
+CREATE TABLE login_failures
+(
+    key             TEXT PRIMARY KEY,
+    failures        INT         NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until    TIMESTAMPTZ
+);
+CREATE INDEX login_failures_last_failure_at ON login_failures (last_failure_at);
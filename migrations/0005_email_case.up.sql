@@ -0,0 +1,9 @@
+-- Emails are stored lowercased. The update fails if two accounts differ only
+-- in email case; such duplicates must be merged by hand before migrating.
+UPDATE users
+SET email = lower(email)
+WHERE email <> lower(email);
+UPDATE invites
+SET email = lower(email)
+WHERE email <> lower(email);
+CREATE UNIQUE INDEX users_email_lower ON users (lower(email));
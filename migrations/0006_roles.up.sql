@@ -0,0 +1,12 @@
+-- Roles outside the known set fall back to the least privileged one, so the
+-- checks below can be added to a live database.
+UPDATE users
+SET role = 'employee'
+WHERE role NOT IN ('employee', 'moderator', 'auditor', 'admin');
+UPDATE invites
+SET role = 'employee'
+WHERE role NOT IN ('employee', 'moderator', 'auditor', 'admin');
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'moderator', 'auditor', 'admin'));
+ALTER TABLE invites
+    ADD CONSTRAINT invites_role_check CHECK (role IN ('employee', 'moderator', 'auditor', 'admin'));
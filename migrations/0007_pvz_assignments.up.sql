@@ -0,0 +1,9 @@
+CREATE TABLE pvz_assignments
+(
+    pvz_id      UUID        NOT NULL REFERENCES pvz (id) ON DELETE CASCADE,
+    user_id     UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    assigned_by UUID,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (pvz_id, user_id)
+);
+CREATE INDEX pvz_assignments_user_id ON pvz_assignments (user_id);
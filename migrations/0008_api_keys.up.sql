@@ -0,0 +1,14 @@
+CREATE TABLE api_keys
+(
+    id           UUID PRIMARY KEY,
+    name         TEXT        NOT NULL,
+    prefix       TEXT UNIQUE NOT NULL,
+    key_hash     TEXT        NOT NULL,
+    scopes       TEXT[]      NOT NULL,
+    created_by   UUID,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    replaced_by  UUID
+);
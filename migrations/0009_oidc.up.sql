@@ -0,0 +1,16 @@
+CREATE TABLE oidc_logins
+(
+    state_hash    TEXT PRIMARY KEY,
+    nonce         TEXT        NOT NULL,
+    code_verifier TEXT        NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL
+);
+CREATE TABLE user_identities
+(
+    issuer     TEXT        NOT NULL,
+    subject    TEXT        NOT NULL,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (issuer, subject)
+);
+CREATE INDEX user_identities_user_id ON user_identities (user_id);
@@ -0,0 +1,13 @@
+CREATE TABLE cities
+(
+    name       TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+INSERT INTO cities (name)
+VALUES ('Москва'),
+       ('Санкт-Петербург'),
+       ('Казань');
+ALTER TABLE pvz
+    DROP CONSTRAINT pvz_city_check;
+ALTER TABLE pvz
+    ADD CONSTRAINT pvz_city_fkey FOREIGN KEY (city) REFERENCES cities (name) ON UPDATE CASCADE;
@@ -0,0 +1,11 @@
+-- Existing PVZs are all in Moscow time; coordinates, hours and capacity stay
+-- unknown until a moderator fills them in.
+ALTER TABLE pvz
+    ADD COLUMN address       TEXT             NOT NULL DEFAULT '',
+    ADD COLUMN latitude      DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude     DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN timezone      TEXT             NOT NULL DEFAULT 'Europe/Moscow',
+    ADD COLUMN working_hours JSONB            NOT NULL DEFAULT '[]',
+    ADD COLUMN capacity      INTEGER CHECK (capacity >= 0),
+    ADD COLUMN status        TEXT             NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'closed')),
+    ADD CONSTRAINT pvz_coordinates CHECK ((latitude IS NULL) = (longitude IS NULL));
@@ -0,0 +1 @@
+CREATE INDEX pvz_location ON pvz (latitude, longitude) WHERE status = 'active' AND latitude IS NOT NULL;
@@ -0,0 +1,16 @@
+-- A reception may be opened against a manifest of what the supplier is
+-- expected to deliver; receptions opened without one get no report.
+ALTER TABLE reception
+    ADD COLUMN manifest JSONB;
+
+CREATE TABLE reception_report
+(
+    reception_id UUID PRIMARY KEY REFERENCES reception (id),
+    missing      JSONB       NOT NULL DEFAULT '[]',
+    surplus      JSONB       NOT NULL DEFAULT '[]',
+    unexpected   JSONB       NOT NULL DEFAULT '[]',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    accepted_by  UUID REFERENCES users (id),
+    accepted_at  TIMESTAMPTZ,
+    comment      TEXT        NOT NULL DEFAULT ''
+);
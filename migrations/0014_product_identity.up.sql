@@ -0,0 +1,15 @@
+-- Products received before barcodes were tracked keep a NULL barcode.
+ALTER TABLE product
+    ADD COLUMN barcode      TEXT,
+    ADD COLUMN sku          TEXT NOT NULL DEFAULT '',
+    ADD COLUMN weight_grams INTEGER CHECK (weight_grams > 0),
+    ADD COLUMN length_mm    INTEGER CHECK (length_mm > 0),
+    ADD COLUMN width_mm     INTEGER CHECK (width_mm > 0),
+    ADD COLUMN height_mm    INTEGER CHECK (height_mm > 0),
+    ADD CONSTRAINT product_dimensions CHECK ((length_mm IS NULL) = (width_mm IS NULL) AND (width_mm IS NULL) = (height_mm IS NULL));
+CREATE UNIQUE INDEX product_reception_barcode ON product (reception_id, barcode);
+CREATE INDEX product_barcode ON product (barcode) WHERE barcode IS NOT NULL;
+
+ALTER TABLE reception_report
+    ADD COLUMN missing_barcodes    JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN unexpected_barcodes JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,19 @@
+CREATE TABLE product_types
+(
+    name              TEXT PRIMARY KEY,
+    fragile           BOOLEAN     NOT NULL DEFAULT false,
+    requires_id_check BOOLEAN     NOT NULL DEFAULT false,
+    max_weight_grams  INTEGER CHECK (max_weight_grams > 0),
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    -- A deprecated type stays for the products already received with it but
+    -- cannot be used for new ones.
+    deprecated_at     TIMESTAMPTZ
+);
+INSERT INTO product_types (name)
+VALUES ('электроника'),
+       ('одежда'),
+       ('обувь');
+ALTER TABLE product
+    DROP CONSTRAINT product_type_check;
+ALTER TABLE product
+    ADD CONSTRAINT product_type_fkey FOREIGN KEY (type) REFERENCES product_types (name);
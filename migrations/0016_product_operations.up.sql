@@ -0,0 +1,23 @@
+-- Every change to the products of a reception is logged, so the last ones can
+-- be undone and redone. product holds the product as it was after an add or
+-- a retype and as it was removed by a delete. undo and redo entries point at
+-- the operation they reverted or reapplied in target_id.
+CREATE TABLE product_operation
+(
+    id            UUID PRIMARY KEY,
+    seq           BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+    reception_id  UUID        NOT NULL REFERENCES reception (id),
+    kind          TEXT        NOT NULL CHECK (kind IN ('add', 'delete', 'retype', 'undo', 'redo')),
+    product       JSONB       NOT NULL,
+    previous_type TEXT,
+    target_id     UUID REFERENCES product_operation (id),
+    -- Undone operations can be redone until a new one is recorded, which
+    -- discards them.
+    state         TEXT        NOT NULL DEFAULT 'done' CHECK (state IN ('done', 'undone', 'discarded')),
+    -- Tokens from /dummyLogin carry users that do not exist, so the actor is
+    -- not a foreign key.
+    user_id       UUID,
+    api_key_id    UUID,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX product_operation_reception ON product_operation (reception_id, seq);
@@ -0,0 +1,4 @@
+-- Moderators from /dummyLogin do not exist in users, so like the actors of
+-- product operations the acceptor of a report is not a foreign key.
+ALTER TABLE reception_report
+    DROP CONSTRAINT reception_report_accepted_by_fkey;
@@ -0,0 +1,15 @@
+-- Products are ordered by seq, which follows the order they were scanned in
+-- even within one batch. Existing products are numbered by scan time.
+ALTER TABLE product
+    ADD COLUMN seq BIGINT;
+UPDATE product p
+SET seq = o.n
+FROM (SELECT id, row_number() OVER (ORDER BY date_time, id) AS n FROM product) o
+WHERE p.id = o.id;
+ALTER TABLE product
+    ALTER COLUMN seq SET NOT NULL,
+    ALTER COLUMN seq ADD GENERATED BY DEFAULT AS IDENTITY,
+    ADD CONSTRAINT product_seq_unique UNIQUE (seq);
+SELECT setval(pg_get_serial_sequence('product', 'seq'), coalesce(max(seq), 0) + 1, false)
+FROM product;
+CREATE INDEX product_reception_seq ON product (reception_id, seq);
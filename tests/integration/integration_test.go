@@ -35,7 +35,6 @@ func TestE2E(t *testing.T) {
 	waitFor()
 
 	modToken := getToken(t, "moderator")
-	cliToken := getToken(t, "employee")
 
 	var pvz struct {
 		ID   string `json:"id"`
@@ -61,6 +60,8 @@ func TestE2E(t *testing.T) {
 		assert.Equal(t, "Москва", pvz.City)
 	}
 
+	cliToken := assignedEmployee(t, modToken, pvz.ID)
+
 	{
 		reqBody := fmt.Sprintf(`{"pvzId":"%s"}`, pvz.ID)
 		req, _ := http.NewRequest("POST", base+"/receptions", bytes.NewBufferString(reqBody))
@@ -121,3 +122,38 @@ func getToken(t *testing.T, role string) string {
 	assert.NoError(t, json.Unmarshal(data, &out), "couldn't parse /dummyLogin JSON: "+string(data))
 	return out.Token
 }
+
+// assignedEmployee registers a real employee account, since receptions are
+// limited to employees assigned to the PVZ, and assigns it to pvzID.
+func assignedEmployee(t *testing.T, modToken, pvzID string) string {
+	email := fmt.Sprintf("e2e-%d@pvz.ru", time.Now().UnixNano())
+	payload := fmt.Sprintf(`{"email":"%s","password":"E2e-password","role":"employee"}`, email)
+	resp, err := http.Post(base+"/register", "application/json", bytes.NewBufferString(payload))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	assert.Equalf(t, http.StatusCreated, resp.StatusCode, "Register returned %d:\n%s", resp.StatusCode, data)
+	var pair struct{ Token string }
+	assert.NoError(t, json.Unmarshal(data, &pair))
+
+	req, _ := http.NewRequest("GET", base+"/users?q="+email, nil)
+	req.Header.Set("Authorization", "Bearer "+modToken)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	var users []struct{ ID string }
+	data, _ = io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(data, &users), string(data))
+	if !assert.Len(t, users, 1) {
+		t.FailNow()
+	}
+
+	req, _ = http.NewRequest("POST", base+"/pvz/"+pvzID+"/employees", bytes.NewBufferString(fmt.Sprintf(`{"userId":"%s"}`, users[0].ID)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+modToken)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	return pair.Token
+}